@@ -1,14 +1,18 @@
 package app
 
 import (
+	"encoding/json"
+	"strings"
+
 	"github.com/fletaio/fleta_testnet/common"
-	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/process/admin"
 	"github.com/fletaio/fleta_testnet/process/formulator"
 	"github.com/fletaio/fleta_testnet/process/gateway"
 	"github.com/fletaio/fleta_testnet/process/payment"
 	"github.com/fletaio/fleta_testnet/process/vault"
+	"github.com/fletaio/fleta_testnet/service/apiserver"
 )
 
 // FletaApp is app
@@ -16,18 +20,18 @@ type FletaApp struct {
 	*types.ApplicationBase
 	pm      types.ProcessManager
 	cn      types.Provider
-	addrMap map[string]common.Address
+	genesis *Genesis
 }
 
-// NewFletaApp returns a FletaApp
+// NewFletaApp returns a FletaApp with the default genesis
 func NewFletaApp() *FletaApp {
+	return NewFletaAppWithGenesis(DefaultGenesis())
+}
+
+// NewFletaAppWithGenesis returns a FletaApp that initializes the chain by the genesis
+func NewFletaAppWithGenesis(g *Genesis) *FletaApp {
 	return &FletaApp{
-		addrMap: map[string]common.Address{
-			"fleta.gateway":    common.MustParseAddress("3CUsUpv9v"),
-			"fleta.formulator": common.MustParseAddress("5PxjxeqJq"),
-			"fleta.payment":    common.MustParseAddress("7bScSUkTk"),
-			"fleta.vault":      common.MustParseAddress("9nvUvJfcf"),
-		},
+		genesis: g,
 	}
 }
 
@@ -45,39 +49,62 @@ func (app *FletaApp) Version() string {
 func (app *FletaApp) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
 	app.pm = pm
 	app.cn = cn
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else if c, is := pm.(*chain.Chain); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("chain")
+		if err != nil {
+			return err
+		}
+		// the transaction is the json object of types.TransactionJSON
+		// or the hex string of the encoded transaction that is followed by hex strings of signatures
+		s.Set("simulate", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() < 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			var tj types.TransactionJSON
+			if strings.HasPrefix(arg0, "{") {
+				if err := json.Unmarshal([]byte(arg0), &tj); err != nil {
+					return nil, err
+				}
+			} else {
+				bs, err := json.Marshal(arg0)
+				if err != nil {
+					return nil, err
+				}
+				tj.Tx = bs
+				for i := 1; i < arg.Len(); i++ {
+					str, err := arg.String(i)
+					if err != nil {
+						return nil, err
+					}
+					sig, err := common.ParseSignature(str)
+					if err != nil {
+						return nil, err
+					}
+					tj.Signatures = append(tj.Signatures, sig)
+				}
+			}
+			return app.Simulate(c, &tj)
+		})
+	}
 	return nil
 }
 
 // InitGenesis initializes genesis data
 func (app *FletaApp) InitGenesis(ctw *types.ContextWrapper) error {
-	rewardPolicy := &formulator.RewardPolicy{
-		RewardPerBlock:        amount.NewCoinAmount(0, 951293759512937600), // 3%
-		PayRewardEveryBlocks:  172800,                                      // 1 day
-		AlphaEfficiency1000:   1000,                                        // 100%
-		SigmaEfficiency1000:   1150,                                        // 115%
-		OmegaEfficiency1000:   1300,                                        // 130%
-		HyperEfficiency1000:   1300,                                        // 130%
-		StakingEfficiency1000: 700,                                         // 70%
-	}
-	alphaPolicy := &formulator.AlphaPolicy{
-		AlphaCreationLimitHeight:  5184000,                         // 30 days
-		AlphaCreationAmount:       amount.NewCoinAmount(200000, 0), // 200,000 FLETA
-		AlphaUnlockRequiredBlocks: 2592000,                         // 15 days
-	}
-	sigmaPolicy := &formulator.SigmaPolicy{
-		SigmaRequiredAlphaBlocks:  5184000, // 30 days
-		SigmaRequiredAlphaCount:   4,       // 4 Alpha (800,000 FLETA)
-		SigmaUnlockRequiredBlocks: 2592000, // 15 days
-	}
-	omegaPolicy := &formulator.OmegaPolicy{
-		OmegaRequiredSigmaBlocks:  5184000, // 30 days
-		OmegaRequiredSigmaCount:   2,       // 2 Sigma (1,600,000 FLETA)
-		OmegaUnlockRequiredBlocks: 2592000, // 15 days
-	}
-	hyperPolicy := &formulator.HyperPolicy{
-		HyperCreationAmount:         amount.NewCoinAmount(5000000, 0), // 5,000,000 FLETA
-		HyperUnlockRequiredBlocks:   2592000,                          // 15 days
-		StakingUnlockRequiredBlocks: 2592000,                          // 15 days
+	g := app.genesis
+	if err := g.Validate(); err != nil {
+		return err
 	}
 
 	if p, err := app.pm.ProcessByName("fleta.admin"); err != nil {
@@ -85,7 +112,7 @@ func (app *FletaApp) InitGenesis(ctw *types.ContextWrapper) error {
 	} else if ap, is := p.(*admin.Admin); !is {
 		return types.ErrNotExistProcess
 	} else {
-		if err := ap.InitAdmin(ctw, app.addrMap); err != nil {
+		if err := ap.InitAdmin(ctw, g.AdminAddressMap); err != nil {
 			return err
 		}
 	}
@@ -95,11 +122,11 @@ func (app *FletaApp) InitGenesis(ctw *types.ContextWrapper) error {
 		return types.ErrNotExistProcess
 	} else {
 		if err := fp.InitPolicy(ctw,
-			rewardPolicy,
-			alphaPolicy,
-			sigmaPolicy,
-			omegaPolicy,
-			hyperPolicy,
+			g.rewardPolicy(),
+			g.alphaPolicy(),
+			g.sigmaPolicy(),
+			g.omegaPolicy(),
+			g.hyperPolicy(),
 		); err != nil {
 			return err
 		}
@@ -109,9 +136,7 @@ func (app *FletaApp) InitGenesis(ctw *types.ContextWrapper) error {
 	} else if pp, is := p.(*payment.Payment); !is {
 		return types.ErrNotExistProcess
 	} else {
-		if err := pp.InitTopics(ctw, []string{
-			"fleta.formulator.server.cost",
-		}); err != nil {
+		if err := pp.InitTopics(ctw, g.Topics); err != nil {
 			return err
 		}
 	}
@@ -120,11 +145,7 @@ func (app *FletaApp) InitGenesis(ctw *types.ContextWrapper) error {
 	} else if fp, is := p.(*gateway.Gateway); !is {
 		return types.ErrNotExistProcess
 	} else {
-		if err := fp.InitPolicy(ctw,
-			&gateway.Policy{
-				WithdrawFee: amount.NewCoinAmount(30, 0),
-			},
-		); err != nil {
+		if err := fp.InitPolicy(ctw, g.gatewayPolicy()); err != nil {
 			return err
 		}
 	}
@@ -133,28 +154,23 @@ func (app *FletaApp) InitGenesis(ctw *types.ContextWrapper) error {
 	} else if sp, is := p.(*vault.Vault); !is {
 		return types.ErrNotExistProcess
 	} else {
-		if err := sp.InitPolicy(ctw,
-			&vault.Policy{
-				AccountCreationAmount: amount.NewCoinAmount(10, 0),
-			},
-		); err != nil {
+		if err := sp.InitPolicy(ctw, g.vaultPolicy()); err != nil {
 			return err
 		}
-
-		totalSupply := amount.NewCoinAmount(2000000000, 0)
-		alphaCreated := alphaPolicy.AlphaCreationAmount.MulC(189)
-		sigmaCreated := alphaPolicy.AlphaCreationAmount.MulC(int64(sigmaPolicy.SigmaRequiredAlphaCount)).MulC(108)
-		hyperCreated := hyperPolicy.HyperCreationAmount.MulC(6)
-		totalDeligated := amount.NewCoinAmount(50585413, 290667405989600000)
-		totalProvided := amount.NewCoinAmount(31076795, 184877310172010000)
-		gatewaySupply := totalSupply.Sub(alphaCreated).Sub(sigmaCreated).Sub(hyperCreated).Sub(totalDeligated).Sub(totalProvided)
-		addSingleAccount(sp, ctw, common.MustParsePublicHash("38dWpxjJY1RwqyzCfhuaTT9YjyyuxJktaWhRBq8XUZ5"), common.MustParseAddress("3CUsUpv9v"), "fleta.gateway", gatewaySupply)
-		addSingleAccount(sp, ctw, common.MustParsePublicHash("4RBfjoFaWGnKqSEaZ68djqceGmkMkCn4BnhYiEoJ5mv"), common.MustParseAddress("5PxjxeqJq"), "fleta.formulator", amount.NewCoinAmount(0, 0))
-		addSingleAccount(sp, ctw, common.MustParsePublicHash("2v2cC7uxoWP4wtvexV2FMM8C7gDSMrpwDQV9cz7t1f2"), common.MustParseAddress("7bScSUkTk"), "fleta.payment", amount.NewCoinAmount(0, 0))
-		addSingleAccount(sp, ctw, common.MustParsePublicHash("3GJBaEiHyjFyoT9PgUW7bLe75urSXYjd4Pegs56mxSa"), common.MustParseAddress("9nvUvJfcf"), "fleta.vault", amount.NewCoinAmount(0, 0))
-
-		setupSigmaFormulator(sp, ctw, sigmaPolicy, alphaPolicy)
-		setupSingleAccunt(sp, ctw)
+		for _, ga := range g.Accounts {
+			if err := addSingleAccount(sp, ctw, ga); err != nil {
+				return err
+			}
+		}
+		for _, gf := range g.Formulators {
+			acc, err := g.formulatorAccount(gf)
+			if err != nil {
+				return err
+			}
+			if err := ctw.CreateAccount(acc); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -164,34 +180,19 @@ func (app *FletaApp) OnLoadChain(loader types.LoaderWrapper) error {
 	return nil
 }
 
-func addSingleAccount(sp *vault.Vault, ctw *types.ContextWrapper, KeyHash common.PublicHash, addr common.Address, name string, am *amount.Amount) {
+func addSingleAccount(sp *vault.Vault, ctw *types.ContextWrapper, ga *GenesisAccount) error {
 	acc := &vault.SingleAccount{
-		Address_: addr,
-		Name_:    name,
-		KeyHash:  KeyHash,
+		Address_: ga.Address,
+		Name_:    ga.Name,
+		KeyHash:  ga.KeyHash,
 	}
 	if err := ctw.CreateAccount(acc); err != nil {
-		panic(err)
+		return err
 	}
-	if !am.IsZero() {
-		if err := sp.AddBalance(ctw, acc.Address(), am); err != nil {
-			panic(err)
+	if ga.Balance != nil && !ga.Balance.IsZero() {
+		if err := sp.AddBalance(ctw, acc.Address(), ga.Balance); err != nil {
+			return err
 		}
 	}
-}
-
-func addSigmaFormulator(sp *vault.Vault, ctw *types.ContextWrapper, sigmaPolicy *formulator.SigmaPolicy, alphaPolicy *formulator.AlphaPolicy, KeyHash common.PublicHash, GenHash common.PublicHash, addr common.Address, name string) {
-	acc := &formulator.FormulatorAccount{
-		Address_:       addr,
-		Name_:          name,
-		FormulatorType: formulator.SigmaFormulatorType,
-		KeyHash:        KeyHash,
-		GenHash:        GenHash,
-		Amount:         alphaPolicy.AlphaCreationAmount.MulC(int64(sigmaPolicy.SigmaRequiredAlphaCount)),
-		PreHeight:      0,
-		UpdatedHeight:  0,
-	}
-	if err := ctw.CreateAccount(acc); err != nil {
-		panic(err)
-	}
+	return nil
 }
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/core/chain"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+// SimulateResult is the result of the transaction that is executed on the current state without storing it
+// Fee, Events and BalanceChanges are empty when the transaction is failed
+// Success is not set when signers are given instead of signatures because the transaction can be failed by its signatures
+type SimulateResult struct {
+	TxHash             hash.Hash256           `json:"tx_hash"`
+	Success            bool                   `json:"success"`
+	SignatureUnchecked bool                   `json:"signature_unchecked,omitempty"`
+	Error              string                 `json:"error,omitempty"`
+	Fee                *amount.Amount         `json:"fee"`
+	Events             []types.Event          `json:"events"`
+	BalanceChanges     []*vault.BalanceChange `json:"balance_changes"`
+}
+
+// Simulate validates and executes the transaction on a new context of the current state of the chain
+// the context is dropped after the execution so the transaction is not stored or pushed to the pool
+func (app *FletaApp) Simulate(cn *chain.Chain, tj *types.TransactionJSON) (*SimulateResult, error) {
+	p, err := app.pm.ProcessByName("fleta.vault")
+	if err != nil {
+		return nil, err
+	}
+	vp, is := p.(*vault.Vault)
+	if !is {
+		return nil, types.ErrNotExistProcess
+	}
+
+	ChainID := app.cn.ChainID()
+	t, tx, err := tj.Transaction(ChainID)
+	if err != nil {
+		return nil, err
+	}
+	TxHash := types.HashTransactionByType(ChainID, t, tx)
+	signers, err := tj.SignerHashes(TxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	step, err := cn.TraceTransaction(t, tx, signers, 0)
+	if err != nil {
+		return nil, err
+	}
+	res := &SimulateResult{
+		TxHash:             TxHash,
+		Success:            step.Err == nil && !tj.IsSignatureUnchecked(),
+		SignatureUnchecked: tj.IsSignatureUnchecked(),
+		Fee:                amount.NewCoinAmount(0, 0),
+		Events:             []types.Event{},
+		BalanceChanges:     []*vault.BalanceChange{},
+	}
+	if step.Err != nil {
+		res.Error = step.Err.Error()
+		return res, nil
+	}
+	if step.Diff == nil {
+		return res, nil
+	}
+	res.Fee = vp.CollectedFeeChange(step.Diff)
+	res.Events = step.Diff.Events
+	res.BalanceChanges = vp.BalanceChanges(step.Diff)
+	return res, nil
+}
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/key"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	_ "github.com/fletaio/fleta_testnet/core/backend/buntdb_driver"
+	"github.com/fletaio/fleta_testnet/core/chain"
+	"github.com/fletaio/fleta_testnet/core/pile"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
+	"github.com/fletaio/fleta_testnet/process/admin"
+	"github.com/fletaio/fleta_testnet/process/formulator"
+	"github.com/fletaio/fleta_testnet/process/gateway"
+	"github.com/fletaio/fleta_testnet/process/payment"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+type testConsensus struct {
+	chain.ConsensusBase
+}
+
+func (cs *testConsensus) Init(cn *chain.Chain, ct chain.Committer) error {
+	return nil
+}
+
+func TestSimulateTransfer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	k, err := key.NewMemoryKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := common.NewAddress(0, 1, 0)
+	to := common.NewAddress(0, 2, 0)
+	g := NewGenesis()
+	for i, name := range []string{"fleta.gateway", "fleta.formulator", "fleta.payment", "fleta.vault"} {
+		g.AdminAddressMap[name] = common.NewAddress(0, uint16(i+10), 0)
+	}
+	g.Accounts = []*GenesisAccount{
+		{Address: from, Name: "from", KeyHash: common.NewPublicHash(k.PublicKey()), Balance: amount.NewCoinAmount(100, 0)},
+		{Address: to, Name: "to", KeyHash: common.NewPublicHash(k.PublicKey())},
+	}
+
+	back, err := backend.Create("buntdb", filepath.Join(dir, "context"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdb, err := pile.Open(filepath.Join(dir, "chain"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := chain.NewStore(back, cdb, 1, "TEST", "testchain", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := NewFletaAppWithGenesis(g)
+	cn := chain.NewChain(&testConsensus{}, app, st)
+	cn.MustAddProcess(admin.NewAdmin(1))
+	vp := vault.NewVault(2)
+	cn.MustAddProcess(vp)
+	cn.MustAddProcess(formulator.NewFormulator(3))
+	cn.MustAddProcess(gateway.NewGateway(4))
+	cn.MustAddProcess(payment.NewPayment(5))
+	if err := cn.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer cn.Close()
+
+	TxType, err := encoding.Factory("transaction").TypeOf(&vault.Transfer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fee := vp.GetDefaultFee(types.NewLoaderWrapper(vp.ID(), types.NewContext(st)))
+
+	tests := []struct {
+		name    string
+		amount  *amount.Amount
+		success bool
+	}{
+		{"valid", amount.NewCoinAmount(10, 0), true},
+		{"insufficient balance", amount.NewCoinAmount(1000, 0), false},
+	}
+	for _, tt := range tests {
+		tx := &vault.Transfer{
+			Timestamp_: uint64(time.Now().UnixNano()),
+			From_:      from,
+			To:         to,
+			Amount:     tt.amount,
+		}
+		bs, err := types.EncodeTransaction(st.ChainID(), TxType, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := k.Sign(types.HashTransactionByType(st.ChainID(), TxType, tx))
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := json.Marshal(hex.EncodeToString(bs))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := app.Simulate(cn, &types.TransactionJSON{
+			Tx:         raw,
+			Signatures: []common.Signature{sig},
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if res.Success != tt.success {
+			t.Errorf("%s: got success %v, want %v", tt.name, res.Success, tt.success)
+		}
+		if !tt.success {
+			if res.Error == "" {
+				t.Errorf("%s: error is empty", tt.name)
+			}
+			if !res.Fee.IsZero() || len(res.BalanceChanges) != 0 {
+				t.Errorf("%s: got changes of the failed transaction", tt.name)
+			}
+			continue
+		}
+		if !res.Fee.Equal(fee) {
+			t.Errorf("%s: got fee %v, want %v", tt.name, res.Fee, fee)
+		}
+		changes := map[common.Address]*amount.Amount{}
+		for _, c := range res.BalanceChanges {
+			changes[c.Address] = c.After.Sub(c.Before)
+		}
+		if am := changes[from]; am == nil || !am.Equal(amount.NewCoinAmount(0, 0).Sub(tt.amount).Sub(fee)) {
+			t.Errorf("%s: got balance change of the sender %v", tt.name, am)
+		}
+		if am := changes[to]; am == nil || !am.Equal(tt.amount) {
+			t.Errorf("%s: got balance change of the receiver %v", tt.name, am)
+		}
+	}
+
+	// the simulated transaction is not applied to the chain
+	if am := vp.Balance(types.NewContext(st), from); !am.Equal(amount.NewCoinAmount(100, 0)) {
+		t.Errorf("got balance %v, want 100", am)
+	}
+}
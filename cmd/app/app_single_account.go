@@ -5,14 +5,16 @@ import (
 
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/amount"
-	"github.com/fletaio/fleta_testnet/core/types"
-	"github.com/fletaio/fleta_testnet/process/vault"
 )
 
-func setupSingleAccunt(sp *vault.Vault, ctw *types.ContextWrapper) {
+func defaultSingleAccounts() []*GenesisAccount {
+	list := []*GenesisAccount{}
 	for i := 0; i < 40000; i++ {
-		addSingleAccount(sp, ctw, common.MustParsePublicHash("2RqGkxiHZ4NopN9QxKgw93RuSrxX2NnLjv1q1aFDdV9"), common.NewAddress(0, uint16(i+21000), 0), strconv.Itoa(i+1000), amount.MustParseAmount("10000000"))
-		addSingleAccount(sp, ctw, common.MustParsePublicHash("2RqGkxiHZ4NopN9QxKgw93RuSrxX2NnLjv1q1aFDdV9"), common.NewAddress(0, uint16(i+21000), 1), "a"+strconv.Itoa(i+1000), amount.MustParseAmount("10000000"))
-		addSingleAccount(sp, ctw, common.MustParsePublicHash("2RqGkxiHZ4NopN9QxKgw93RuSrxX2NnLjv1q1aFDdV9"), common.NewAddress(0, uint16(i+21000), 2), "b"+strconv.Itoa(i+1000), amount.MustParseAmount("10000000"))
+		list = append(list,
+			&GenesisAccount{Address: common.NewAddress(0, uint16(i+21000), 0), Name: strconv.Itoa(i + 1000), KeyHash: common.MustParsePublicHash("2RqGkxiHZ4NopN9QxKgw93RuSrxX2NnLjv1q1aFDdV9"), Balance: amount.MustParseAmount("10000000")},
+			&GenesisAccount{Address: common.NewAddress(0, uint16(i+21000), 1), Name: "a" + strconv.Itoa(i+1000), KeyHash: common.MustParsePublicHash("2RqGkxiHZ4NopN9QxKgw93RuSrxX2NnLjv1q1aFDdV9"), Balance: amount.MustParseAmount("10000000")},
+			&GenesisAccount{Address: common.NewAddress(0, uint16(i+21000), 2), Name: "b" + strconv.Itoa(i+1000), KeyHash: common.MustParsePublicHash("2RqGkxiHZ4NopN9QxKgw93RuSrxX2NnLjv1q1aFDdV9"), Balance: amount.MustParseAmount("10000000")},
+		)
 	}
+	return list
 }
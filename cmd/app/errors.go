@@ -0,0 +1,11 @@
+package app
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidGenesisPolicy     = errors.New("invalid genesis policy")
+	ErrInvalidFormulatorType    = errors.New("invalid formulator type")
+	ErrDuplicatedGenesisAddress = errors.New("duplicated genesis address")
+	ErrDuplicatedGenesisName    = errors.New("duplicated genesis name")
+)
@@ -0,0 +1,310 @@
+package app
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/process/formulator"
+	"github.com/fletaio/fleta_testnet/process/gateway"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+// Genesis describes the initial state of the chain
+type Genesis struct {
+	AdminAddressMap map[string]common.Address `json:"admin_address_map"`
+	RewardPolicy    *GenesisRewardPolicy      `json:"reward_policy"`
+	AlphaPolicy     *GenesisAlphaPolicy       `json:"alpha_policy"`
+	SigmaPolicy     *GenesisSigmaPolicy       `json:"sigma_policy"`
+	OmegaPolicy     *GenesisOmegaPolicy       `json:"omega_policy"`
+	HyperPolicy     *GenesisHyperPolicy       `json:"hyper_policy"`
+	GatewayPolicy   *GenesisGatewayPolicy     `json:"gateway_policy"`
+	VaultPolicy     *GenesisVaultPolicy       `json:"vault_policy"`
+	Topics          []string                  `json:"topics"`
+	Accounts        []*GenesisAccount         `json:"accounts"`
+	Formulators     []*GenesisFormulator      `json:"formulators"`
+}
+
+// GenesisRewardPolicy is the reward policy of the formulator process
+type GenesisRewardPolicy struct {
+	RewardPerBlock        *amount.Amount `json:"reward_per_block"`
+	PayRewardEveryBlocks  uint32         `json:"pay_reward_every_blocks"`
+	AlphaEfficiency1000   uint32         `json:"alpha_efficiency_1000"`
+	SigmaEfficiency1000   uint32         `json:"sigma_efficiency_1000"`
+	OmegaEfficiency1000   uint32         `json:"omega_efficiency_1000"`
+	HyperEfficiency1000   uint32         `json:"hyper_efficiency_1000"`
+	StakingEfficiency1000 uint32         `json:"staking_efficiency_1000"`
+}
+
+// GenesisAlphaPolicy is the alpha policy of the formulator process
+type GenesisAlphaPolicy struct {
+	AlphaCreationLimitHeight  uint32         `json:"alpha_creation_limit_height"`
+	AlphaCreationAmount       *amount.Amount `json:"alpha_creation_amount"`
+	AlphaUnlockRequiredBlocks uint32         `json:"alpha_unlock_required_blocks"`
+}
+
+// GenesisSigmaPolicy is the sigma policy of the formulator process
+type GenesisSigmaPolicy struct {
+	SigmaRequiredAlphaBlocks  uint32 `json:"sigma_required_alpha_blocks"`
+	SigmaRequiredAlphaCount   uint32 `json:"sigma_required_alpha_count"`
+	SigmaUnlockRequiredBlocks uint32 `json:"sigma_unlock_required_blocks"`
+}
+
+// GenesisOmegaPolicy is the omega policy of the formulator process
+type GenesisOmegaPolicy struct {
+	OmegaRequiredSigmaBlocks  uint32 `json:"omega_required_sigma_blocks"`
+	OmegaRequiredSigmaCount   uint32 `json:"omega_required_sigma_count"`
+	OmegaUnlockRequiredBlocks uint32 `json:"omega_unlock_required_blocks"`
+}
+
+// GenesisHyperPolicy is the hyper policy of the formulator process
+type GenesisHyperPolicy struct {
+	HyperCreationAmount         *amount.Amount `json:"hyper_creation_amount"`
+	HyperUnlockRequiredBlocks   uint32         `json:"hyper_unlock_required_blocks"`
+	StakingUnlockRequiredBlocks uint32         `json:"staking_unlock_required_blocks"`
+}
+
+// GenesisGatewayPolicy is the policy of the gateway process
+type GenesisGatewayPolicy struct {
+	WithdrawFee          *amount.Amount `json:"withdraw_fee"`
+	BatchInterval        uint32         `json:"batch_interval"`
+	MaxBatchSize         uint16         `json:"max_batch_size"`
+	WithdrawFeeRatio1000 uint32         `json:"withdraw_fee_ratio_1000"`
+	MinWithdrawFee       *amount.Amount `json:"min_withdraw_fee"`
+	MaxWithdrawFee       *amount.Amount `json:"max_withdraw_fee"`
+	AddressDailyLimit    *amount.Amount `json:"address_daily_limit"`
+	GlobalDailyLimit     *amount.Amount `json:"global_daily_limit"`
+	BlocksPerDay         uint32         `json:"blocks_per_day"`
+}
+
+// GenesisVaultPolicy is the policy of the vault process
+type GenesisVaultPolicy struct {
+	AccountCreationAmount *amount.Amount `json:"account_creation_amount"`
+}
+
+// GenesisAccount is a single account of the genesis
+type GenesisAccount struct {
+	Address common.Address    `json:"address"`
+	Name    string            `json:"name"`
+	KeyHash common.PublicHash `json:"key_hash"`
+	Balance *amount.Amount    `json:"balance,omitempty"`
+}
+
+// GenesisFormulator is a formulator account of the genesis
+// the amount is derived from the policies of the type when it is empty
+type GenesisFormulator struct {
+	Address         common.Address          `json:"address"`
+	Name            string                  `json:"name"`
+	Type            string                  `json:"type"`
+	KeyHash         common.PublicHash       `json:"key_hash"`
+	GenHash         common.PublicHash       `json:"gen_hash"`
+	Amount          *amount.Amount          `json:"amount,omitempty"`
+	ValidatorPolicy *GenesisValidatorPolicy `json:"validator_policy,omitempty"`
+}
+
+// GenesisValidatorPolicy is the validator policy of the hyper formulator
+type GenesisValidatorPolicy struct {
+	CommissionRatio1000 uint32         `json:"commission_ratio_1000"`
+	MinimumStaking      *amount.Amount `json:"minimum_staking"`
+	PayOutInterval      uint32         `json:"pay_out_interval"`
+}
+
+// LoadGenesis loads the genesis from the json file of the path
+func LoadGenesis(path string) (*Genesis, error) {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	g := &Genesis{}
+	if err := json.Unmarshal(bs, g); err != nil {
+		return nil, err
+	}
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// SaveGenesis saves the genesis to the json file of the path
+func SaveGenesis(path string, g *Genesis) error {
+	bs, err := json.MarshalIndent(g, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, bs, 0644)
+}
+
+// Validate checks the genesis
+func (g *Genesis) Validate() error {
+	if g.RewardPolicy == nil || g.RewardPolicy.RewardPerBlock == nil {
+		return ErrInvalidGenesisPolicy
+	}
+	if g.AlphaPolicy == nil || g.AlphaPolicy.AlphaCreationAmount == nil {
+		return ErrInvalidGenesisPolicy
+	}
+	if g.SigmaPolicy == nil || g.OmegaPolicy == nil {
+		return ErrInvalidGenesisPolicy
+	}
+	if g.HyperPolicy == nil || g.HyperPolicy.HyperCreationAmount == nil {
+		return ErrInvalidGenesisPolicy
+	}
+	if g.GatewayPolicy == nil || g.GatewayPolicy.WithdrawFee == nil || g.GatewayPolicy.MinWithdrawFee == nil || g.GatewayPolicy.MaxWithdrawFee == nil || g.GatewayPolicy.AddressDailyLimit == nil || g.GatewayPolicy.GlobalDailyLimit == nil || g.GatewayPolicy.BlocksPerDay == 0 {
+		return ErrInvalidGenesisPolicy
+	}
+	if g.VaultPolicy == nil || g.VaultPolicy.AccountCreationAmount == nil {
+		return ErrInvalidGenesisPolicy
+	}
+	addrMap := map[common.Address]bool{}
+	nameMap := map[string]bool{}
+	check := func(addr common.Address, name string) error {
+		if addrMap[addr] {
+			return ErrDuplicatedGenesisAddress
+		}
+		if nameMap[name] {
+			return ErrDuplicatedGenesisName
+		}
+		addrMap[addr] = true
+		nameMap[name] = true
+		return nil
+	}
+	for _, ga := range g.Accounts {
+		if err := check(ga.Address, ga.Name); err != nil {
+			return err
+		}
+	}
+	for _, gf := range g.Formulators {
+		if err := check(gf.Address, gf.Name); err != nil {
+			return err
+		}
+		ft, err := parseFormulatorType(gf.Type)
+		if err != nil {
+			return err
+		}
+		if ft == formulator.HyperFormulatorType {
+			if gf.ValidatorPolicy == nil || gf.ValidatorPolicy.MinimumStaking == nil {
+				return ErrInvalidGenesisPolicy
+			}
+		}
+	}
+	return nil
+}
+
+func parseFormulatorType(Type string) (formulator.FormulatorType, error) {
+	switch Type {
+	case "alpha":
+		return formulator.AlphaFormulatorType, nil
+	case "sigma":
+		return formulator.SigmaFormulatorType, nil
+	case "omega":
+		return formulator.OmegaFormulatorType, nil
+	case "hyper":
+		return formulator.HyperFormulatorType, nil
+	default:
+		return 0, ErrInvalidFormulatorType
+	}
+}
+
+func (g *Genesis) rewardPolicy() *formulator.RewardPolicy {
+	return &formulator.RewardPolicy{
+		RewardPerBlock:        g.RewardPolicy.RewardPerBlock,
+		PayRewardEveryBlocks:  g.RewardPolicy.PayRewardEveryBlocks,
+		AlphaEfficiency1000:   g.RewardPolicy.AlphaEfficiency1000,
+		SigmaEfficiency1000:   g.RewardPolicy.SigmaEfficiency1000,
+		OmegaEfficiency1000:   g.RewardPolicy.OmegaEfficiency1000,
+		HyperEfficiency1000:   g.RewardPolicy.HyperEfficiency1000,
+		StakingEfficiency1000: g.RewardPolicy.StakingEfficiency1000,
+	}
+}
+
+func (g *Genesis) alphaPolicy() *formulator.AlphaPolicy {
+	return &formulator.AlphaPolicy{
+		AlphaCreationLimitHeight:  g.AlphaPolicy.AlphaCreationLimitHeight,
+		AlphaCreationAmount:       g.AlphaPolicy.AlphaCreationAmount,
+		AlphaUnlockRequiredBlocks: g.AlphaPolicy.AlphaUnlockRequiredBlocks,
+	}
+}
+
+func (g *Genesis) sigmaPolicy() *formulator.SigmaPolicy {
+	return &formulator.SigmaPolicy{
+		SigmaRequiredAlphaBlocks:  g.SigmaPolicy.SigmaRequiredAlphaBlocks,
+		SigmaRequiredAlphaCount:   g.SigmaPolicy.SigmaRequiredAlphaCount,
+		SigmaUnlockRequiredBlocks: g.SigmaPolicy.SigmaUnlockRequiredBlocks,
+	}
+}
+
+func (g *Genesis) omegaPolicy() *formulator.OmegaPolicy {
+	return &formulator.OmegaPolicy{
+		OmegaRequiredSigmaBlocks:  g.OmegaPolicy.OmegaRequiredSigmaBlocks,
+		OmegaRequiredSigmaCount:   g.OmegaPolicy.OmegaRequiredSigmaCount,
+		OmegaUnlockRequiredBlocks: g.OmegaPolicy.OmegaUnlockRequiredBlocks,
+	}
+}
+
+func (g *Genesis) hyperPolicy() *formulator.HyperPolicy {
+	return &formulator.HyperPolicy{
+		HyperCreationAmount:         g.HyperPolicy.HyperCreationAmount,
+		HyperUnlockRequiredBlocks:   g.HyperPolicy.HyperUnlockRequiredBlocks,
+		StakingUnlockRequiredBlocks: g.HyperPolicy.StakingUnlockRequiredBlocks,
+	}
+}
+
+func (g *Genesis) gatewayPolicy() *gateway.Policy {
+	return &gateway.Policy{
+		WithdrawFee:          g.GatewayPolicy.WithdrawFee,
+		BatchInterval:        g.GatewayPolicy.BatchInterval,
+		MaxBatchSize:         g.GatewayPolicy.MaxBatchSize,
+		WithdrawFeeRatio1000: g.GatewayPolicy.WithdrawFeeRatio1000,
+		MinWithdrawFee:       g.GatewayPolicy.MinWithdrawFee,
+		MaxWithdrawFee:       g.GatewayPolicy.MaxWithdrawFee,
+		AddressDailyLimit:    g.GatewayPolicy.AddressDailyLimit,
+		GlobalDailyLimit:     g.GatewayPolicy.GlobalDailyLimit,
+		BlocksPerDay:         g.GatewayPolicy.BlocksPerDay,
+	}
+}
+
+func (g *Genesis) vaultPolicy() *vault.Policy {
+	return &vault.Policy{
+		AccountCreationAmount: g.VaultPolicy.AccountCreationAmount,
+	}
+}
+
+// formulatorAccount returns the formulator account of the genesis formulator
+func (g *Genesis) formulatorAccount(gf *GenesisFormulator) (*formulator.FormulatorAccount, error) {
+	ft, err := parseFormulatorType(gf.Type)
+	if err != nil {
+		return nil, err
+	}
+	acc := &formulator.FormulatorAccount{
+		Address_:       gf.Address,
+		Name_:          gf.Name,
+		FormulatorType: ft,
+		KeyHash:        gf.KeyHash,
+		GenHash:        gf.GenHash,
+		Amount:         gf.Amount,
+		PreHeight:      0,
+		UpdatedHeight:  0,
+	}
+	if acc.Amount == nil {
+		alphaAmount := g.AlphaPolicy.AlphaCreationAmount
+		switch ft {
+		case formulator.AlphaFormulatorType:
+			acc.Amount = alphaAmount
+		case formulator.SigmaFormulatorType:
+			acc.Amount = alphaAmount.MulC(int64(g.SigmaPolicy.SigmaRequiredAlphaCount))
+		case formulator.OmegaFormulatorType:
+			acc.Amount = alphaAmount.MulC(int64(g.SigmaPolicy.SigmaRequiredAlphaCount)).MulC(int64(g.OmegaPolicy.OmegaRequiredSigmaCount))
+		case formulator.HyperFormulatorType:
+			acc.Amount = g.HyperPolicy.HyperCreationAmount
+		}
+	}
+	if ft == formulator.HyperFormulatorType {
+		acc.StakingAmount = amount.NewCoinAmount(0, 0)
+		acc.Policy = &formulator.ValidatorPolicy{
+			CommissionRatio1000: gf.ValidatorPolicy.CommissionRatio1000,
+			MinimumStaking:      gf.ValidatorPolicy.MinimumStaking,
+			PayOutInterval:      gf.ValidatorPolicy.PayOutInterval,
+		}
+	}
+	return acc, nil
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+)
+
+// NewGenesis returns a genesis that has policies of the running network without accounts
+func NewGenesis() *Genesis {
+	return &Genesis{
+		AdminAddressMap: map[string]common.Address{},
+		RewardPolicy: &GenesisRewardPolicy{
+			RewardPerBlock:        amount.NewCoinAmount(0, 951293759512937600), // 3%
+			PayRewardEveryBlocks:  172800,                                      // 1 day
+			AlphaEfficiency1000:   1000,                                        // 100%
+			SigmaEfficiency1000:   1150,                                        // 115%
+			OmegaEfficiency1000:   1300,                                        // 130%
+			HyperEfficiency1000:   1300,                                        // 130%
+			StakingEfficiency1000: 700,                                         // 70%
+		},
+		AlphaPolicy: &GenesisAlphaPolicy{
+			AlphaCreationLimitHeight:  5184000,                         // 30 days
+			AlphaCreationAmount:       amount.NewCoinAmount(200000, 0), // 200,000 FLETA
+			AlphaUnlockRequiredBlocks: 2592000,                         // 15 days
+		},
+		SigmaPolicy: &GenesisSigmaPolicy{
+			SigmaRequiredAlphaBlocks:  5184000, // 30 days
+			SigmaRequiredAlphaCount:   4,       // 4 Alpha (800,000 FLETA)
+			SigmaUnlockRequiredBlocks: 2592000, // 15 days
+		},
+		OmegaPolicy: &GenesisOmegaPolicy{
+			OmegaRequiredSigmaBlocks:  5184000, // 30 days
+			OmegaRequiredSigmaCount:   2,       // 2 Sigma (1,600,000 FLETA)
+			OmegaUnlockRequiredBlocks: 2592000, // 15 days
+		},
+		HyperPolicy: &GenesisHyperPolicy{
+			HyperCreationAmount:         amount.NewCoinAmount(5000000, 0), // 5,000,000 FLETA
+			HyperUnlockRequiredBlocks:   2592000,                          // 15 days
+			StakingUnlockRequiredBlocks: 2592000,                          // 15 days
+		},
+		GatewayPolicy: &GenesisGatewayPolicy{
+			WithdrawFee:          amount.NewCoinAmount(30, 0),
+			BatchInterval:        60,
+			MaxBatchSize:         100,
+			WithdrawFeeRatio1000: 0,
+			MinWithdrawFee:       amount.NewCoinAmount(0, 0),
+			MaxWithdrawFee:       amount.NewCoinAmount(0, 0),
+			AddressDailyLimit:    amount.NewCoinAmount(0, 0),
+			GlobalDailyLimit:     amount.NewCoinAmount(0, 0),
+			BlocksPerDay:         172800, // 1 day
+		},
+		VaultPolicy: &GenesisVaultPolicy{
+			AccountCreationAmount: amount.NewCoinAmount(10, 0),
+		},
+		Topics: []string{
+			"fleta.formulator.server.cost",
+		},
+		Accounts:    []*GenesisAccount{},
+		Formulators: []*GenesisFormulator{},
+	}
+}
+
+// DefaultGenesis returns the genesis of the running network
+func DefaultGenesis() *Genesis {
+	g := NewGenesis()
+	g.AdminAddressMap = map[string]common.Address{
+		"fleta.gateway":    common.MustParseAddress("3CUsUpv9v"),
+		"fleta.formulator": common.MustParseAddress("5PxjxeqJq"),
+		"fleta.payment":    common.MustParseAddress("7bScSUkTk"),
+		"fleta.vault":      common.MustParseAddress("9nvUvJfcf"),
+	}
+
+	totalSupply := amount.NewCoinAmount(2000000000, 0)
+	alphaCreated := g.AlphaPolicy.AlphaCreationAmount.MulC(189)
+	sigmaCreated := g.AlphaPolicy.AlphaCreationAmount.MulC(int64(g.SigmaPolicy.SigmaRequiredAlphaCount)).MulC(108)
+	hyperCreated := g.HyperPolicy.HyperCreationAmount.MulC(6)
+	totalDeligated := amount.NewCoinAmount(50585413, 290667405989600000)
+	totalProvided := amount.NewCoinAmount(31076795, 184877310172010000)
+	gatewaySupply := totalSupply.Sub(alphaCreated).Sub(sigmaCreated).Sub(hyperCreated).Sub(totalDeligated).Sub(totalProvided)
+
+	g.Accounts = []*GenesisAccount{
+		&GenesisAccount{Address: common.MustParseAddress("3CUsUpv9v"), Name: "fleta.gateway", KeyHash: common.MustParsePublicHash("38dWpxjJY1RwqyzCfhuaTT9YjyyuxJktaWhRBq8XUZ5"), Balance: gatewaySupply},
+		&GenesisAccount{Address: common.MustParseAddress("5PxjxeqJq"), Name: "fleta.formulator", KeyHash: common.MustParsePublicHash("4RBfjoFaWGnKqSEaZ68djqceGmkMkCn4BnhYiEoJ5mv")},
+		&GenesisAccount{Address: common.MustParseAddress("7bScSUkTk"), Name: "fleta.payment", KeyHash: common.MustParsePublicHash("2v2cC7uxoWP4wtvexV2FMM8C7gDSMrpwDQV9cz7t1f2")},
+		&GenesisAccount{Address: common.MustParseAddress("9nvUvJfcf"), Name: "fleta.vault", KeyHash: common.MustParsePublicHash("3GJBaEiHyjFyoT9PgUW7bLe75urSXYjd4Pegs56mxSa")},
+	}
+	g.Accounts = append(g.Accounts, defaultSingleAccounts()...)
+	g.Formulators = defaultSigmaFormulators()
+	return g
+}
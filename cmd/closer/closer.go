@@ -1,7 +1,6 @@
 package closer
 
 import (
-	"log"
 	"sync"
 )
 
@@ -50,7 +49,7 @@ func (cm *Manager) CloseAll() {
 	if !cm.isClosed {
 		cm.isClosed = true
 		for i, c := range cm.Closers {
-			log.Println("Close", cm.Names[i])
+			logger.Info("Close", "name", cm.Names[i])
 			c.Close()
 		}
 		cm.wg.Done()
@@ -61,3 +60,8 @@ func (cm *Manager) CloseAll() {
 func (cm *Manager) Wait() {
 	cm.wg.Wait()
 }
+
+// Close closes all closers so that the manager can be added to another manager
+func (cm *Manager) Close() {
+	cm.CloseAll()
+}
@@ -0,0 +1,6 @@
+package closer
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of closing the node
+var logger = logging.New("node")
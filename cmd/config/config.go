@@ -3,7 +3,9 @@ package config
 import (
 	"bytes"
 	"io"
+	"io/ioutil"
 	"os"
+	"reflect"
 
 	toml "github.com/fletaio/fleta_testnet/cmd/config/go-toml"
 )
@@ -32,3 +34,12 @@ func LoadReader(r io.Reader, v interface{}) error {
 	}
 	return nil
 }
+
+// SaveFile writes the config to the file of the path
+func SaveFile(path string, v interface{}) error {
+	bs, err := toml.Marshal(reflect.Indirect(reflect.ValueOf(v)).Interface())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, bs, 0644)
+}
@@ -168,10 +168,8 @@ func main() {
 	}
 	cm.Add("store", st)
 
-	if st.Height() > 0 {
-		if _, err := cdb.GetData(st.Height(), 0); err != nil {
-			panic(err)
-		}
+	if err := st.CheckConsistency(); err != nil {
+		panic(err)
 	}
 
 	cs := pof.NewConsensus(MaxBlocksPerFormulator, ObserverKeys)
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/fletaio/fleta_testnet/cmd/app"
+	"github.com/fletaio/fleta_testnet/cmd/closer"
+	"github.com/fletaio/fleta_testnet/cmd/profile"
+	"github.com/fletaio/fleta_testnet/common/key"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	_ "github.com/fletaio/fleta_testnet/core/backend/badger_driver"
+	_ "github.com/fletaio/fleta_testnet/core/backend/buntdb_driver"
+	"github.com/fletaio/fleta_testnet/core/chain"
+	"github.com/fletaio/fleta_testnet/core/pile"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/pof"
+	"github.com/fletaio/fleta_testnet/service/apiserver"
+	"github.com/fletaio/fleta_testnet/service/explorerservice"
+	"github.com/fletaio/fleta_testnet/service/history"
+)
+
+// errors
+var (
+	errClosed                            = errors.New("closed")
+	errUnknownService                    = errors.New("unknown service")
+	errInvalidGenesisCommand             = errors.New("invalid genesis command: init or hash is required")
+	errTooManyGenesisAccounts            = errors.New("too many genesis accounts")
+	errUnknownDevnetCommand              = errors.New("unknown devnet command")
+	errInvalidScenarioSeed               = errors.New("invalid scenario seed: pool account names derived from it are not allowed")
+	errInvalidScenarioFunder             = errors.New("invalid scenario funder: the address and the key are required")
+	errInvalidScenarioAccountCount       = errors.New("invalid scenario account count: at least two accounts are required")
+	errInvalidScenarioMultiTransferCount = errors.New("invalid scenario multi transfer count")
+	errEmptyScenarioStages               = errors.New("empty scenario stages")
+	errInvalidScenarioStage              = errors.New("invalid scenario stage")
+	errEmptyScenarioMix                  = errors.New("empty scenario mix")
+	errUnknownScenarioTxType             = errors.New("unknown scenario transaction type")
+	errInvalidScenarioWeight             = errors.New("invalid scenario weight")
+	errInvalidScenarioStaking            = errors.New("invalid scenario staking: the hyper formulator is required")
+	errInvalidScenarioBilling            = errors.New("invalid scenario billing: the topic, the payment admin and its key are required")
+	errNotExistPoolAccount               = errors.New("not exist pool account")
+	errTransactionTimeout                = errors.New("transaction timeout")
+	errNotExistReplayBlock               = errors.New("not exist replay block: the block file is required")
+	errInvalidReplayHeight               = errors.New("invalid replay height: blocks of the height are not stored")
+)
+
+// loadKey loads the key from the hex string
+func loadKey(KeyHex string) (key.Key, error) {
+	bs, err := hex.DecodeString(KeyHex)
+	if err != nil {
+		return nil, err
+	}
+	return key.NewMemoryKeyFromBytes(bs)
+}
+
+// loadNodeKey loads the node key from the hex string or the key file of the store root
+// a new key is generated and stored when both of them are not exist
+func loadNodeKey(NodeKeyHex string, StoreRoot string) (key.Key, error) {
+	if len(NodeKeyHex) > 0 {
+		return loadKey(NodeKeyHex)
+	}
+	path := StoreRoot + "/ndkey.key"
+	if bs, err := ioutil.ReadFile(path); err == nil {
+		return key.NewMemoryKeyFromBytes(bs)
+	}
+	k, err := key.NewMemoryKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(StoreRoot, os.ModePerm); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, k.Bytes(), 0600); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// openChain opens the store and initializes the chain with processes and services of the profile and given services
+// blocks that are stored but not applied to the context are connected before returning
+func openChain(pf *profile.Profile, cfg *Config, cm *closer.Manager, services ...types.Service) (*chain.Chain, *pof.Consensus, error) {
+	st, err := openStore(pf, cfg.StoreRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	cm.Add("store", st)
+
+	if err := st.CheckConsistency(); err != nil {
+		return nil, nil, err
+	}
+
+	cn, cs, err := newChain(pf, st)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range pf.Services {
+		s, err := createService(name, cfg, cs)
+		if err != nil {
+			return nil, nil, err
+		}
+		cn.MustAddService(s)
+	}
+	for _, s := range services {
+		cn.MustAddService(s)
+	}
+	if err := cn.Init(); err != nil {
+		return nil, nil, err
+	}
+	addNodeCloser(cm, cn, "chain", cn)
+
+	if err := st.IterBlockAfterContext(func(b *types.Block) error {
+		if cm.IsClosed() {
+			return chain.ErrStoreClosed
+		}
+		if err := cn.ConnectBlock(b, nil); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		if err == chain.ErrStoreClosed {
+			return nil, nil, errClosed
+		}
+		return nil, nil, err
+	}
+	return cn, cs, nil
+}
+
+// openStore opens the context and the pile of the store root
+func openStore(pf *profile.Profile, StoreRoot string) (*chain.Store, error) {
+	back, err := backend.Create("buntdb", StoreRoot+"/context")
+	if err != nil {
+		return nil, err
+	}
+	cdb, err := pile.Open(StoreRoot + "/chain")
+	if err != nil {
+		return nil, err
+	}
+	cdb.SetSyncMode(true)
+	return chain.NewStore(back, cdb, pf.Chain.ChainID, pf.Chain.Symbol, pf.Chain.Usage, pf.Chain.Version)
+}
+
+// newChain returns the chain of the store with the consensus and processes of the profile
+func newChain(pf *profile.Profile, st *chain.Store) (*chain.Chain, *pof.Consensus, error) {
+	ObserverKeys, err := pf.ObserverKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := pf.Genesis()
+	if err != nil {
+		return nil, nil, err
+	}
+	cs := pof.NewConsensus(pf.Chain.MaxBlocksPerFormulator, ObserverKeys)
+	app := app.NewFletaAppWithGenesis(g)
+	cn := chain.NewChain(cs, app, st)
+	pf.MustAddProcesses(cn)
+	return cn, cs, nil
+}
+
+// addNodeCloser replaces closers of the manager with the shutdown sequence of the node
+// the api server is drained first and the closer stops the consensus participation and closes the chain
+// the chain waits the current block commit and closes services and the store after it
+func addNodeCloser(cm *closer.Manager, cn *chain.Chain, name string, c closer.Closer) {
+	cm.RemoveAll()
+	if s, err := cn.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := s.(*apiserver.APIServer); is {
+		cm.Add("apiserver", v)
+	}
+	cm.Add(name, c)
+}
+
+// createService creates the service of the name that is enabled by the profile
+func createService(name string, cfg *Config, cs *pof.Consensus) (types.Service, error) {
+	switch name {
+	case "fleta.apiserver":
+		s := apiserver.NewAPIServer()
+		go s.Run(":" + strconv.Itoa(cfg.APIPort))
+		return s, nil
+	case "fleta.history":
+		db, err := backend.Create("badger", cfg.StoreRoot+"/history")
+		if err != nil {
+			return nil, err
+		}
+		return history.NewHistory(db), nil
+	case "fleta.explorer":
+		return explorerservice.NewBlockExplorer(cfg.StoreRoot+"/explorer", cs, cfg.WebPort)
+	default:
+		return nil, errUnknownService
+	}
+}
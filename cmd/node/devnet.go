@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/fletaio/fleta_testnet/cmd/closer"
+	"github.com/fletaio/fleta_testnet/cmd/config"
+	"github.com/fletaio/fleta_testnet/cmd/profile"
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/key"
+	"github.com/fletaio/fleta_testnet/common/logging"
+	"github.com/fletaio/fleta_testnet/core/chain"
+)
+
+// DevnetNode is a node of the devnet
+type DevnetNode struct {
+	Name    string
+	Command string
+}
+
+// Devnet is the manifest of the devnet directory
+type Devnet struct {
+	Nodes []*DevnetNode
+}
+
+var devnetRunNodeFuncMap = map[string]runNodeFunc{
+	"observer":   runObserver,
+	"formulator": runFormulator,
+	"fullnode":   runFullNode,
+}
+
+func runDevnet(name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	dir := fs.String("dir", "./devnet", "directory of the devnet")
+	observerCount := fs.Int("observers", 3, "number of observers")
+	formulatorCount := fs.Int("formulators", 2, "number of formulators")
+	fullNodeCount := fs.Int("fullnodes", 1, "number of full nodes")
+	accountCount := fs.Int("accounts", 10, "number of single accounts of the genesis")
+	basePort := fs.Int("base-port", 40000, "first port of nodes, each node uses 10 ports from it")
+	reset := fs.Bool("reset", false, "removes the devnet directory before creating it")
+	logLevel := fs.String("log-level", "info", "levels of logs like info,p2p=debug")
+	metricsHost := fs.String("metrics-host", "127.0.0.1", "host of the metrics of all nodes in the devnet")
+	metricsPort := fs.Int("metrics-port", 0, "port of the metrics of all nodes in the devnet, 0 disables it")
+	usePprof := fs.Bool("pprof", false, "serves the pprof profiler on the metrics port")
+	fs.Parse(args)
+
+	if err := logging.SetLevels(*logLevel); err != nil {
+		return err
+	}
+
+	root, err := filepath.Abs(*dir)
+	if err != nil {
+		return err
+	}
+	if *reset {
+		if err := os.RemoveAll(root); err != nil {
+			return err
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "devnet.toml")); os.IsNotExist(err) {
+		if err := initDevnet(root, *observerCount, *formulatorCount, *fullNodeCount, *accountCount, *basePort); err != nil {
+			return err
+		}
+		logger.Info("Devnet created", "dir", root)
+	}
+
+	var dn Devnet
+	if err := config.LoadFile(filepath.Join(root, "devnet.toml"), &dn); err != nil {
+		return err
+	}
+	pf, err := profile.LoadFile(filepath.Join(root, "profile.toml"))
+	if err != nil {
+		return err
+	}
+
+	cm := newSignalManager()
+	defer cm.CloseAll()
+
+	// nodes of the devnet have their own closers so the metrics server is not removed by them
+	if ms := startMetrics(*metricsHost, *metricsPort, *usePprof); ms != nil {
+		cm.Add("metrics", ms)
+	}
+
+	chainMap := map[string]*chain.Chain{}
+	for _, nd := range dn.Nodes {
+		fn, has := devnetRunNodeFuncMap[nd.Command]
+		if !has {
+			return errUnknownDevnetCommand
+		}
+		var cfg Config
+		if err := config.LoadFile(filepath.Join(root, nd.Name, "config.toml"), &cfg); err != nil {
+			return err
+		}
+		ncm := closer.NewManager()
+		cn, err := fn(pf, &cfg, ncm)
+		if err != nil {
+			ncm.CloseAll()
+			if err == errClosed {
+				return nil
+			}
+			return err
+		}
+		cm.Add(nd.Name, ncm)
+		chainMap[nd.Name] = cn
+		logger.Info("Devnet node started", "name", nd.Name, "command", nd.Command, "port", cfg.Port, "observer_port", cfg.ObserverPort, "formulator_port", cfg.FormulatorPort, "api_port", cfg.APIPort)
+	}
+
+	go func() {
+		for !cm.IsClosed() {
+			time.Sleep(10 * time.Second)
+			if cm.IsClosed() {
+				return
+			}
+			for _, nd := range dn.Nodes {
+				p := chainMap[nd.Name].Provider()
+				logger.Info("Devnet node status", "name", nd.Name, "height", p.Height(), "hash", p.LastHash())
+			}
+		}
+	}()
+
+	cm.Wait()
+	return nil
+}
+
+// initDevnet creates keys, the genesis, the profile and node configs of the devnet under the root
+func initDevnet(root string, observerCount int, formulatorCount int, fullNodeCount int, accountCount int, basePort int) error {
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return err
+	}
+
+	g, gk, err := generateGenesis(formulatorCount, "sigma", accountCount, amount.NewCoinAmount(1000000, 0), amount.NewCoinAmount(2000000000, 0))
+	if err != nil {
+		return err
+	}
+	if err := saveGenesisFiles(filepath.Join(root, "genesis.json"), filepath.Join(root, "genesis_keys.json"), g, gk); err != nil {
+		return err
+	}
+
+	pf := &profile.Profile{
+		Chain: profile.ChainParams{
+			ChainID:                     0xFF,
+			Symbol:                      "DEV",
+			Usage:                       "Devnet",
+			Version:                     0x0001,
+			MaxBlocksPerFormulator:      10,
+			ChangeStakingCooldownHeight: 1,
+		},
+		GenesisFile: "genesis.json",
+		Observers:   []*profile.ObserverEntry{},
+		SeedNodeMap: map[string]string{},
+		Processes: []*profile.ProcessEntry{
+			&profile.ProcessEntry{Name: "fleta.admin", ID: 1},
+			&profile.ProcessEntry{Name: "fleta.vault", ID: 2},
+			&profile.ProcessEntry{Name: "fleta.formulator", ID: 3},
+			&profile.ProcessEntry{Name: "fleta.gateway", ID: 4},
+			&profile.ProcessEntry{Name: "fleta.payment", ID: 5},
+		},
+		Services: []string{"fleta.apiserver"},
+	}
+	dn := &Devnet{
+		Nodes: []*DevnetNode{},
+	}
+
+	port := basePort
+	addNode := func(Name string, Command string, cfg *Config) error {
+		dn.Nodes = append(dn.Nodes, &DevnetNode{
+			Name:    Name,
+			Command: Command,
+		})
+		cfg.StoreRoot = filepath.Join(root, Name, "data")
+		cfg.APIPort = port + 2
+		port += 10
+		if err := os.MkdirAll(filepath.Join(root, Name), os.ModePerm); err != nil {
+			return err
+		}
+		return config.SaveFile(filepath.Join(root, Name, "config.toml"), cfg)
+	}
+	newNodeKey := func() (string, common.PublicHash, error) {
+		k, err := key.NewMemoryKey()
+		if err != nil {
+			return "", common.PublicHash{}, err
+		}
+		return hex.EncodeToString(k.Bytes()), common.NewPublicHash(k.PublicKey()), nil
+	}
+
+	for i := 0; i < observerCount; i++ {
+		KeyHex, pubhash, err := newNodeKey()
+		if err != nil {
+			return err
+		}
+		cfg := &Config{
+			KeyHex:         KeyHex,
+			ObserverPort:   port,
+			FormulatorPort: port + 1,
+		}
+		pf.Observers = append(pf.Observers, &profile.ObserverEntry{
+			PublicHash:        pubhash.String(),
+			Address:           "localhost:" + strconv.Itoa(cfg.ObserverPort),
+			FormulatorAddress: "localhost:" + strconv.Itoa(cfg.FormulatorPort),
+		})
+		if err := addNode("observer"+strconv.Itoa(i+1), "observer", cfg); err != nil {
+			return err
+		}
+	}
+	for _, fk := range gk.Formulators {
+		NodeKeyHex, pubhash, err := newNodeKey()
+		if err != nil {
+			return err
+		}
+		cfg := &Config{
+			KeyHex:     fk.GenKeyHex,
+			NodeKeyHex: NodeKeyHex,
+			Formulator: fk.Address,
+			Port:       port,
+		}
+		pf.SeedNodeMap[pubhash.String()] = "localhost:" + strconv.Itoa(cfg.Port)
+		if err := addNode(fk.Name, "formulator", cfg); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < fullNodeCount; i++ {
+		NodeKeyHex, pubhash, err := newNodeKey()
+		if err != nil {
+			return err
+		}
+		cfg := &Config{
+			NodeKeyHex: NodeKeyHex,
+			Port:       port,
+		}
+		pf.SeedNodeMap[pubhash.String()] = "localhost:" + strconv.Itoa(cfg.Port)
+		if err := addNode("fullnode"+strconv.Itoa(i+1), "fullnode", cfg); err != nil {
+			return err
+		}
+	}
+
+	if err := pf.Validate(); err != nil {
+		return err
+	}
+	if err := config.SaveFile(filepath.Join(root, "profile.toml"), pf); err != nil {
+		return err
+	}
+	return config.SaveFile(filepath.Join(root, "devnet.toml"), dn)
+}
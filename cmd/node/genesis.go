@@ -0,0 +1,266 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/fletaio/fleta_testnet/cmd/app"
+	"github.com/fletaio/fleta_testnet/cmd/profile"
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/key"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	"github.com/fletaio/fleta_testnet/core/chain"
+	"github.com/fletaio/fleta_testnet/core/pile"
+	"github.com/fletaio/fleta_testnet/pof"
+)
+
+// GenesisKey is a generated key of an account of the genesis
+// GenKeyHex is the block generation key of the formulator that is hashed to the GenHash
+type GenesisKey struct {
+	Name      string `json:"name"`
+	Address   string `json:"address"`
+	KeyHex    string `json:"key_hex"`
+	GenKeyHex string `json:"gen_key_hex,omitempty"`
+}
+
+// GenesisKeys is generated keys of the genesis
+type GenesisKeys struct {
+	Admins      []*GenesisKey `json:"admins"`
+	Formulators []*GenesisKey `json:"formulators"`
+	Accounts    []*GenesisKey `json:"accounts"`
+}
+
+// LoadGenesisKeys loads generated keys from the json file of the path
+func LoadGenesisKeys(path string) (*GenesisKeys, error) {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	gk := &GenesisKeys{}
+	if err := json.Unmarshal(bs, gk); err != nil {
+		return nil, err
+	}
+	return gk, nil
+}
+
+var genesisAdminNames = []string{
+	"fleta.gateway",
+	"fleta.formulator",
+	"fleta.payment",
+	"fleta.vault",
+}
+
+func runGenesis(name string, args []string) error {
+	if len(args) < 1 {
+		return errInvalidGenesisCommand
+	}
+	switch args[0] {
+	case "init":
+		return runGenesisInit(name+" init", args[1:])
+	case "hash":
+		return runGenesisHash(name+" hash", args[1:])
+	default:
+		return errInvalidGenesisCommand
+	}
+}
+
+func runGenesisInit(name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	outPath := fs.String("out", "./genesis.json", "path of the genesis file")
+	keysPath := fs.String("keys", "./genesis_keys.json", "path of the generated keys")
+	formulatorCount := fs.Int("formulators", 4, "number of formulators")
+	formulatorType := fs.String("formulator-type", "sigma", "type of formulators (alpha, sigma, omega, hyper)")
+	accountCount := fs.Int("accounts", 10, "number of single accounts")
+	balance := fs.String("balance", "1000000", "balance of each single account")
+	supply := fs.String("supply", "2000000000", "balance of the gateway admin account")
+	fs.Parse(args)
+
+	Balance, err := amount.ParseAmount(*balance)
+	if err != nil {
+		return err
+	}
+	Supply, err := amount.ParseAmount(*supply)
+	if err != nil {
+		return err
+	}
+
+	g, gk, err := generateGenesis(*formulatorCount, *formulatorType, *accountCount, Balance, Supply)
+	if err != nil {
+		return err
+	}
+	if err := saveGenesisFiles(*outPath, *keysPath, g, gk); err != nil {
+		return err
+	}
+	fmt.Println("genesis", *outPath, "keys", *keysPath)
+	return nil
+}
+
+// saveGenesisFiles saves the genesis and generated keys of it
+// the key file is only readable by the owner
+func saveGenesisFiles(genesisPath string, keysPath string, g *app.Genesis, gk *GenesisKeys) error {
+	if err := app.SaveGenesis(genesisPath, g); err != nil {
+		return err
+	}
+	bs, err := json.MarshalIndent(gk, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(keysPath, bs, 0600)
+}
+
+// generateGenesis generates a genesis with fresh keys
+// admin accounts of processes come first and the gateway admin has the supply
+func generateGenesis(formulatorCount int, formulatorType string, accountCount int, Balance *amount.Amount, Supply *amount.Amount) (*app.Genesis, *GenesisKeys, error) {
+	g := app.NewGenesis()
+	gk := &GenesisKeys{
+		Admins:      []*GenesisKey{},
+		Formulators: []*GenesisKey{},
+		Accounts:    []*GenesisKey{},
+	}
+
+	if len(genesisAdminNames)+formulatorCount+accountCount > 65535 {
+		return nil, nil, errTooManyGenesisAccounts
+	}
+	var index uint16
+	newAccount := func(name string) (*key.MemoryKey, common.Address, *GenesisKey, error) {
+		k, err := key.NewMemoryKey()
+		if err != nil {
+			return nil, common.Address{}, nil, err
+		}
+		index++
+		addr := common.NewAddress(0, index, 0)
+		return k, addr, &GenesisKey{
+			Name:    name,
+			Address: addr.String(),
+			KeyHex:  hex.EncodeToString(k.Bytes()),
+		}, nil
+	}
+
+	for _, name := range genesisAdminNames {
+		k, addr, ak, err := newAccount(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		ga := &app.GenesisAccount{
+			Address: addr,
+			Name:    name,
+			KeyHash: common.NewPublicHash(k.PublicKey()),
+		}
+		if name == "fleta.gateway" {
+			ga.Balance = Supply
+		}
+		g.AdminAddressMap[name] = addr
+		g.Accounts = append(g.Accounts, ga)
+		gk.Admins = append(gk.Admins, ak)
+	}
+	for i := 0; i < formulatorCount; i++ {
+		k, addr, fk, err := newAccount("formulator" + strconv.Itoa(i+1))
+		if err != nil {
+			return nil, nil, err
+		}
+		genkey, err := key.NewMemoryKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		fk.GenKeyHex = hex.EncodeToString(genkey.Bytes())
+		gf := &app.GenesisFormulator{
+			Address: addr,
+			Name:    fk.Name,
+			Type:    formulatorType,
+			KeyHash: common.NewPublicHash(k.PublicKey()),
+			GenHash: common.NewPublicHash(genkey.PublicKey()),
+		}
+		if formulatorType == "hyper" {
+			gf.ValidatorPolicy = &app.GenesisValidatorPolicy{
+				CommissionRatio1000: 50,
+				MinimumStaking:      amount.NewCoinAmount(100, 0),
+				PayOutInterval:      1,
+			}
+		}
+		g.Formulators = append(g.Formulators, gf)
+		gk.Formulators = append(gk.Formulators, fk)
+	}
+	for i := 0; i < accountCount; i++ {
+		k, addr, ak, err := newAccount("account" + strconv.Itoa(i+1))
+		if err != nil {
+			return nil, nil, err
+		}
+		g.Accounts = append(g.Accounts, &app.GenesisAccount{
+			Address: addr,
+			Name:    ak.Name,
+			KeyHash: common.NewPublicHash(k.PublicKey()),
+			Balance: Balance,
+		})
+		gk.Accounts = append(gk.Accounts, ak)
+	}
+	if err := g.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return g, gk, nil
+}
+
+func runGenesisHash(name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	profilePath := fs.String("profile", "./profile.toml", "path of the network profile")
+	fs.Parse(args)
+
+	pf, err := profile.LoadFile(*profilePath)
+	if err != nil {
+		return err
+	}
+	GenesisHash, err := genesisHash(pf)
+	if err != nil {
+		return err
+	}
+	fmt.Println(GenesisHash)
+	return nil
+}
+
+// genesisHash initializes the chain of the profile in a temporary store and returns the genesis hash of it
+func genesisHash(pf *profile.Profile) (string, error) {
+	ObserverKeys, err := pf.ObserverKeys()
+	if err != nil {
+		return "", err
+	}
+	g, err := pf.Genesis()
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := ioutil.TempDir("", "fleta_genesis")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	back, err := backend.Create("buntdb", dir+"/context")
+	if err != nil {
+		return "", err
+	}
+	cdb, err := pile.Open(dir + "/chain")
+	if err != nil {
+		return "", err
+	}
+	st, err := chain.NewStore(back, cdb, pf.Chain.ChainID, pf.Chain.Symbol, pf.Chain.Usage, pf.Chain.Version)
+	if err != nil {
+		return "", err
+	}
+	cs := pof.NewConsensus(pf.Chain.MaxBlocksPerFormulator, ObserverKeys)
+	cn := chain.NewChain(cs, app.NewFletaAppWithGenesis(g), st)
+	defer cn.Close()
+	pf.MustAddProcesses(cn)
+	if err := cn.Init(); err != nil {
+		return "", err
+	}
+	h, err := st.Hash(0)
+	if err != nil {
+		return "", err
+	}
+	return h.String(), nil
+}
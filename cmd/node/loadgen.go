@@ -0,0 +1,494 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fletaio/fleta_testnet/cmd/closer"
+	"github.com/fletaio/fleta_testnet/cmd/config"
+	"github.com/fletaio/fleta_testnet/cmd/profile"
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/binutil"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/common/key"
+	"github.com/fletaio/fleta_testnet/core/chain"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/formulator"
+	"github.com/fletaio/fleta_testnet/process/payment"
+	"github.com/fletaio/fleta_testnet/process/vault"
+	"github.com/fletaio/fleta_testnet/service/p2p"
+)
+
+// loadgenAccount is an account that signs transactions of the load generator
+type loadgenAccount struct {
+	Name    string
+	Address common.Address
+	Key     key.Key
+}
+
+// loadgen submits transactions of the scenario through the p2p node of the full node
+type loadgen struct {
+	sc              *Scenario
+	cn              *chain.Chain
+	nd              *p2p.Node
+	tr              *txTracker
+	vp              *vault.Vault
+	pp              *payment.Payment
+	funder          *loadgenAccount
+	paymentAdmin    *loadgenAccount
+	pool            []*loadgenAccount
+	HyperFormulator common.Address
+	Topic           uint64
+	FundAmount      *amount.Amount
+	TransferAmount  *amount.Amount
+	StakingAmount   *amount.Amount
+	BillingAmount   *amount.Amount
+	totalWeight     int
+	rnd             *rand.Rand
+}
+
+func runLoadgen(name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	profilePath := fs.String("profile", "./profile.toml", "path of the network profile")
+	configPath := fs.String("config", "./config.toml", "path of the node config")
+	scenarioPath := fs.String("scenario", "./scenario.toml", "path of the load generation scenario")
+	fs.Parse(args)
+
+	pf, err := profile.LoadFile(*profilePath)
+	if err != nil {
+		return err
+	}
+	var cfg Config
+	if err := config.LoadFile(*configPath, &cfg); err != nil {
+		return err
+	}
+	if len(cfg.StoreRoot) == 0 {
+		cfg.StoreRoot = "./" + name + "_data"
+	}
+	if err := setupLogging(&cfg); err != nil {
+		return err
+	}
+	sc, err := LoadScenario(*scenarioPath)
+	if err != nil {
+		return err
+	}
+
+	cm := newSignalManager()
+	defer cm.CloseAll()
+
+	ms := startMetrics(cfg.MetricsHost, cfg.MetricsPort, cfg.UsePprof)
+
+	tr := newTxTracker()
+	cn, nd, err := openFullNode(pf, &cfg, cm, tr)
+	if err != nil {
+		if err == errClosed {
+			return nil
+		}
+		return err
+	}
+	if ms != nil {
+		cm.Add("metrics", ms)
+	}
+	lg, err := newLoadgen(sc, cn, nd, tr)
+	if err != nil {
+		return err
+	}
+	if err := lg.Run(cm, os.Stdout); err != nil {
+		if err == errClosed {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func newLoadgen(sc *Scenario, cn *chain.Chain, nd *p2p.Node, tr *txTracker) (*loadgen, error) {
+	lg := &loadgen{
+		sc: sc,
+		cn: cn,
+		nd: nd,
+		tr: tr,
+	}
+	if p, err := cn.ProcessByName("fleta.vault"); err != nil {
+		return nil, err
+	} else {
+		lg.vp = p.(*vault.Vault)
+	}
+	if sc.HasType("billing") {
+		if p, err := cn.ProcessByName("fleta.payment"); err != nil {
+			return nil, err
+		} else {
+			lg.pp = p.(*payment.Payment)
+		}
+	}
+
+	var err error
+	if lg.funder, err = newLoadgenAccount(sc.Funder, sc.FunderKeyHex); err != nil {
+		return nil, err
+	}
+	if sc.HasType("billing") {
+		if lg.paymentAdmin, err = newLoadgenAccount(sc.PaymentAdmin, sc.PaymentAdminKeyHex); err != nil {
+			return nil, err
+		}
+		lg.Topic = payment.Topic(sc.Topic)
+	}
+	if sc.HasType("staking") {
+		if lg.HyperFormulator, err = common.ParseAddress(sc.HyperFormulator); err != nil {
+			return nil, err
+		}
+	}
+	if lg.FundAmount, err = amount.ParseAmount(sc.FundAmount); err != nil {
+		return nil, err
+	}
+	if lg.TransferAmount, err = amount.ParseAmount(sc.TransferAmount); err != nil {
+		return nil, err
+	}
+	if lg.StakingAmount, err = amount.ParseAmount(sc.StakingAmount); err != nil {
+		return nil, err
+	}
+	if lg.BillingAmount, err = amount.ParseAmount(sc.BillingAmount); err != nil {
+		return nil, err
+	}
+
+	h := hash.Hash([]byte(sc.Seed))
+	lg.rnd = rand.New(rand.NewSource(int64(binutil.LittleEndian.Uint64(h[:]))))
+	for _, m := range sc.Mix {
+		lg.totalWeight += m.Weight
+	}
+
+	lg.pool = make([]*loadgenAccount, 0, sc.AccountCount)
+	for i := 0; i < sc.AccountCount; i++ {
+		h := hash.Hash([]byte(sc.Seed + "#" + strconv.Itoa(i)))
+		k, err := key.NewMemoryKeyFromBytes(h[:])
+		if err != nil {
+			return nil, err
+		}
+		lg.pool = append(lg.pool, &loadgenAccount{
+			Name: sc.accountName(i),
+			Key:  k,
+		})
+	}
+	return lg, nil
+}
+
+// newLoadgenAccount returns the account of the address that is signed by the key of the hex string
+func newLoadgenAccount(addr string, KeyHex string) (*loadgenAccount, error) {
+	Address, err := common.ParseAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	k, err := loadKey(KeyHex)
+	if err != nil {
+		return nil, err
+	}
+	return &loadgenAccount{
+		Address: Address,
+		Key:     k,
+	}, nil
+}
+
+// Run prepares the account pool, runs stages of the scenario and writes the report
+// transactions that are not included in the inclusion timeout after the last stage are rejected as timeout
+func (lg *loadgen) Run(cm *closer.Manager, w io.Writer) error {
+	if err := lg.waitSync(cm); err != nil {
+		return err
+	}
+	if err := lg.setup(cm); err != nil {
+		return err
+	}
+	lg.tr.Reset()
+
+	start := time.Now()
+	lg.runStages(cm)
+	elapsed := time.Now().Sub(start)
+
+	deadline := time.Now().Add(time.Duration(lg.sc.InclusionTimeout) * time.Second)
+	for !cm.IsClosed() && time.Now().Before(deadline) && lg.tr.PendingCount() > 0 {
+		time.Sleep(100 * time.Millisecond)
+	}
+	lg.tr.Expire(fmt.Sprintf("not included in %d seconds", lg.sc.InclusionTimeout))
+	lg.tr.Report(w, elapsed)
+	return nil
+}
+
+// waitSync waits until the last block of the chain is recent enough to accept transactions
+func (lg *loadgen) waitSync(cm *closer.Manager) error {
+	lastLog := time.Now()
+	for {
+		if cm.IsClosed() {
+			return errClosed
+		}
+		p := lg.cn.Provider()
+		if time.Now().UnixNano()-int64(p.LastTimestamp()) < int64(10*time.Second) {
+			logger.Info("Loadgen synced", "height", p.Height())
+			return nil
+		}
+		if time.Now().Sub(lastLog) >= 10*time.Second {
+			logger.Info("Loadgen waits the sync of the chain", "height", p.Height())
+			lastLog = time.Now()
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// setup creates and funds pool accounts that are not ready and subscribes them to the topic when billing is mixed
+func (lg *loadgen) setup(cm *closer.Manager) error {
+	loader := lg.cn.Provider().NewLoaderWrapper(lg.vp.ID())
+
+	ts := []*trackedTx{}
+	for _, acc := range lg.pool {
+		if addr, err := loader.AddressByName(acc.Name); err == nil {
+			acc.Address = addr
+			continue
+		}
+		ts = append(ts, lg.submit("create_account", &vault.CreateAccount{
+			Timestamp_: uint64(time.Now().UnixNano()),
+			From_:      lg.funder.Address,
+			Name:       acc.Name,
+			KeyHash:    common.NewPublicHash(acc.Key.PublicKey()),
+		}, lg.funder.Key))
+	}
+	if len(ts) > 0 {
+		logger.Info("Loadgen creates pool accounts", "count", len(ts))
+		if err := lg.waitAll(cm, ts); err != nil {
+			return err
+		}
+		loader = lg.cn.Provider().NewLoaderWrapper(lg.vp.ID())
+		for _, acc := range lg.pool {
+			addr, err := loader.AddressByName(acc.Name)
+			if err != nil {
+				return errNotExistPoolAccount
+			}
+			acc.Address = addr
+		}
+	}
+
+	ts = []*trackedTx{}
+	tx := &vault.MultiTransfer{}
+	for _, acc := range lg.pool {
+		if !lg.vp.Balance(loader, acc.Address).Less(lg.FundAmount) {
+			continue
+		}
+		tx.To = append(tx.To, acc.Address)
+		tx.Amount = append(tx.Amount, lg.FundAmount)
+		if len(tx.To) >= vault.MaxMultiTransferCount {
+			ts = append(ts, lg.submitFunding(tx))
+			tx = &vault.MultiTransfer{}
+		}
+	}
+	if len(tx.To) > 0 {
+		ts = append(ts, lg.submitFunding(tx))
+	}
+	if len(ts) > 0 {
+		logger.Info("Loadgen funds pool accounts", "txs", len(ts))
+		if err := lg.waitAll(cm, ts); err != nil {
+			return err
+		}
+	}
+
+	if lg.pp != nil {
+		ts = []*trackedTx{}
+		for _, acc := range lg.pool {
+			if _, err := lg.pp.Subscription(loader, lg.Topic, acc.Address); err == nil {
+				continue
+			}
+			ts = append(ts, lg.submit("subscribe", &payment.Subscribe{
+				Timestamp_: uint64(time.Now().UnixNano()),
+				From_:      acc.Address,
+				Topic:      lg.Topic,
+				Amount:     lg.BillingAmount,
+				Period:     lg.sc.BillingPeriod,
+			}, lg.paymentAdmin.Key, acc.Key))
+		}
+		if len(ts) > 0 {
+			logger.Info("Loadgen subscribes pool accounts", "count", len(ts), "topic", lg.sc.Topic)
+			if err := lg.waitAll(cm, ts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (lg *loadgen) submitFunding(tx *vault.MultiTransfer) *trackedTx {
+	tx.Timestamp_ = uint64(time.Now().UnixNano())
+	tx.From_ = lg.funder.Address
+	return lg.submit("fund", tx, lg.funder.Key)
+}
+
+// waitAll waits inclusion of all transactions in the inclusion timeout
+func (lg *loadgen) waitAll(cm *closer.Manager, ts []*trackedTx) error {
+	deadline := time.Now().Add(time.Duration(lg.sc.InclusionTimeout) * time.Second)
+	for _, t := range ts {
+		for {
+			if cm.IsClosed() {
+				return errClosed
+			}
+			wait := deadline.Sub(time.Now())
+			if wait > time.Second {
+				wait = time.Second
+			}
+			if wait <= 0 {
+				return errTransactionTimeout
+			}
+			if _, err := t.Wait(wait); err == nil {
+				break
+			} else if err != errTransactionTimeout {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// runStages submits transactions of the mix at the target TPS of each stage
+// the target is not reached when all workers are busy and the report shows the achieved TPS
+func (lg *loadgen) runStages(cm *closer.Manager) {
+	jobCh := make(chan func(), lg.sc.Workers*4)
+	var wg sync.WaitGroup
+	for i := 0; i < lg.sc.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for fn := range jobCh {
+				fn()
+			}
+		}()
+	}
+	defer func() {
+		close(jobCh)
+		wg.Wait()
+	}()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for i, st := range lg.sc.Stages {
+		logger.Info("Loadgen stage", "stage", i+1, "from_tps", st.FromTPS, "to_tps", st.ToTPS, "duration", st.Duration)
+		begin := time.Now()
+		last := begin
+		budget := 0.0
+		for now := range ticker.C {
+			if cm.IsClosed() {
+				return
+			}
+			elapsed := now.Sub(begin).Seconds()
+			if elapsed >= float64(st.Duration) {
+				break
+			}
+			budget += st.TPS(elapsed) * now.Sub(last).Seconds()
+			last = now
+			for ; budget >= 1; budget-- {
+				jobCh <- lg.nextJob()
+			}
+		}
+	}
+}
+
+// nextJob builds a transaction of the type that is picked by weights of the mix and returns the job that submits it
+func (lg *loadgen) nextJob() func() {
+	Type := lg.pickType()
+	from := lg.pool[lg.rnd.Intn(len(lg.pool))]
+	Timestamp := uint64(time.Now().UnixNano())
+
+	var tx types.Transaction
+	signer := from.Key
+	switch Type {
+	case "transfer":
+		tx = &vault.Transfer{
+			Timestamp_: Timestamp,
+			From_:      from.Address,
+			To:         lg.pickOthers(from, 1)[0].Address,
+			Amount:     lg.TransferAmount,
+		}
+	case "multi_transfer":
+		mt := &vault.MultiTransfer{
+			Timestamp_: Timestamp,
+			From_:      from.Address,
+		}
+		for _, acc := range lg.pickOthers(from, lg.sc.MultiTransferCount) {
+			mt.To = append(mt.To, acc.Address)
+			mt.Amount = append(mt.Amount, lg.TransferAmount)
+		}
+		tx = mt
+	case "create_account":
+		tx = &vault.CreateAccount{
+			Timestamp_: Timestamp,
+			From_:      from.Address,
+			Name:       lg.sc.Seed + "." + strconv.FormatUint(Timestamp, 36),
+			KeyHash:    common.NewPublicHash(from.Key.PublicKey()),
+		}
+	case "staking":
+		tx = &formulator.Staking{
+			Timestamp_:      Timestamp,
+			From_:           from.Address,
+			HyperFormulator: lg.HyperFormulator,
+			Amount:          lg.StakingAmount,
+		}
+	case "billing":
+		tx = &payment.Billing{
+			Timestamp_: Timestamp,
+			From_:      lg.paymentAdmin.Address,
+			Topic:      lg.Topic,
+			To:         from.Address,
+			Amount:     lg.BillingAmount,
+		}
+		signer = lg.paymentAdmin.Key
+	}
+	return func() {
+		lg.submit(Type, tx, signer)
+	}
+}
+
+func (lg *loadgen) pickType() string {
+	r := lg.rnd.Intn(lg.totalWeight)
+	for _, m := range lg.sc.Mix {
+		if r < m.Weight {
+			return m.Type
+		}
+		r -= m.Weight
+	}
+	return lg.sc.Mix[len(lg.sc.Mix)-1].Type
+}
+
+// pickOthers picks distinct pool accounts except the from account
+func (lg *loadgen) pickOthers(from *loadgenAccount, count int) []*loadgenAccount {
+	accs := make([]*loadgenAccount, 0, count)
+	pickedMap := map[*loadgenAccount]bool{
+		from: true,
+	}
+	for len(accs) < count {
+		acc := lg.pool[lg.rnd.Intn(len(lg.pool))]
+		if !pickedMap[acc] {
+			pickedMap[acc] = true
+			accs = append(accs, acc)
+		}
+	}
+	return accs
+}
+
+// submit signs the transaction by keys in order and adds it to the node with tracking
+func (lg *loadgen) submit(Type string, tx types.Transaction, keys ...key.Key) *trackedTx {
+	TxHash := types.HashTransaction(lg.cn.Provider().ChainID(), tx)
+	sigs := make([]common.Signature, 0, len(keys))
+	var err error
+	for _, k := range keys {
+		var sig common.Signature
+		if sig, err = k.Sign(TxHash); err != nil {
+			break
+		}
+		sigs = append(sigs, sig)
+	}
+	t := lg.tr.Track(TxHash, Type)
+	if err == nil {
+		err = lg.nd.AddTx(tx, sigs)
+	}
+	lg.tr.Submitted(TxHash, t, err)
+	return t
+}
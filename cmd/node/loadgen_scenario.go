@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fletaio/fleta_testnet/cmd/config"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+// Scenario is a load generation scenario
+// the account pool is derived from the seed so that a scenario reuses accounts of the previous run
+// the funder creates and funds pool accounts and the payment admin subscribes them to the topic when billing is mixed
+// the payment admin pays the fee of billings so it should have the balance for them
+type Scenario struct {
+	Seed               string
+	AccountCount       int
+	Funder             string
+	FunderKeyHex       string
+	FundAmount         string
+	Workers            int
+	InclusionTimeout   int
+	TransferAmount     string
+	MultiTransferCount int
+	HyperFormulator    string
+	StakingAmount      string
+	Topic              string
+	PaymentAdmin       string
+	PaymentAdminKeyHex string
+	BillingAmount      string
+	BillingPeriod      uint32
+	Stages             []*ScenarioStage
+	Mix                []*ScenarioMix
+}
+
+// ScenarioStage is a stage of the scenario that ramps the target TPS linearly over the duration in seconds
+type ScenarioStage struct {
+	Duration int
+	FromTPS  int
+	ToTPS    int
+}
+
+// ScenarioMix is a transaction type of the scenario with its weight
+type ScenarioMix struct {
+	Type   string
+	Weight int
+}
+
+var scenarioTxTypes = map[string]bool{
+	"transfer":       true,
+	"multi_transfer": true,
+	"create_account": true,
+	"staking":        true,
+	"billing":        true,
+}
+
+// LoadScenario loads the scenario from the toml file of the path and fills default values
+func LoadScenario(path string) (*Scenario, error) {
+	sc := &Scenario{}
+	if err := config.LoadFile(path, sc); err != nil {
+		return nil, err
+	}
+	if sc.Workers == 0 {
+		sc.Workers = 16
+	}
+	if sc.InclusionTimeout == 0 {
+		sc.InclusionTimeout = 60
+	}
+	if len(sc.FundAmount) == 0 {
+		sc.FundAmount = "1000"
+	}
+	if len(sc.StakingAmount) == 0 {
+		sc.StakingAmount = "100"
+	}
+	if len(sc.BillingAmount) == 0 {
+		sc.BillingAmount = "0.1"
+	}
+	if len(sc.TransferAmount) == 0 {
+		sc.TransferAmount = "0.1"
+	}
+	if sc.MultiTransferCount == 0 {
+		sc.MultiTransferCount = 10
+	}
+	if sc.BillingPeriod == 0 {
+		sc.BillingPeriod = 1
+	}
+	if err := sc.Validate(); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// Validate checks the scenario
+func (sc *Scenario) Validate() error {
+	if len(sc.Seed) == 0 || len(sc.Seed) > 20 || !types.IsAllowedAccountName(sc.accountName(0)) {
+		return errInvalidScenarioSeed
+	}
+	if len(sc.Funder) == 0 || len(sc.FunderKeyHex) == 0 {
+		return errInvalidScenarioFunder
+	}
+	if sc.AccountCount < 2 {
+		return errInvalidScenarioAccountCount
+	}
+	if sc.MultiTransferCount < 1 || sc.MultiTransferCount > vault.MaxMultiTransferCount || sc.MultiTransferCount >= sc.AccountCount {
+		return errInvalidScenarioMultiTransferCount
+	}
+	if len(sc.Stages) == 0 {
+		return errEmptyScenarioStages
+	}
+	for _, st := range sc.Stages {
+		if st.Duration <= 0 || st.FromTPS < 0 || st.ToTPS < 0 {
+			return errInvalidScenarioStage
+		}
+	}
+	if len(sc.Mix) == 0 {
+		return errEmptyScenarioMix
+	}
+	for _, m := range sc.Mix {
+		if !scenarioTxTypes[m.Type] {
+			return errUnknownScenarioTxType
+		}
+		if m.Weight <= 0 {
+			return errInvalidScenarioWeight
+		}
+	}
+	if sc.HasType("staking") && len(sc.HyperFormulator) == 0 {
+		return errInvalidScenarioStaking
+	}
+	if sc.HasType("billing") && (len(sc.Topic) == 0 || len(sc.PaymentAdmin) == 0 || len(sc.PaymentAdminKeyHex) == 0) {
+		return errInvalidScenarioBilling
+	}
+	return nil
+}
+
+// HasType returns the scenario mixes the transaction type or not
+func (sc *Scenario) HasType(t string) bool {
+	for _, m := range sc.Mix {
+		if m.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// accountName returns the name of the pool account of the index
+func (sc *Scenario) accountName(index int) string {
+	return fmt.Sprintf("%s.pool%06d", sc.Seed, index)
+}
+
+// TPS returns the target TPS of the stage at the elapsed seconds
+func (st *ScenarioStage) TPS(elapsed float64) float64 {
+	if elapsed >= float64(st.Duration) {
+		return float64(st.ToTPS)
+	}
+	return float64(st.FromTPS) + float64(st.ToTPS-st.FromTPS)*elapsed/float64(st.Duration)
+}
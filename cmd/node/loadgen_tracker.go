@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// trackedTx is a submitted transaction that waits the inclusion
+type trackedTx struct {
+	Type          string
+	SubmittedAt   time.Time
+	SubmitLatency time.Duration
+	IncludedAt    time.Time
+	TXID          string
+	Reason        string
+	isExpired     bool
+	doneCh        chan struct{}
+}
+
+// txTracker is a service that tracks inclusion of submitted transactions like the wait of the bank
+type txTracker struct {
+	sync.Mutex
+	types.ServiceBase
+	ChainID     uint8
+	pendingMap  map[hash.Hash256]*trackedTx
+	txs         []*trackedTx
+	rejectedMap map[string]map[string]int
+}
+
+func newTxTracker() *txTracker {
+	return &txTracker{
+		pendingMap:  map[hash.Hash256]*trackedTx{},
+		txs:         []*trackedTx{},
+		rejectedMap: map[string]map[string]int{},
+	}
+}
+
+// Name returns the name of the service
+func (tr *txTracker) Name() string {
+	return "loadgen.tracker"
+}
+
+// Init called when initialize service
+func (tr *txTracker) Init(pm types.ProcessManager, cn types.Provider) error {
+	tr.ChainID = cn.ChainID()
+	return nil
+}
+
+// Track registers the transaction before the submission
+func (tr *txTracker) Track(TxHash hash.Hash256, Type string) *trackedTx {
+	t := &trackedTx{
+		Type:        Type,
+		SubmittedAt: time.Now(),
+		doneCh:      make(chan struct{}),
+	}
+	tr.Lock()
+	tr.pendingMap[TxHash] = t
+	tr.txs = append(tr.txs, t)
+	tr.Unlock()
+	return t
+}
+
+// Submitted records the result of the submission of the tracked transaction
+func (tr *txTracker) Submitted(TxHash hash.Hash256, t *trackedTx, err error) {
+	tr.Lock()
+	defer tr.Unlock()
+
+	t.SubmitLatency = time.Now().Sub(t.SubmittedAt)
+	if err != nil {
+		if _, has := tr.pendingMap[TxHash]; has {
+			tr.rejectLocked(TxHash, t, err.Error())
+		}
+	}
+}
+
+// PendingCount returns the number of transactions that are not included or rejected yet
+func (tr *txTracker) PendingCount() int {
+	tr.Lock()
+	defer tr.Unlock()
+
+	return len(tr.pendingMap)
+}
+
+// Reset forgets finished transactions so that the report only includes transactions after it
+func (tr *txTracker) Reset() {
+	tr.Lock()
+	defer tr.Unlock()
+
+	tr.txs = []*trackedTx{}
+	for _, t := range tr.pendingMap {
+		tr.txs = append(tr.txs, t)
+	}
+	tr.rejectedMap = map[string]map[string]int{}
+}
+
+// Expire rejects transactions that are not included yet
+func (tr *txTracker) Expire(reason string) {
+	tr.Lock()
+	defer tr.Unlock()
+
+	for TxHash, t := range tr.pendingMap {
+		if t.isExpired {
+			tr.rejectLocked(TxHash, t, "expired in the transaction pool")
+		} else {
+			tr.rejectLocked(TxHash, t, reason)
+		}
+	}
+}
+
+func (tr *txTracker) rejectLocked(TxHash hash.Hash256, t *trackedTx, reason string) {
+	delete(tr.pendingMap, TxHash)
+	t.Reason = reason
+	reasonMap, has := tr.rejectedMap[t.Type]
+	if !has {
+		reasonMap = map[string]int{}
+		tr.rejectedMap[t.Type] = reasonMap
+	}
+	reasonMap[reason]++
+	close(t.doneCh)
+}
+
+// Wait waits the inclusion of the tracked transaction and returns the id of it
+func (t *trackedTx) Wait(wait time.Duration) (string, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return "", errTransactionTimeout
+	case <-t.doneCh:
+		if len(t.TXID) == 0 {
+			return "", fmt.Errorf("%s transaction rejected: %s", t.Type, t.Reason)
+		}
+		return t.TXID, nil
+	}
+}
+
+// OnBlockConnected called when a block is connected to the chain
+func (tr *txTracker) OnBlockConnected(b *types.Block, events []types.Event, loader types.Loader) {
+	now := time.Now()
+
+	tr.Lock()
+	defer tr.Unlock()
+
+	if len(tr.pendingMap) == 0 {
+		return
+	}
+	for i, tx := range b.Transactions {
+		TxHash := types.HashTransactionByType(tr.ChainID, b.TransactionTypes[i], tx)
+		if t, has := tr.pendingMap[TxHash]; has {
+			delete(tr.pendingMap, TxHash)
+			t.IncludedAt = now
+			t.TXID = types.TransactionID(b.Header.Height, uint16(i))
+			close(t.doneCh)
+		}
+	}
+}
+
+// OnTransactionInPoolExpired called when a transaction in pool is expired
+// the transaction is still tracked because it can be included by the pool of other nodes
+func (tr *txTracker) OnTransactionInPoolExpired(txs []types.Transaction) {
+	tr.Lock()
+	defer tr.Unlock()
+
+	for _, tx := range txs {
+		TxHash := types.HashTransaction(tr.ChainID, tx)
+		if t, has := tr.pendingMap[TxHash]; has {
+			t.isExpired = true
+		}
+	}
+}
+
+// Report writes the result of tracked transactions
+func (tr *txTracker) Report(w io.Writer, elapsed time.Duration) {
+	tr.Lock()
+	defer tr.Unlock()
+
+	type typeCount struct {
+		Submitted int
+		Included  int
+		Rejected  int
+		Pending   int
+	}
+	countMap := map[string]*typeCount{}
+	typeNames := []string{}
+	submitLatencies := []time.Duration{}
+	inclusionLatencies := []time.Duration{}
+	total := &typeCount{}
+	for _, t := range tr.txs {
+		c, has := countMap[t.Type]
+		if !has {
+			c = &typeCount{}
+			countMap[t.Type] = c
+			typeNames = append(typeNames, t.Type)
+		}
+		for _, v := range []*typeCount{c, total} {
+			v.Submitted++
+			if len(t.TXID) > 0 {
+				v.Included++
+			} else if len(t.Reason) > 0 {
+				v.Rejected++
+			} else {
+				v.Pending++
+			}
+		}
+		submitLatencies = append(submitLatencies, t.SubmitLatency)
+		if len(t.TXID) > 0 {
+			inclusionLatencies = append(inclusionLatencies, t.IncludedAt.Sub(t.SubmittedAt))
+		}
+	}
+	sort.Strings(typeNames)
+
+	fmt.Fprintf(w, "Load generation report (%s)\n", elapsed.Round(time.Second))
+	fmt.Fprintf(w, "  %-16s %10s %10s %10s %10s\n", "type", "submitted", "included", "rejected", "pending")
+	for _, name := range typeNames {
+		c := countMap[name]
+		fmt.Fprintf(w, "  %-16s %10d %10d %10d %10d\n", name, c.Submitted, c.Included, c.Rejected, c.Pending)
+	}
+	fmt.Fprintf(w, "  %-16s %10d %10d %10d %10d\n", "total", total.Submitted, total.Included, total.Rejected, total.Pending)
+	if elapsed > 0 {
+		fmt.Fprintf(w, "  achieved TPS: submitted %.1f, included %.1f\n", float64(total.Submitted)/elapsed.Seconds(), float64(total.Included)/elapsed.Seconds())
+	}
+	fmt.Fprintln(w, "Latency")
+	writeLatency(w, "submit", submitLatencies)
+	writeLatency(w, "inclusion", inclusionLatencies)
+	fmt.Fprintln(w, "Rejection reasons")
+	if len(tr.rejectedMap) == 0 {
+		fmt.Fprintln(w, "  none")
+	}
+	for _, name := range typeNames {
+		reasonMap := tr.rejectedMap[name]
+		reasons := make([]string, 0, len(reasonMap))
+		for reason := range reasonMap {
+			reasons = append(reasons, reason)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			return reasonMap[reasons[i]] > reasonMap[reasons[j]]
+		})
+		for _, reason := range reasons {
+			fmt.Fprintf(w, "  %-16s %10d %s\n", name, reasonMap[reason], reason)
+		}
+	}
+}
+
+func writeLatency(w io.Writer, name string, ds []time.Duration) {
+	if len(ds) == 0 {
+		fmt.Fprintf(w, "  %-10s no samples\n", name)
+		return
+	}
+	sort.Slice(ds, func(i, j int) bool {
+		return ds[i] < ds[j]
+	})
+	var sum time.Duration
+	for _, d := range ds {
+		sum += d
+	}
+	percentile := func(p int) time.Duration {
+		return ds[(len(ds)-1)*p/100]
+	}
+	fmt.Fprintf(w, "  %-10s avg %v p50 %v p95 %v p99 %v max %v\n", name,
+		(sum / time.Duration(len(ds))).Round(time.Microsecond),
+		percentile(50).Round(time.Microsecond),
+		percentile(95).Round(time.Microsecond),
+		percentile(99).Round(time.Microsecond),
+		ds[len(ds)-1].Round(time.Microsecond),
+	)
+}
@@ -0,0 +1,6 @@
+package main
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the node command
+var logger = logging.New("node")
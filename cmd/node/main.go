@@ -0,0 +1,228 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+
+	"github.com/fletaio/fleta_testnet/cmd/closer"
+	"github.com/fletaio/fleta_testnet/cmd/config"
+	"github.com/fletaio/fleta_testnet/cmd/profile"
+	"github.com/fletaio/fleta_testnet/common/logging"
+	"github.com/fletaio/fleta_testnet/common/metrics"
+	"github.com/fletaio/fleta_testnet/common/rlog"
+	"github.com/fletaio/fleta_testnet/core/chain"
+)
+
+// Config is a configuration of the node that is not shared with other nodes of the network
+type Config struct {
+	KeyHex                  string
+	NodeKeyHex              string
+	Formulator              string
+	Port                    int
+	ObserverPort            int
+	FormulatorPort          int
+	APIPort                 int
+	WebPort                 int
+	MaxTransactionsPerBlock int
+	StoreRoot               string
+	RLogHost                string
+	RLogPath                string
+	UseRLog                 bool
+	LogLevel                string
+	LogFormat               string
+	LogFile                 string
+	LogMaxSize              int
+	LogMaxBackups           int
+	MetricsHost             string
+	MetricsPort             int
+	UsePprof                bool
+	AdminAPIPort            int
+}
+
+type command struct {
+	Usage string
+	Run   func(name string, args []string) error
+}
+
+var commandMap = map[string]*command{
+	"observer": &command{
+		Usage: "runs an observer that signs blocks of formulators",
+		Run:   nodeCommand(runObserver),
+	},
+	"formulator": &command{
+		Usage: "runs a formulator that generates blocks",
+		Run:   nodeCommand(runFormulator),
+	},
+	"fullnode": &command{
+		Usage: "runs a full node that validates and relays blocks and transactions",
+		Run:   nodeCommand(runFullNode),
+	},
+	"devnet": &command{
+		Usage: "creates and runs a local network of observers, formulators and full nodes in one process",
+		Run:   runDevnet,
+	},
+	"loadgen": &command{
+		Usage: "runs a full node that submits transactions of the scenario and reports latencies and rejections",
+		Run:   runLoadgen,
+	},
+	"replay": &command{
+		Usage: "re-executes the next block of the height step by step and prints state changes of each step",
+		Run:   runReplay,
+	},
+	"genesis": &command{
+		Usage: "creates a genesis file (init) or prints the genesis hash of the profile (hash)",
+		Run:   runGenesis,
+	},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: node <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Commands:")
+	names := make([]string, 0, len(commandMap))
+	for k := range commandMap {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", k, commandMap[k].Usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, has := commandMap[os.Args[1]]
+	if !has {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.Run(os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+type runNodeFunc func(pf *profile.Profile, cfg *Config, cm *closer.Manager) (*chain.Chain, error)
+
+// newSignalManager returns a closer manager that closes all closers when the process receives a terminating signal
+func newSignalManager() *closer.Manager {
+	cm := closer.NewManager()
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc,
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+	go func() {
+		<-sigc
+		cm.CloseAll()
+	}()
+	return cm
+}
+
+// nodeCommand returns the command that runs the node until it is closed by a signal
+func nodeCommand(fn runNodeFunc) func(name string, args []string) error {
+	return func(name string, args []string) error {
+		fs := flag.NewFlagSet(name, flag.ExitOnError)
+		profilePath := fs.String("profile", "./profile.toml", "path of the network profile")
+		configPath := fs.String("config", "./config.toml", "path of the node config")
+		fs.Parse(args)
+
+		pf, err := profile.LoadFile(*profilePath)
+		if err != nil {
+			return err
+		}
+		var cfg Config
+		if err := config.LoadFile(*configPath, &cfg); err != nil {
+			return err
+		}
+		if len(cfg.StoreRoot) == 0 {
+			cfg.StoreRoot = "./" + name + "_data"
+		}
+
+		cm := newSignalManager()
+		defer cm.CloseAll()
+
+		if err := setupLogging(&cfg); err != nil {
+			return err
+		}
+		ms := startMetrics(cfg.MetricsHost, cfg.MetricsPort, cfg.UsePprof)
+
+		if _, err := fn(pf, &cfg, cm); err != nil {
+			if err == errClosed {
+				return nil
+			}
+			return err
+		}
+		if ms != nil {
+			cm.Add("metrics", ms)
+		}
+		cm.Wait()
+		return nil
+	}
+}
+
+// setupLogging sets levels and sinks of logs by the config
+// the log file is rotated by LogMaxSize in megabytes and the rlog is added as an optional sink
+func setupLogging(cfg *Config) error {
+	if err := logging.SetLevels(cfg.LogLevel); err != nil {
+		return err
+	}
+	format, err := logging.ParseFormat(cfg.LogFormat)
+	if err != nil {
+		return err
+	}
+	sinks := []logging.Sink{logging.NewWriterSink(os.Stderr, format)}
+	if len(cfg.LogFile) > 0 {
+		if cfg.LogMaxSize <= 0 {
+			cfg.LogMaxSize = 100
+		}
+		w, err := logging.NewRotateWriter(cfg.LogFile, int64(cfg.LogMaxSize)*1024*1024, cfg.LogMaxBackups)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, logging.NewWriterSink(w, format))
+	}
+	logging.SetSinks(sinks...)
+
+	if len(cfg.RLogHost) > 0 && cfg.UseRLog {
+		if len(cfg.RLogPath) == 0 {
+			cfg.RLogPath = cfg.StoreRoot + "/rlog"
+		}
+		rlog.SetRLogHost(cfg.RLogHost)
+		rlog.Enablelogger(cfg.RLogPath)
+	}
+	return nil
+}
+
+// startMetrics serves metrics of the node on the port in the prometheus text format when the port is set
+// the server listens the loopback address unless the host is set because the pprof profiler is served on the same port when usePprof is set
+// the server is returned to be added to the closer after the shutdown sequence of the node is registered
+func startMetrics(host string, port int, usePprof bool) *metrics.Server {
+	if port <= 0 {
+		return nil
+	}
+	if len(host) == 0 {
+		host = "127.0.0.1"
+	}
+	addr := host + ":" + strconv.Itoa(port)
+	ms := metrics.NewServer(addr, metrics.DefaultRegistry)
+	if usePprof {
+		ms.EnableProfiler()
+	}
+	go func() {
+		logger.Info("Metrics server listens", "addr", addr, "pprof", usePprof)
+		if err := ms.Run(); err != nil {
+			logger.Error("Metrics server failed", "addr", addr, "err", err)
+		}
+	}()
+	return ms
+}
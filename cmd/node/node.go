@@ -0,0 +1,126 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/fletaio/fleta_testnet/cmd/closer"
+	"github.com/fletaio/fleta_testnet/cmd/profile"
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/chain"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/pof"
+	"github.com/fletaio/fleta_testnet/service/p2p"
+)
+
+func runObserver(pf *profile.Profile, cfg *Config, cm *closer.Manager) (*chain.Chain, error) {
+	obkey, err := loadKey(cfg.KeyHex)
+	if err != nil {
+		return nil, err
+	}
+	cn, cs, err := openChain(pf, cfg, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	ob := pof.NewObserverNode(obkey, pf.ObserverNetAddressMap(), cs)
+	if err := ob.Init(); err != nil {
+		return nil, err
+	}
+	addNodeCloser(cm, cn, "observer", ob)
+
+	go ob.Run(":"+strconv.Itoa(cfg.ObserverPort), ":"+strconv.Itoa(cfg.FormulatorPort))
+	return cn, nil
+}
+
+func runFormulator(pf *profile.Profile, cfg *Config, cm *closer.Manager) (*chain.Chain, error) {
+	frkey, err := loadKey(cfg.KeyHex)
+	if err != nil {
+		return nil, err
+	}
+	ndkey, err := loadNodeKey(cfg.NodeKeyHex, cfg.StoreRoot)
+	if err != nil {
+		return nil, err
+	}
+	Formulator, err := common.ParseAddress(cfg.Formulator)
+	if err != nil {
+		return nil, err
+	}
+	SeedNodeMap, err := pf.SeedNodes()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxTransactionsPerBlock == 0 {
+		cfg.MaxTransactionsPerBlock = 7000
+	}
+	cn, cs, err := openChain(pf, cfg, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	fr := pof.NewFormulatorNode(&pof.FormulatorConfig{
+		Formulator:              Formulator,
+		MaxTransactionsPerBlock: cfg.MaxTransactionsPerBlock,
+	}, frkey, ndkey, pf.FormulatorNetAddressMap(), SeedNodeMap, cs, cfg.StoreRoot+"/peer")
+	if err := fr.Init(); err != nil {
+		return nil, err
+	}
+	addNodeCloser(cm, cn, "formulator", fr)
+
+	go fr.Run(":" + strconv.Itoa(cfg.Port))
+	return cn, nil
+}
+
+func runFullNode(pf *profile.Profile, cfg *Config, cm *closer.Manager) (*chain.Chain, error) {
+	cn, _, err := openFullNode(pf, cfg, cm)
+	if err != nil {
+		return nil, err
+	}
+	return cn, nil
+}
+
+// openFullNode opens the chain with additional services and runs the p2p node on it
+func openFullNode(pf *profile.Profile, cfg *Config, cm *closer.Manager, services ...types.Service) (*chain.Chain, *p2p.Node, error) {
+	ndkey, err := loadNodeKey(cfg.NodeKeyHex, cfg.StoreRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	SeedNodeMap, err := pf.SeedNodes()
+	if err != nil {
+		return nil, nil, err
+	}
+	cn, _, err := openChain(pf, cfg, cm, services...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nd := p2p.NewNode(ndkey, SeedNodeMap, cn, cfg.StoreRoot+"/peer")
+	if err := nd.Init(); err != nil {
+		return nil, nil, err
+	}
+	addNodeCloser(cm, cn, "node", nd)
+	if err := startAdminAPI(nd, cfg.AdminAPIPort, cm); err != nil {
+		return nil, nil, err
+	}
+
+	go nd.Run(":" + strconv.Itoa(cfg.Port))
+	return cn, nd, nil
+}
+
+// startAdminAPI serves admin methods of the node on the loopback address when the port is set
+func startAdminAPI(nd *p2p.Node, port int, cm *closer.Manager) error {
+	if port <= 0 {
+		return nil
+	}
+	as, err := nd.NewAdminAPI()
+	if err != nil {
+		return err
+	}
+	cm.Add("adminapi", as)
+	go func() {
+		logger.Info("Admin api server listens", "port", port)
+		if err := as.Run("127.0.0.1:" + strconv.Itoa(port)); err != nil {
+			logger.Error("Admin api server failed", "port", port, "err", err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/fletaio/fleta_testnet/cmd/closer"
+	"github.com/fletaio/fleta_testnet/cmd/config"
+	"github.com/fletaio/fleta_testnet/cmd/profile"
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	"github.com/fletaio/fleta_testnet/core/chain"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+// replayStep is the json form of the trace step that is printed by the replay
+type replayStep struct {
+	Step           string                 `json:"step"`
+	ProcessID      uint8                  `json:"process_id"`
+	Index          int                    `json:"index"`
+	TxHash         string                 `json:"tx_hash,omitempty"`
+	TxType         uint16                 `json:"tx_type,omitempty"`
+	Tx             types.Transaction      `json:"tx,omitempty"`
+	Signers        []common.PublicHash    `json:"signers,omitempty"`
+	Error          string                 `json:"error,omitempty"`
+	Diff           *types.ContextDiff     `json:"diff"`
+	BalanceChanges []*vault.BalanceChange `json:"balance_changes,omitempty"`
+}
+
+// tempDirCloser removes the temporary directory when it is closed
+type tempDirCloser string
+
+func (dir tempDirCloser) Close() {
+	os.RemoveAll(string(dir))
+}
+
+// runReplay re-executes the next block of the height step by step and prints state changes of each step
+// the state of the height is rebuilt from the genesis in a temporary store when the context of the store is not at the height
+func runReplay(name string, args []string) error {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	profilePath := fs.String("profile", "./profile.toml", "path of the network profile")
+	configPath := fs.String("config", "./config.toml", "path of the node config")
+	height := fs.Int("height", -1, "height of the state to replay on (default: the height of the context of the store)")
+	blockPath := fs.String("block", "", "path of the hex encoded block to replay instead of the next block of the store")
+	txPath := fs.String("tx", "", "path of the transaction json to dry run on the state instead of the block")
+	all := fs.Bool("all", false, "prints steps that have no changes")
+	fs.Parse(args)
+
+	pf, err := profile.LoadFile(*profilePath)
+	if err != nil {
+		return err
+	}
+	var cfg Config
+	if err := config.LoadFile(*configPath, &cfg); err != nil {
+		return err
+	}
+	if len(cfg.StoreRoot) == 0 {
+		cfg.StoreRoot = "./" + name + "_data"
+	}
+	if err := setupLogging(&cfg); err != nil {
+		return err
+	}
+
+	cm := newSignalManager()
+	defer cm.CloseAll()
+
+	src, err := openStore(pf, cfg.StoreRoot)
+	if err != nil {
+		return err
+	}
+	cm.Add("store", src)
+
+	if *height < 0 {
+		*height = int(src.Height())
+	}
+	cn, err := openReplayChain(pf, src, uint32(*height), cm)
+	if err != nil {
+		return err
+	}
+
+	var vp *vault.Vault
+	if p, err := cn.ProcessByName("fleta.vault"); err != nil {
+		//ignore when not loaded
+	} else if v, is := p.(*vault.Vault); is {
+		vp = v
+	}
+
+	if len(*txPath) > 0 {
+		return replayTransaction(cn, pf.Chain.ChainID, *txPath, vp, os.Stdout)
+	}
+
+	var b *types.Block
+	if len(*blockPath) > 0 {
+		if b, err = loadBlockFile(*blockPath); err != nil {
+			return err
+		}
+	} else if b, err = src.Block(uint32(*height) + 1); err != nil {
+		if err == backend.ErrNotExistKey {
+			return errNotExistReplayBlock
+		}
+		return err
+	}
+
+	_, err = cn.TraceBlock(b, func(step *chain.TraceStep) {
+		if *all || step.Err != nil || (step.Diff != nil && !step.Diff.IsEmpty()) {
+			writeReplayStep(os.Stdout, step, vp)
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stdout, "block %d %s failed: %v\n", b.Header.Height, encoding.Hash(b.Header), err)
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "block %d %s succeeded\n", b.Header.Height, encoding.Hash(b.Header))
+	return nil
+}
+
+// openReplayChain returns the chain of the state at the height
+// the store is used directly when its context is at the height otherwise blocks of the store are connected to a temporary store
+func openReplayChain(pf *profile.Profile, src *chain.Store, height uint32, cm *closer.Manager) (*chain.Chain, error) {
+	if src.Height() == height {
+		cn, _, err := newChain(pf, src)
+		if err != nil {
+			return nil, err
+		}
+		if err := cn.Init(); err != nil {
+			return nil, err
+		}
+		if err := src.LoadTimeSlots(); err != nil {
+			return nil, err
+		}
+		return cn, nil
+	}
+
+	dir, err := ioutil.TempDir("", "fleta_replay")
+	if err != nil {
+		return nil, err
+	}
+	st, err := openStore(pf, dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	cm.Add("replay store", st)
+	cm.Add("replay dir", tempDirCloser(dir))
+
+	cn, _, err := newChain(pf, st)
+	if err != nil {
+		return nil, err
+	}
+	if err := cn.Init(); err != nil {
+		return nil, err
+	}
+	logger.Info("Rebuild the state", "height", height, "dir", dir)
+	for h := uint32(1); h <= height; h++ {
+		if cm.IsClosed() {
+			return nil, errClosed
+		}
+		b, err := src.Block(h)
+		if err != nil {
+			if err == backend.ErrNotExistKey {
+				return nil, errInvalidReplayHeight
+			}
+			return nil, err
+		}
+		if err := cn.ConnectBlock(b, nil); err != nil {
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+// replayTransaction executes the transaction of the json file on the state as a dry run
+func replayTransaction(cn *chain.Chain, ChainID uint8, path string, vp *vault.Vault, w io.Writer) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var tj types.TransactionJSON
+	if err := json.Unmarshal(data, &tj); err != nil {
+		return err
+	}
+	t, tx, err := tj.Transaction(ChainID)
+	if err != nil {
+		return err
+	}
+	signers, err := tj.SignerHashes(types.HashTransactionByType(ChainID, t, tx))
+	if err != nil {
+		return err
+	}
+	step, err := cn.TraceTransaction(t, tx, signers, 0)
+	if err != nil {
+		return err
+	}
+	writeReplayStep(w, step, vp)
+	return step.Err
+}
+
+// loadBlockFile loads the block from the hex string of the file
+func loadBlockFile(path string) (*types.Block, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	var b types.Block
+	if err := encoding.Unmarshal(bs, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func writeReplayStep(w io.Writer, step *chain.TraceStep, vp *vault.Vault) {
+	rs := &replayStep{
+		Step:      step.Name,
+		ProcessID: step.ProcessID,
+		Index:     step.Index,
+		TxType:    step.TxType,
+		Tx:        step.Tx,
+		Signers:   step.Signers,
+		Diff:      step.Diff,
+	}
+	if step.Tx != nil {
+		rs.TxHash = step.TxHash.String()
+	}
+	if step.Err != nil {
+		rs.Error = step.Err.Error()
+	}
+	if vp != nil && step.Diff != nil {
+		rs.BalanceChanges = vp.BalanceChanges(step.Diff)
+	}
+	bs, err := json.MarshalIndent(rs, "", "\t")
+	if err != nil {
+		fmt.Fprintf(w, "%s %d: %v\n", step.Name, step.Index, err)
+		return
+	}
+	w.Write(bs)
+	w.Write([]byte("\n"))
+}
@@ -0,0 +1,16 @@
+package profile
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidChainID                = errors.New("invalid chain id")
+	ErrInvalidSymbol                 = errors.New("invalid symbol")
+	ErrInvalidUsage                  = errors.New("invalid usage")
+	ErrInvalidMaxBlocksPerFormulator = errors.New("invalid max blocks per formulator")
+	ErrNotExistObserverKey           = errors.New("not exist observer key")
+	ErrDuplicatedObserver            = errors.New("duplicated observer")
+	ErrNotExistProcess               = errors.New("not exist process")
+	ErrUnknownProcess                = errors.New("unknown process")
+	ErrDuplicatedProcess             = errors.New("duplicated process")
+)
@@ -0,0 +1,223 @@
+package profile
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/fletaio/fleta_testnet/cmd/app"
+	"github.com/fletaio/fleta_testnet/cmd/config"
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/chain"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+	"github.com/fletaio/fleta_testnet/process/formulator"
+	"github.com/fletaio/fleta_testnet/process/gateway"
+	"github.com/fletaio/fleta_testnet/process/payment"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+// Profile describes a network that nodes join
+// It replaces chain constants, observer keys, seed nodes and the process list that were hard-coded in each cmd
+type Profile struct {
+	Chain       ChainParams
+	GenesisFile string
+	Observers   []*ObserverEntry
+	SeedNodeMap map[string]string
+	Processes   []*ProcessEntry
+	Services    []string
+}
+
+// ChainParams is the chain parameters of the network
+// ChangeStakingCooldownHeight is the height from which a staker can change staking once per reward period and zero disables it
+type ChainParams struct {
+	ChainID                     uint8
+	Symbol                      string
+	Usage                       string
+	Version                     uint16
+	MaxBlocksPerFormulator      uint32
+	ChangeStakingCooldownHeight uint32
+}
+
+// ObserverEntry is an observer of the network
+// Address is used between observers and FormulatorAddress is used by formulators
+type ObserverEntry struct {
+	PublicHash        string
+	Address           string
+	FormulatorAddress string
+}
+
+// ProcessEntry is a process of the chain with its id
+type ProcessEntry struct {
+	Name string
+	ID   uint8
+}
+
+// ProcessCreator creates a process with the id
+type ProcessCreator func(ID uint8) types.Process
+
+var processCreatorMap = map[string]ProcessCreator{
+	"fleta.admin": func(ID uint8) types.Process {
+		return admin.NewAdmin(ID)
+	},
+	"fleta.vault": func(ID uint8) types.Process {
+		return vault.NewVault(ID)
+	},
+	"fleta.formulator": func(ID uint8) types.Process {
+		return formulator.NewFormulator(ID)
+	},
+	"fleta.gateway": func(ID uint8) types.Process {
+		return gateway.NewGateway(ID)
+	},
+	"fleta.payment": func(ID uint8) types.Process {
+		return payment.NewPayment(ID)
+	},
+}
+
+// RegisterProcess adds the process creator that can be used in profiles
+func RegisterProcess(Name string, fn ProcessCreator) {
+	processCreatorMap[Name] = fn
+}
+
+// LoadFile loads and validates the profile of the path
+func LoadFile(path string) (*Profile, error) {
+	pf := &Profile{}
+	if err := config.LoadFile(path, pf); err != nil {
+		return nil, err
+	}
+	if len(pf.GenesisFile) > 0 && !filepath.IsAbs(pf.GenesisFile) {
+		pf.GenesisFile = filepath.Join(filepath.Dir(path), pf.GenesisFile)
+	}
+	if err := pf.Validate(); err != nil {
+		return nil, err
+	}
+	return pf, nil
+}
+
+// Genesis loads the genesis file of the profile
+// the default genesis is used when the genesis file is not specified
+func (pf *Profile) Genesis() (*app.Genesis, error) {
+	if len(pf.GenesisFile) == 0 {
+		return app.DefaultGenesis(), nil
+	}
+	return app.LoadGenesis(pf.GenesisFile)
+}
+
+// Validate checks the profile
+func (pf *Profile) Validate() error {
+	if pf.Chain.ChainID == 0 {
+		return ErrInvalidChainID
+	}
+	// same rules of the chain store that panics when they are violated
+	if len(pf.Chain.Symbol) < 3 || len(pf.Chain.Symbol) > 5 || strings.IndexFunc(pf.Chain.Symbol, func(c rune) bool {
+		return (c < '0' || '9' < c) && (c < 'A' || 'Z' < c)
+	}) >= 0 {
+		return ErrInvalidSymbol
+	}
+	if len(pf.Chain.Usage) < 4 || len(pf.Chain.Usage) > 16 || strings.IndexFunc(pf.Chain.Usage, func(c rune) bool {
+		return (c < '0' || '9' < c) && (c < 'A' || 'Z' < c) && (c < 'a' || 'z' < c)
+	}) >= 0 {
+		return ErrInvalidUsage
+	}
+	if pf.Chain.MaxBlocksPerFormulator == 0 {
+		return ErrInvalidMaxBlocksPerFormulator
+	}
+	if len(pf.Observers) == 0 {
+		return ErrNotExistObserverKey
+	}
+	if len(pf.Processes) == 0 {
+		return ErrNotExistProcess
+	}
+	idMap := map[uint8]bool{}
+	nameMap := map[string]bool{}
+	for _, p := range pf.Processes {
+		if _, has := processCreatorMap[p.Name]; !has {
+			return ErrUnknownProcess
+		}
+		if p.ID == 0 || idMap[p.ID] || nameMap[p.Name] {
+			return ErrDuplicatedProcess
+		}
+		idMap[p.ID] = true
+		nameMap[p.Name] = true
+	}
+	if _, err := pf.ObserverKeys(); err != nil {
+		return err
+	}
+	if _, err := pf.SeedNodes(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ObserverKeys returns public hashes of observers in the order of the profile
+func (pf *Profile) ObserverKeys() ([]common.PublicHash, error) {
+	ObserverKeys := make([]common.PublicHash, 0, len(pf.Observers))
+	keyMap := map[common.PublicHash]bool{}
+	for _, ob := range pf.Observers {
+		pubhash, err := common.ParsePublicHash(ob.PublicHash)
+		if err != nil {
+			return nil, err
+		}
+		if keyMap[pubhash] {
+			return nil, ErrDuplicatedObserver
+		}
+		keyMap[pubhash] = true
+		ObserverKeys = append(ObserverKeys, pubhash)
+	}
+	return ObserverKeys, nil
+}
+
+// ObserverNetAddressMap returns addresses that observers connect to each other
+func (pf *Profile) ObserverNetAddressMap() map[common.PublicHash]string {
+	NetAddressMap := map[common.PublicHash]string{}
+	for _, ob := range pf.Observers {
+		NetAddressMap[common.MustParsePublicHash(ob.PublicHash)] = ob.Address
+	}
+	return NetAddressMap
+}
+
+// FormulatorNetAddressMap returns addresses that formulators connect to observers
+func (pf *Profile) FormulatorNetAddressMap() map[common.PublicHash]string {
+	NetAddressMap := map[common.PublicHash]string{}
+	for _, ob := range pf.Observers {
+		NetAddressMap[common.MustParsePublicHash(ob.PublicHash)] = "ws://" + ob.FormulatorAddress
+	}
+	return NetAddressMap
+}
+
+// SeedNodes returns the seed node map of the network
+func (pf *Profile) SeedNodes() (map[common.PublicHash]string, error) {
+	SeedNodeMap := map[common.PublicHash]string{}
+	for k, netAddr := range pf.SeedNodeMap {
+		pubhash, err := common.ParsePublicHash(k)
+		if err != nil {
+			return nil, err
+		}
+		SeedNodeMap[pubhash] = netAddr
+	}
+	return SeedNodeMap, nil
+}
+
+// HasService returns the service is enabled or not
+func (pf *Profile) HasService(Name string) bool {
+	for _, v := range pf.Services {
+		if v == Name {
+			return true
+		}
+	}
+	return false
+}
+
+// MustAddProcesses adds processes of the profile to the chain in the order of the profile
+func (pf *Profile) MustAddProcesses(cn *chain.Chain) {
+	for _, p := range pf.Processes {
+		fn, has := processCreatorMap[p.Name]
+		if !has {
+			panic(ErrUnknownProcess)
+		}
+		v := fn(p.ID)
+		if fp, is := v.(*formulator.Formulator); is {
+			fp.SetChangeStakingCooldownHeight(pf.Chain.ChangeStakingCooldownHeight)
+		}
+		cn.MustAddProcess(v)
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"encoding/hex"
-	"log"
 	"strconv"
 	"time"
 
@@ -53,7 +52,7 @@ func main() {
 			obkeys = append(obkeys, Key)
 			pubhash := common.NewPublicHash(Key.PublicKey())
 			ObserverKeys = append(ObserverKeys, pubhash)
-			log.Println(i, pubhash.String())
+			logger.Info("Observer key loaded", "index", i, "pubhash", pubhash)
 			NetAddressMap[pubhash] = ":400" + strconv.Itoa(i)
 			FrNetAddressMap[pubhash] = "ws://localhost:500" + strconv.Itoa(i)
 		}
@@ -90,7 +89,7 @@ func main() {
 			if err := cn.ConnectBlock(b, nil); err != nil {
 				panic(err)
 			}
-			log.Println(b.Header.Height, "Connect block From local", b.Header.Generator.String(), b.Header.Height)
+			logger.Info("Block connected from local", "height", b.Header.Height, "generator", b.Header.Generator)
 			return nil
 		}); err != nil {
 			panic(err)
@@ -168,7 +167,7 @@ func main() {
 			if err := cn.ConnectBlock(b, nil); err != nil {
 				panic(err)
 			}
-			log.Println(b.Header.Height, "Connect block From local", b.Header.Generator.String(), b.Header.Height)
+			logger.Info("Block connected from local", "height", b.Header.Height, "generator", b.Header.Generator)
 			return nil
 		}); err != nil {
 			panic(err)
@@ -227,7 +226,7 @@ func main() {
 			if err := cn.ConnectBlock(b, nil); err != nil {
 				panic(err)
 			}
-			log.Println(b.Header.Height, "Connect block From local", b.Header.Generator.String(), b.Header.Height)
+			logger.Info("Block connected from local", "height", b.Header.Height, "generator", b.Header.Generator)
 			return nil
 		}); err != nil {
 			panic(err)
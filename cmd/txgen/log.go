@@ -0,0 +1,6 @@
+package main
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the txgen command
+var logger = logging.New("txgen")
@@ -3,7 +3,6 @@ package main
 import (
 	"encoding/hex"
 	"io/ioutil"
-	"log"
 	"os"
 	"os/signal"
 	"strconv"
@@ -138,10 +137,8 @@ func main() {
 	}
 	cm.Add("store", st)
 
-	if st.Height() > 0 {
-		if _, err := cdb.GetData(st.Height(), 0); err != nil {
-			panic(err)
-		}
+	if err := st.CheckConsistency(); err != nil {
+		panic(err)
 	}
 
 	cs := pof.NewConsensus(MaxBlocksPerFormulator, ObserverKeys)
@@ -246,7 +243,7 @@ func main() {
 			for _, v := range Addrs {
 				go func(Addr common.Address) {
 					key, _ := key.NewMemoryKeyFromString("fd1167aad31c104c9fceb5b8a4ffd3e20a272af82176352d3b6ac236d02bafd4")
-					log.Println(Addr.String(), "Start Transaction")
+					logger.Info("Transaction started", "addr", Addr)
 
 					for {
 						/*
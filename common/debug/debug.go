@@ -1,3 +0,0 @@
-package debug
-
-const DEBUG = true
@@ -0,0 +1,11 @@
+package logging
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidLevel   = errors.New("invalid level")
+	ErrInvalidFormat  = errors.New("invalid format")
+	ErrInvalidMaxSize = errors.New("invalid max size")
+	ErrWriterClosed   = errors.New("writer closed")
+)
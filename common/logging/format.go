@@ -0,0 +1,137 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format is the output format of log entries
+type Format uint8
+
+// formats
+const (
+	TextFormat Format = iota
+	JSONFormat
+)
+
+// ParseFormat returns the format of the name
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", "text":
+		return TextFormat, nil
+	case "json":
+		return JSONFormat, nil
+	default:
+		return 0, ErrInvalidFormat
+	}
+}
+
+// Marshal returns the line of the entry in the format
+func (f Format) Marshal(e *Entry) []byte {
+	var buffer bytes.Buffer
+	switch f {
+	case JSONFormat:
+		buffer.WriteString(`{"time":`)
+		buffer.WriteString(jsonString(e.Time.Format(time.RFC3339Nano)))
+		buffer.WriteString(`,"level":`)
+		buffer.WriteString(jsonString(e.Level.String()))
+		buffer.WriteString(`,"subsystem":`)
+		buffer.WriteString(jsonString(e.Subsystem))
+		buffer.WriteString(`,"msg":`)
+		buffer.WriteString(jsonString(e.Message))
+		for _, fd := range e.Fields {
+			buffer.WriteString(",")
+			buffer.WriteString(jsonString(fd.Key))
+			buffer.WriteString(":")
+			buffer.Write(jsonValue(fd.Value))
+		}
+		buffer.WriteString("}\n")
+	default:
+		buffer.WriteString(e.Time.Format("2006/01/02 15:04:05.000"))
+		buffer.WriteString(" ")
+		buffer.WriteString(fmt.Sprintf("%-5s", strings.ToUpper(e.Level.String())))
+		buffer.WriteString(" ")
+		buffer.WriteString(e.Subsystem)
+		buffer.WriteString(": ")
+		buffer.WriteString(e.Message)
+		for _, fd := range e.Fields {
+			buffer.WriteString(" ")
+			buffer.WriteString(fd.Key)
+			buffer.WriteString("=")
+			buffer.WriteString(textValue(fd.Value))
+		}
+		buffer.WriteString("\n")
+	}
+	return buffer.Bytes()
+}
+
+// stringValue returns the string of the value that is a stringer or an error
+func stringValue(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case error:
+		return v.Error(), true
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	return "", false
+}
+
+func textValue(v interface{}) string {
+	str, is := stringValue(v)
+	if !is {
+		str = fmt.Sprint(v)
+	}
+	if len(str) == 0 || strings.ContainsAny(str, " =\"\n\t") {
+		return strconv.Quote(str)
+	}
+	return str
+}
+
+func jsonValue(v interface{}) []byte {
+	if str, is := stringValue(v); is {
+		return []byte(jsonString(str))
+	}
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return []byte(jsonString(fmt.Sprint(v)))
+	}
+	return bs
+}
+
+func jsonString(str string) string {
+	bs, _ := json.Marshal(str)
+	return string(bs)
+}
+
+// WriterSink writes log entries to the writer in the format
+type WriterSink struct {
+	sync.Mutex
+	w      io.Writer
+	format Format
+}
+
+// NewWriterSink returns a WriterSink
+func NewWriterSink(w io.Writer, format Format) *WriterSink {
+	return &WriterSink{
+		w:      w,
+		format: format,
+	}
+}
+
+// WriteEntry writes the entry to the writer
+func (s *WriterSink) WriteEntry(e *Entry) {
+	bs := s.format.Marshal(e)
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.w.Write(bs)
+}
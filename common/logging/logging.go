@@ -0,0 +1,228 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of the log entry
+type Level uint8
+
+// levels
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+// String returns the name of the level
+func (lv Level) String() string {
+	if int(lv) < len(levelNames) {
+		return levelNames[lv]
+	}
+	return "unknown"
+}
+
+// ParseLevel returns the level of the name
+func ParseLevel(name string) (Level, error) {
+	for i, v := range levelNames {
+		if strings.EqualFold(v, name) {
+			return Level(i), nil
+		}
+	}
+	return 0, ErrInvalidLevel
+}
+
+// Field is a key-value pair of the log entry
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Entry is a log entry that is passed to sinks
+type Entry struct {
+	Time      time.Time
+	Level     Level
+	Subsystem string
+	Message   string
+	Fields    []Field
+}
+
+// Sink receives log entries that are enabled by levels
+type Sink interface {
+	WriteEntry(e *Entry)
+}
+
+var (
+	configLock   sync.RWMutex
+	defaultLevel = InfoLevel
+	levelMap     = map[string]Level{}
+	sinks        = []Sink{NewWriterSink(os.Stderr, TextFormat)}
+)
+
+// SetLevel sets the level of subsystems that have no level of their own
+func SetLevel(lv Level) {
+	configLock.Lock()
+	defer configLock.Unlock()
+
+	defaultLevel = lv
+}
+
+// SetSubsystemLevel sets the level of the subsystem
+func SetSubsystemLevel(subsystem string, lv Level) {
+	configLock.Lock()
+	defer configLock.Unlock()
+
+	levelMap[subsystem] = lv
+}
+
+// SetLevels sets levels from the spec like "info,p2p=debug,pof=warn"
+// the item without the subsystem is the default level
+func SetLevels(spec string) error {
+	def := InfoLevel
+	lvMap := map[string]Level{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		ls := strings.SplitN(item, "=", 2)
+		if len(ls) == 1 {
+			lv, err := ParseLevel(ls[0])
+			if err != nil {
+				return err
+			}
+			def = lv
+		} else {
+			lv, err := ParseLevel(ls[1])
+			if err != nil {
+				return err
+			}
+			lvMap[strings.TrimSpace(ls[0])] = lv
+		}
+	}
+
+	configLock.Lock()
+	defer configLock.Unlock()
+
+	defaultLevel = def
+	levelMap = lvMap
+	return nil
+}
+
+// IsEnabled returns the level is enabled for the subsystem or not
+func IsEnabled(subsystem string, lv Level) bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+
+	if v, has := levelMap[subsystem]; has {
+		return lv >= v
+	}
+	return lv >= defaultLevel
+}
+
+// SetSinks replaces sinks that receive log entries
+func SetSinks(ss ...Sink) {
+	configLock.Lock()
+	defer configLock.Unlock()
+
+	sinks = ss
+}
+
+// AddSink adds a sink that receives log entries
+func AddSink(s Sink) {
+	configLock.Lock()
+	defer configLock.Unlock()
+
+	sinks = append(sinks[:len(sinks):len(sinks)], s)
+}
+
+// Logger writes log entries of the subsystem
+type Logger struct {
+	subsystem string
+	fields    []Field
+}
+
+// New returns a Logger of the subsystem
+func New(subsystem string) *Logger {
+	return &Logger{
+		subsystem: subsystem,
+	}
+}
+
+// With returns a Logger that adds key-value fields to every entry
+func (l *Logger) With(kvs ...interface{}) *Logger {
+	return &Logger{
+		subsystem: l.subsystem,
+		fields:    append(l.fields[:len(l.fields):len(l.fields)], toFields(kvs)...),
+	}
+}
+
+// IsEnabled returns the level is enabled for the subsystem of the logger or not
+func (l *Logger) IsEnabled(lv Level) bool {
+	return IsEnabled(l.subsystem, lv)
+}
+
+// Debug writes the message with key-value fields in the debug level
+func (l *Logger) Debug(msg string, kvs ...interface{}) {
+	l.write(DebugLevel, msg, kvs)
+}
+
+// Info writes the message with key-value fields in the info level
+func (l *Logger) Info(msg string, kvs ...interface{}) {
+	l.write(InfoLevel, msg, kvs)
+}
+
+// Warn writes the message with key-value fields in the warn level
+func (l *Logger) Warn(msg string, kvs ...interface{}) {
+	l.write(WarnLevel, msg, kvs)
+}
+
+// Error writes the message with key-value fields in the error level
+func (l *Logger) Error(msg string, kvs ...interface{}) {
+	l.write(ErrorLevel, msg, kvs)
+}
+
+func (l *Logger) write(lv Level, msg string, kvs []interface{}) {
+	if !IsEnabled(l.subsystem, lv) {
+		return
+	}
+	e := &Entry{
+		Time:      time.Now(),
+		Level:     lv,
+		Subsystem: l.subsystem,
+		Message:   msg,
+		Fields:    append(l.fields[:len(l.fields):len(l.fields)], toFields(kvs)...),
+	}
+
+	configLock.RLock()
+	ss := sinks
+	configLock.RUnlock()
+
+	for _, s := range ss {
+		s.WriteEntry(e)
+	}
+}
+
+// toFields pairs keys and values and the value without the key is keyed as "extra"
+func toFields(kvs []interface{}) []Field {
+	fields := make([]Field, 0, (len(kvs)+1)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		if i+1 == len(kvs) {
+			fields = append(fields, Field{Key: "extra", Value: kvs[i]})
+			break
+		}
+		key, is := kvs[i].(string)
+		if !is {
+			key = fmt.Sprint(kvs[i])
+		}
+		fields = append(fields, Field{Key: key, Value: kvs[i+1]})
+	}
+	return fields
+}
@@ -0,0 +1,105 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// RotateWriter writes to the file and rotates it when it exceeds the max size
+// rotated files are renamed to path.1, path.2, ... and files over the max backups are removed
+type RotateWriter struct {
+	sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewRotateWriter returns a RotateWriter that appends to the file of the path
+func NewRotateWriter(path string, maxSize int64, maxBackups int) (*RotateWriter, error) {
+	if maxSize <= 0 {
+		return nil, ErrInvalidMaxSize
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	w := &RotateWriter{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes data to the file after the rotation when the data exceeds the max size
+func (w *RotateWriter) Write(bs []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.file == nil {
+		return 0, ErrWriterClosed
+	}
+	if w.size > 0 && w.size+int64(len(bs)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(bs)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the file
+func (w *RotateWriter) Close() error {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *RotateWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	w.file = file
+	w.size = fi.Size()
+	return nil
+}
+
+func (w *RotateWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+	if w.maxBackups > 0 {
+		os.Remove(w.path + "." + strconv.Itoa(w.maxBackups))
+		for i := w.maxBackups - 1; i >= 1; i-- {
+			os.Rename(w.path+"."+strconv.Itoa(i), w.path+"."+strconv.Itoa(i+1))
+		}
+		if err := os.Rename(w.path, w.path+".1"); err != nil {
+			return err
+		}
+	} else {
+		if err := os.Remove(w.path); err != nil {
+			return err
+		}
+	}
+	return w.open()
+}
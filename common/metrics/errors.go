@@ -0,0 +1,9 @@
+package metrics
+
+import "errors"
+
+// errors
+var (
+	ErrExistMetric        = errors.New("exist metric")
+	ErrInvalidLabelValues = errors.New("invalid label values")
+)
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are upper bounds in seconds that fit latencies of the block and the store
+var DefaultBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram counts observed values in buckets
+type Histogram struct {
+	sync.Mutex
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+// Observe adds the value to the histogram
+func (h *Histogram) Observe(v float64) {
+	h.Lock()
+	defer h.Unlock()
+
+	for i, b := range h.buckets {
+		if v <= b {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+// ObserveSince adds the elapsed seconds from the start time to the histogram
+func (h *Histogram) ObserveSince(start time.Time) {
+	h.Observe(time.Now().Sub(start).Seconds())
+}
+
+func (h *Histogram) writeTo(buffer *bytes.Buffer, name string, labels string) {
+	h.Lock()
+	counts := make([]uint64, len(h.counts))
+	copy(counts, h.counts)
+	count := h.count
+	sum := h.sum
+	h.Unlock()
+
+	prefix := labels
+	if len(prefix) > 0 {
+		prefix += ","
+	}
+	var acc uint64
+	for i, b := range h.buckets {
+		acc += counts[i]
+		writeSample(buffer, name+"_bucket", prefix+`le="`+formatFloat(b)+`"`, float64(acc))
+	}
+	writeSample(buffer, name+"_bucket", prefix+`le="+Inf"`, float64(count))
+	writeSample(buffer, name+"_sum", labels, sum)
+	writeSample(buffer, name+"_count", labels, float64(count))
+}
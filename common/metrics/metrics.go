@@ -0,0 +1,321 @@
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Registry is a set of metrics that is written in the prometheus text format
+type Registry struct {
+	sync.Mutex
+	familyMap map[string]*family
+}
+
+// NewRegistry returns a Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		familyMap: map[string]*family{},
+	}
+}
+
+// DefaultRegistry is the registry of metrics that are created by package functions
+var DefaultRegistry = NewRegistry()
+
+func (r *Registry) register(f *family) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, has := r.familyMap[f.name]; has {
+		panic(ErrExistMetric.Error() + ": " + f.name)
+	}
+	r.familyMap[f.name] = f
+}
+
+// WriteTo writes all metrics in the prometheus text format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.Lock()
+	families := make([]*family, 0, len(r.familyMap))
+	for _, f := range r.familyMap {
+		families = append(families, f)
+	}
+	r.Unlock()
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].name < families[j].name
+	})
+
+	var buffer bytes.Buffer
+	for _, f := range families {
+		f.writeTo(&buffer)
+	}
+	return buffer.WriteTo(w)
+}
+
+// NewCounter returns a Counter that is registered to the default registry
+func NewCounter(name string, help string) *Counter {
+	return NewCounterVec(name, help).With()
+}
+
+// NewCounterVec returns a CounterVec that is registered to the default registry
+func NewCounterVec(name string, help string, labels ...string) *CounterVec {
+	return DefaultRegistry.NewCounterVec(name, help, labels...)
+}
+
+// NewGauge returns a Gauge that is registered to the default registry
+func NewGauge(name string, help string) *Gauge {
+	return NewGaugeVec(name, help).With()
+}
+
+// NewGaugeVec returns a GaugeVec that is registered to the default registry
+func NewGaugeVec(name string, help string, labels ...string) *GaugeVec {
+	return DefaultRegistry.NewGaugeVec(name, help, labels...)
+}
+
+// NewHistogram returns a Histogram that is registered to the default registry
+func NewHistogram(name string, help string, buckets []float64) *Histogram {
+	return NewHistogramVec(name, help, buckets).With()
+}
+
+// NewHistogramVec returns a HistogramVec that is registered to the default registry
+func NewHistogramVec(name string, help string, buckets []float64, labels ...string) *HistogramVec {
+	return DefaultRegistry.NewHistogramVec(name, help, buckets, labels...)
+}
+
+// NewCounterVec returns a CounterVec that is registered to the registry
+func (r *Registry) NewCounterVec(name string, help string, labels ...string) *CounterVec {
+	f := newFamily(name, help, "counter", labels, func() metric {
+		return &Counter{}
+	})
+	r.register(f)
+	return &CounterVec{f: f}
+}
+
+// NewGaugeVec returns a GaugeVec that is registered to the registry
+func (r *Registry) NewGaugeVec(name string, help string, labels ...string) *GaugeVec {
+	f := newFamily(name, help, "gauge", labels, func() metric {
+		return &Gauge{}
+	})
+	r.register(f)
+	return &GaugeVec{f: f}
+}
+
+// NewHistogramVec returns a HistogramVec that is registered to the registry
+// buckets are upper bounds in the ascending order and DefaultBuckets is used when it is empty
+func (r *Registry) NewHistogramVec(name string, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	bs := make([]float64, len(buckets))
+	copy(bs, buckets)
+	sort.Float64s(bs)
+	f := newFamily(name, help, "histogram", labels, func() metric {
+		return &Histogram{
+			buckets: bs,
+			counts:  make([]uint64, len(bs)),
+		}
+	})
+	r.register(f)
+	return &HistogramVec{f: f}
+}
+
+// CounterVec is a set of counters that are partitioned by label values
+type CounterVec struct {
+	f *family
+}
+
+// With returns the counter of label values
+func (v *CounterVec) With(values ...string) *Counter {
+	return v.f.get(values).(*Counter)
+}
+
+// GaugeVec is a set of gauges that are partitioned by label values
+type GaugeVec struct {
+	f *family
+}
+
+// With returns the gauge of label values
+func (v *GaugeVec) With(values ...string) *Gauge {
+	return v.f.get(values).(*Gauge)
+}
+
+// HistogramVec is a set of histograms that are partitioned by label values
+type HistogramVec struct {
+	f *family
+}
+
+// With returns the histogram of label values
+func (v *HistogramVec) With(values ...string) *Histogram {
+	return v.f.get(values).(*Histogram)
+}
+
+// Counter is a metric that only increases
+type Counter struct {
+	value uint64
+}
+
+// Inc increases the counter by 1
+func (c *Counter) Inc() {
+	atomic.AddUint64(&c.value, 1)
+}
+
+// Add increases the counter by n
+func (c *Counter) Add(n uint64) {
+	atomic.AddUint64(&c.value, n)
+}
+
+// Value returns the current value of the counter
+func (c *Counter) Value() uint64 {
+	return atomic.LoadUint64(&c.value)
+}
+
+func (c *Counter) writeTo(buffer *bytes.Buffer, name string, labels string) {
+	writeSample(buffer, name, labels, float64(c.Value()))
+}
+
+// Gauge is a metric that can go up and down
+type Gauge struct {
+	bits uint64
+}
+
+// Set sets the value of the gauge
+func (g *Gauge) Set(v float64) {
+	atomic.StoreUint64(&g.bits, math.Float64bits(v))
+}
+
+// Add adds v to the value of the gauge
+func (g *Gauge) Add(v float64) {
+	for {
+		old := atomic.LoadUint64(&g.bits)
+		if atomic.CompareAndSwapUint64(&g.bits, old, math.Float64bits(math.Float64frombits(old)+v)) {
+			return
+		}
+	}
+}
+
+// Inc increases the gauge by 1
+func (g *Gauge) Inc() {
+	g.Add(1)
+}
+
+// Dec decreases the gauge by 1
+func (g *Gauge) Dec() {
+	g.Add(-1)
+}
+
+// Value returns the current value of the gauge
+func (g *Gauge) Value() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&g.bits))
+}
+
+func (g *Gauge) writeTo(buffer *bytes.Buffer, name string, labels string) {
+	writeSample(buffer, name, labels, g.Value())
+}
+
+type metric interface {
+	writeTo(buffer *bytes.Buffer, name string, labels string)
+}
+
+type family struct {
+	sync.Mutex
+	name     string
+	help     string
+	typeName string
+	labels   []string
+	newFunc  func() metric
+	childMap map[string]metric
+}
+
+func newFamily(name string, help string, typeName string, labels []string, newFunc func() metric) *family {
+	return &family{
+		name:     name,
+		help:     help,
+		typeName: typeName,
+		labels:   labels,
+		newFunc:  newFunc,
+		childMap: map[string]metric{},
+	}
+}
+
+// get returns the metric of label values and creates it when it is not exist
+func (f *family) get(values []string) metric {
+	if len(values) != len(f.labels) {
+		panic(ErrInvalidLabelValues.Error() + ": " + f.name)
+	}
+	var buffer bytes.Buffer
+	for i, v := range values {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		buffer.WriteString(f.labels[i])
+		buffer.WriteString("=")
+		buffer.WriteString(strconv.Quote(v))
+	}
+	key := buffer.String()
+
+	f.Lock()
+	defer f.Unlock()
+
+	m, has := f.childMap[key]
+	if !has {
+		m = f.newFunc()
+		f.childMap[key] = m
+	}
+	return m
+}
+
+func (f *family) writeTo(buffer *bytes.Buffer) {
+	f.Lock()
+	keys := make([]string, 0, len(f.childMap))
+	for k := range f.childMap {
+		keys = append(keys, k)
+	}
+	children := make([]metric, 0, len(keys))
+	sort.Strings(keys)
+	for _, k := range keys {
+		children = append(children, f.childMap[k])
+	}
+	f.Unlock()
+
+	buffer.WriteString("# HELP ")
+	buffer.WriteString(f.name)
+	buffer.WriteString(" ")
+	buffer.WriteString(strings.NewReplacer("\\", `\\`, "\n", `\n`).Replace(f.help))
+	buffer.WriteString("\n# TYPE ")
+	buffer.WriteString(f.name)
+	buffer.WriteString(" ")
+	buffer.WriteString(f.typeName)
+	buffer.WriteString("\n")
+	for i, m := range children {
+		m.writeTo(buffer, f.name, keys[i])
+	}
+}
+
+func writeSample(buffer *bytes.Buffer, name string, labels string, v float64) {
+	buffer.WriteString(name)
+	if len(labels) > 0 {
+		buffer.WriteString("{")
+		buffer.WriteString(labels)
+		buffer.WriteString("}")
+	}
+	buffer.WriteString(" ")
+	buffer.WriteString(formatFloat(v))
+	buffer.WriteString("\n")
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
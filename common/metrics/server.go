@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+// Handler returns a http handler that writes metrics of the registry in the prometheus text format
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteTo(w)
+	})
+}
+
+// Server serves metrics of the registry on the /metrics path
+type Server struct {
+	mux *http.ServeMux
+	srv *http.Server
+}
+
+// NewServer returns a Server of the registry that listens the address
+func NewServer(addr string, r *Registry) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r.Handler())
+	return &Server{
+		mux: mux,
+		srv: &http.Server{
+			Addr:    addr,
+			Handler: mux,
+		},
+	}
+}
+
+// EnableProfiler serves the pprof profiler on the /debug/pprof/ path
+// it should be called before Run
+func (s *Server) EnableProfiler() {
+	s.mux.HandleFunc("/debug/pprof/", pprof.Index)
+	s.mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	s.mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	s.mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	s.mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+}
+
+// Run serves metrics until the server is closed
+func (s *Server) Run() error {
+	if err := s.srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Close stops the server after requests in progress
+func (s *Server) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.srv.Shutdown(ctx)
+}
@@ -61,6 +61,13 @@ func (pubhash PublicHash) Clone() PublicHash {
 	return cp
 }
 
+// PublicHashFromPeerID returns the public hash of the peer id that is the string of public hash bytes
+func PublicHashFromPeerID(ID string) PublicHash {
+	var pubhash PublicHash
+	copy(pubhash[:], []byte(ID))
+	return pubhash
+}
+
 // ParsePublicHash parse the public hash from the string
 func ParsePublicHash(str string) (PublicHash, error) {
 	bs, err := base58.Decode(str)
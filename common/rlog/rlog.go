@@ -3,40 +3,18 @@ package rlog
 import (
 	"bytes"
 	"errors"
-	"log"
 	"net/http"
-	"os"
 	"sync"
 	"time"
 
 	"github.com/fletaio/fleta_testnet/common/binutil"
+	"github.com/fletaio/fleta_testnet/common/logging"
 	lediscfg "github.com/siddontang/ledisdb/config"
 	"github.com/siddontang/ledisdb/ledis"
 )
 
-var logger *log.Logger
 var loggerAddress string
 var loggerHost string
-var loggerUse bool
-
-func init() {
-	logger = log.New(os.Stderr, "", log.LstdFlags)
-}
-
-// Println calls l.Output to print to the logger.
-func Println(v ...interface{}) {
-	logger.Println(v...)
-}
-
-// Fatal is equivalent to l.Print() followed by a call to os.Exit(1).
-func Fatal(v ...interface{}) {
-	logger.Fatal(v...)
-}
-
-// Fatalln is equivalent to l.Println() followed by a call to os.Exit(1).
-func Fatalln(v ...interface{}) {
-	logger.Fatalln(v...)
-}
 
 // errors
 var (
@@ -54,14 +32,13 @@ func SetRLogHost(host string) {
 }
 
 // Enablelogger turn on of the remote log
+// log entries are stored in the path and uploaded to the remote host as a sink of the logging
 func Enablelogger(path string) {
-	loggerUse = true
-
 	lw, err := NewLogWriter(path)
 	if err != nil {
 		panic(err)
 	}
-	logger = log.New(lw, "", log.LstdFlags)
+	logging.AddSink(lw)
 
 	go func() {
 		for {
@@ -72,11 +49,13 @@ func Enablelogger(path string) {
 	}()
 }
 
+// LogWriter is a sink of the logging that stores log entries to upload them to the remote host
 type LogWriter struct {
 	sync.Mutex
 	db *ledis.DB
 }
 
+// NewLogWriter returns a LogWriter that stores log entries in the path
 func NewLogWriter(path string) (*LogWriter, error) {
 	cfg := lediscfg.NewConfigDefault()
 	cfg.DataDir = path
@@ -94,12 +73,9 @@ func NewLogWriter(path string) (*LogWriter, error) {
 	return lw, nil
 }
 
-func (lw *LogWriter) Write(bs []byte) (int, error) {
-	os.Stderr.Write(bs)
-	if !loggerUse {
-		return len(bs), nil
-	}
-
+// WriteEntry stores the entry in the text format until it is uploaded
+func (lw *LogWriter) WriteEntry(e *logging.Entry) {
+	bs := logging.TextFormat.Marshal(e)
 	if bs[len(bs)-1] == '\n' {
 		bs = bs[:len(bs)-1]
 	}
@@ -108,7 +84,7 @@ func (lw *LogWriter) Write(bs []byte) (int, error) {
 	}
 
 	var buffer bytes.Buffer
-	buffer.Write(binutil.LittleEndian.Uint64ToBytes(uint64(time.Now().UnixNano())))
+	buffer.Write(binutil.LittleEndian.Uint64ToBytes(uint64(e.Time.UnixNano())))
 	buffer.Write(binutil.LittleEndian.Uint16ToBytes(uint16(len(bs))))
 	buffer.Write(bs)
 
@@ -116,15 +92,12 @@ func (lw *LogWriter) Write(bs []byte) (int, error) {
 	defer lw.Unlock()
 	count, err := lw.db.LLen([]byte("log"))
 	if err != nil {
-		return 0, err
+		return
 	}
 	if count > 5000000 {
 		lw.db.LPop([]byte("log"))
 	}
-	if _, err := lw.db.RPush([]byte("log"), buffer.Bytes()); err != nil {
-		return 0, err
-	}
-	return len(bs), nil
+	lw.db.RPush([]byte("log"), buffer.Bytes())
 }
 
 // Upload uploads logs to the remote log server
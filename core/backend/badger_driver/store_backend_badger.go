@@ -1,7 +1,6 @@
 package badger_driver
 
 import (
-	"log"
 	"os"
 	"path/filepath"
 	"time"
@@ -33,7 +32,7 @@ func NewStoreBackendBadger(path string) (backend.StoreBackend, error) {
 	if err != nil {
 		return nil, err
 	}
-	log.Println("Badger is opened in", time.Now().Sub(start))
+	logger.Info("Badger is opened", "path", path, "elapsed", time.Now().Sub(start))
 	back := &StoreBackendBadger{
 		db: db,
 	}
@@ -68,7 +67,7 @@ again:
 		}
 	}
 	st.db.Close()
-	log.Println("Badger is closed in", time.Now().Sub(start))
+	logger.Info("Badger is closed", "elapsed", time.Now().Sub(start))
 }
 
 func (st *StoreBackendBadger) View(fn func(txn backend.StoreReader) error) error {
@@ -84,6 +83,8 @@ func (st *StoreBackendBadger) View(fn func(txn backend.StoreReader) error) error
 }
 
 func (st *StoreBackendBadger) Update(fn func(txn backend.StoreWriter) error) error {
+	defer backend.WriteSeconds.With("badger").ObserveSince(time.Now())
+
 	if err := st.db.Update(func(txn *badger.Txn) error {
 		r := &storeBackendBadgerTx{
 			txn: txn,
@@ -0,0 +1,6 @@
+package bolt_driver
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the store backend
+var logger = logging.New("store")
@@ -2,7 +2,6 @@ package bolt_driver
 
 import (
 	"bytes"
-	"log"
 	"os"
 	"time"
 
@@ -33,7 +32,7 @@ func NewStoreBackendBolt(path string) (backend.StoreBackend, error) {
 	}); err != nil {
 		return nil, err
 	}
-	log.Println("Bolt is opened in", time.Now().Sub(start))
+	logger.Info("Bolt is opened", "path", path, "elapsed", time.Now().Sub(start))
 	back := &StoreBackendBolt{
 		db: db,
 	}
@@ -46,7 +45,7 @@ func (st *StoreBackendBolt) Shrink() {
 func (st *StoreBackendBolt) Close() {
 	start := time.Now()
 	st.db.Close()
-	log.Println("Bolt is closed in", time.Now().Sub(start))
+	logger.Info("Bolt is closed", "elapsed", time.Now().Sub(start))
 }
 
 func (st *StoreBackendBolt) View(fn func(txn backend.StoreReader) error) error {
@@ -62,6 +61,8 @@ func (st *StoreBackendBolt) View(fn func(txn backend.StoreReader) error) error {
 }
 
 func (st *StoreBackendBolt) Update(fn func(txn backend.StoreWriter) error) error {
+	defer backend.WriteSeconds.With("bolt").ObserveSince(time.Now())
+
 	if err := st.db.Update(func(txn *bolt.Tx) error {
 		r := &StoreBackendBoltTx{
 			txn: txn,
@@ -2,7 +2,6 @@ package buntdb_driver
 
 import (
 	"bytes"
-	"log"
 	"os"
 	"path/filepath"
 	"sync"
@@ -29,7 +28,7 @@ func NewStoreBackendBuntDB(path string) (backend.StoreBackend, error) {
 	if err != nil {
 		return nil, err
 	}
-	log.Println("BuntDB is opened in", time.Now().Sub(start))
+	logger.Info("BuntDB is opened", "path", path, "elapsed", time.Now().Sub(start))
 	back := &StoreBackendBuntDB{
 		db: db,
 	}
@@ -73,7 +72,7 @@ func (st *StoreBackendBuntDB) Close() {
 	start := time.Now()
 	st.db.Shrink()
 	st.db.Close()
-	log.Println("BuntDB is closed in", time.Now().Sub(start))
+	logger.Info("BuntDB is closed", "elapsed", time.Now().Sub(start))
 }
 
 func (st *StoreBackendBuntDB) View(fn func(txn backend.StoreReader) error) error {
@@ -89,6 +88,8 @@ func (st *StoreBackendBuntDB) View(fn func(txn backend.StoreReader) error) error
 }
 
 func (st *StoreBackendBuntDB) Update(fn func(txn backend.StoreWriter) error) error {
+	defer backend.WriteSeconds.With("buntdb").ObserveSince(time.Now())
+
 	if err := st.db.Update(func(txn *buntdb.Tx) error {
 		r := &storeBackendBuntDBTx{
 			txn: txn,
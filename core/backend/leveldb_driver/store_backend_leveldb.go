@@ -2,7 +2,6 @@ package leveldb_drvier
 
 import (
 	"bytes"
-	"log"
 	"time"
 
 	"github.com/syndtr/goleveldb/leveldb"
@@ -25,7 +24,7 @@ func NewStoreBackendLevelDB(path string) (backend.StoreBackend, error) {
 	if err != nil {
 		return nil, err
 	}
-	log.Println("LevelDB is opened in", time.Now().Sub(start))
+	logger.Info("LevelDB is opened", "path", path, "elapsed", time.Now().Sub(start))
 	back := &StoreBackendLevelDB{
 		db: db,
 	}
@@ -38,7 +37,7 @@ func (st *StoreBackendLevelDB) Shrink() {
 func (st *StoreBackendLevelDB) Close() {
 	start := time.Now()
 	st.db.Close()
-	log.Println("LevelDB is closed in", time.Now().Sub(start))
+	logger.Info("LevelDB is closed", "elapsed", time.Now().Sub(start))
 }
 
 func (st *StoreBackendLevelDB) View(fn func(txn backend.StoreReader) error) error {
@@ -61,6 +60,8 @@ func (st *StoreBackendLevelDB) View(fn func(txn backend.StoreReader) error) erro
 }
 
 func (st *StoreBackendLevelDB) Update(fn func(txn backend.StoreWriter) error) error {
+	defer backend.WriteSeconds.With("leveldb").ObserveSince(time.Now())
+
 	txn, err := st.db.OpenTransaction()
 	if err != nil {
 		return err
@@ -0,0 +1,6 @@
+package backend
+
+import "github.com/fletaio/fleta_testnet/common/metrics"
+
+// WriteSeconds is the time to commit a write transaction by the driver
+var WriteSeconds = metrics.NewHistogramVec("fleta_store_write_seconds", "time to commit a write transaction by the driver", nil, "driver")
@@ -33,3 +33,39 @@ func Create(Name string, Path string) (StoreBackend, error) {
 	}
 	return fn(Path)
 }
+
+// DeleteAll removes all keys of the store
+// keys are deleted in batches to keep each transaction small
+func DeleteAll(db StoreBackend) error {
+	keys := [][]byte{}
+	if err := db.View(func(txn StoreReader) error {
+		return txn.Iterate(nil, func(key []byte, value []byte) error {
+			k := make([]byte, len(key))
+			copy(k, key)
+			keys = append(keys, k)
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > 1000 {
+			n = 1000
+		}
+		if err := db.Update(func(txn StoreWriter) error {
+			for _, key := range keys[:n] {
+				if err := txn.Delete(key); err != nil {
+					if err != ErrNotExistKey {
+						return err
+					}
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
@@ -1,10 +1,10 @@
 package chain
 
 import (
-	"log"
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/hash"
@@ -87,6 +87,7 @@ func (cn *Chain) Init() error {
 	top := genesisContext.Top()
 
 	GenesisHash := hash.Hashes(hash.Hash([]byte(cn.store.Name())), hash.Hash([]byte{cn.store.ChainID()}), genesisContext.Hash())
+	logger.Info("Genesis hash", "hash", GenesisHash)
 	if cn.store.Height() > 0 {
 		if h, err := cn.store.Hash(0); err != nil {
 			return err
@@ -120,7 +121,8 @@ func (cn *Chain) Init() error {
 		}
 	}
 
-	log.Println("Chain loaded", cn.store.Height(), ctx.LastHash().String())
+	logger.Info("Chain loaded", "height", cn.store.Height(), "hash", ctx.LastHash())
+	heightGauge.Set(float64(cn.store.Height()))
 
 	cn.isInit = true
 	return nil
@@ -132,6 +134,7 @@ func (cn *Chain) Provider() types.Provider {
 }
 
 // Close terminates and cleans the chain
+// it waits the current block commit and closes services and the store after it
 func (cn *Chain) Close() {
 	cn.closeLock.Lock()
 	defer cn.closeLock.Unlock()
@@ -140,6 +143,11 @@ func (cn *Chain) Close() {
 	defer cn.Unlock()
 
 	if !cn.isClose {
+		for _, s := range cn.services {
+			if c, is := s.(types.ServiceCloser); is {
+				c.Close()
+			}
+		}
 		cn.store.Close()
 		cn.isClose = true
 	}
@@ -245,6 +253,7 @@ func (cn *Chain) ConnectBlock(b *types.Block, sp SignerProvider) error {
 	cn.Lock()
 	defer cn.Unlock()
 
+	start := time.Now()
 	if err := cn.validateHeader(&b.Header); err != nil {
 		return err
 	}
@@ -253,10 +262,14 @@ func (cn *Chain) ConnectBlock(b *types.Block, sp SignerProvider) error {
 	}
 
 	ctx := types.NewContext(cn.store)
-	if err := cn.executeBlockOnContext(b, ctx, sp); err != nil {
+	if err := cn.executeBlockOnContext(b, ctx, sp, nil); err != nil {
+		return err
+	}
+	if err := cn.connectBlockWithContext(b, ctx); err != nil {
 		return err
 	}
-	return cn.connectBlockWithContext(b, ctx)
+	connectBlockSeconds.ObserveSince(start)
+	return nil
 }
 
 func (cn *Chain) connectBlockWithContext(b *types.Block, ctx *types.Context) error {
@@ -266,7 +279,7 @@ func (cn *Chain) connectBlockWithContext(b *types.Block, ctx *types.Context) err
 	}
 
 	if b.Header.ContextHash != ctx.Hash() {
-		log.Println(ctx.Dump())
+		logger.Error("Context hash mismatch", "height", b.Header.Height, "dump", ctx.Dump())
 		return ErrInvalidContextHash
 	}
 
@@ -294,16 +307,25 @@ func (cn *Chain) connectBlockWithContext(b *types.Block, ctx *types.Context) err
 	}
 
 	top := ctx.Top()
+	start := time.Now()
 	if err := cn.store.StoreBlock(b, top); err != nil {
 		return err
 	}
+	storeBlockSeconds.ObserveSince(start)
+	heightGauge.Set(float64(b.Header.Height))
+	connectedBlocks.Inc()
+	connectedTxs.Add(uint64(len(b.Transactions)))
+	blockTransactionSize.Observe(float64(len(b.Transactions)))
+
 	for _, s := range cn.services {
 		s.OnBlockConnected(b, top.Events, ctx)
 	}
 	return nil
 }
 
-func (cn *Chain) executeBlockOnContext(b *types.Block, ctx *types.Context, sp SignerProvider) error {
+// executeBlockOnContext executes the block on the context and calls fn with each step of the execution when fn is not nil
+// the context is same whether fn is nil or not and it stops at the step that is failed
+func (cn *Chain) executeBlockOnContext(b *types.Block, ctx *types.Context, sp SignerProvider, fn func(step *TraceStep)) error {
 	TxSigners, TxHashes, err := cn.validateTransactionSignatures(b, sp)
 	if err != nil {
 		return err
@@ -315,61 +337,38 @@ func (cn *Chain) executeBlockOnContext(b *types.Block, ctx *types.Context, sp Si
 
 	// BeforeExecuteTransactions
 	for i, p := range cn.processes {
-		if err := p.BeforeExecuteTransactions(types.NewContextWrapper(IDMap[i], ctx)); err != nil {
+		step := &TraceStep{Name: TraceBeforeExecute, ProcessID: IDMap[i], Index: -1}
+		if err := executeHook(ctx, step, p.BeforeExecuteTransactions, fn); err != nil {
 			return err
-		} else if ctx.StackSize() > 1 {
-			return ErrDirtyContext
 		}
 	}
-	if err := cn.app.BeforeExecuteTransactions(types.NewContextWrapper(255, ctx)); err != nil {
+	step := &TraceStep{Name: TraceBeforeExecute, ProcessID: 255, Index: -1}
+	if err := executeHook(ctx, step, cn.app.BeforeExecuteTransactions, fn); err != nil {
 		return err
-	} else if ctx.StackSize() > 1 {
-		return ErrDirtyContext
 	}
 
 	// Execute Transctions
 	currentSlot := types.ToTimeSlot(b.Header.Timestamp)
 	for i, tx := range b.Transactions {
+		t := b.TransactionTypes[i]
+		step := &TraceStep{
+			Name:      TraceTransaction,
+			ProcessID: uint8(t >> 8),
+			Index:     i,
+			TxHash:    TxHashes[i],
+			TxType:    t,
+			Tx:        tx,
+			Signers:   TxSigners[i],
+		}
 		slot := types.ToTimeSlot(tx.Timestamp())
 		if slot < currentSlot-1 {
-			return types.ErrInvalidTransactionTimeSlot
+			return reportStep(step, types.ErrInvalidTransactionTimeSlot, fn)
 		} else if slot > currentSlot {
-			return types.ErrInvalidTransactionTimeSlot
-		}
-
-		signers := TxSigners[i]
-		t := b.TransactionTypes[i]
-		pid := uint8(t >> 8)
-		p, err := cn.Process(pid)
-		if err != nil {
-			return err
-		}
-		ctw := types.NewContextWrapper(pid, ctx)
-
-		sn := ctw.Snapshot()
-		if err := ctx.UseTimeSlot(slot, string(TxHashes[i][:])); err != nil {
-			return err
-		}
-		if err := tx.Validate(p, ctw, signers); err != nil {
-			ctw.Revert(sn)
-			return err
+			return reportStep(step, types.ErrInvalidTransactionTimeSlot, fn)
 		}
-		if err := tx.Execute(p, ctw, uint16(i)); err != nil {
-			ctw.Revert(sn)
+		if err := cn.executeTransaction(ctx, step, slot, string(TxHashes[i][:]), &b.Header.Generator, fn); err != nil {
 			return err
 		}
-		if Has, err := ctw.HasAccount(b.Header.Generator); err != nil {
-			ctw.Revert(sn)
-			if err == types.ErrDeletedAccount {
-				return ErrCannotDeleteGeneratorAccount
-			} else {
-				return err
-			}
-		} else if !Has {
-			ctw.Revert(sn)
-			return ErrCannotDeleteGeneratorAccount
-		}
-		ctw.Commit(sn)
 	}
 
 	if ctx.StackSize() > 1 {
@@ -378,16 +377,18 @@ func (cn *Chain) executeBlockOnContext(b *types.Block, ctx *types.Context, sp Si
 
 	// AfterExecuteTransactions
 	for i, p := range cn.processes {
-		if err := p.AfterExecuteTransactions(b, types.NewContextWrapper(IDMap[i], ctx)); err != nil {
+		step := &TraceStep{Name: TraceAfterExecute, ProcessID: IDMap[i], Index: -1}
+		if err := executeHook(ctx, step, func(ctw *types.ContextWrapper) error {
+			return p.AfterExecuteTransactions(b, ctw)
+		}, fn); err != nil {
 			return err
-		} else if ctx.StackSize() > 1 {
-			return ErrDirtyContext
 		}
 	}
-	if err := cn.app.AfterExecuteTransactions(b, types.NewContextWrapper(255, ctx)); err != nil {
+	step = &TraceStep{Name: TraceAfterExecute, ProcessID: 255, Index: -1}
+	if err := executeHook(ctx, step, func(ctw *types.ContextWrapper) error {
+		return cn.app.AfterExecuteTransactions(b, ctw)
+	}, fn); err != nil {
 		return err
-	} else if ctx.StackSize() > 1 {
-		return ErrDirtyContext
 	}
 	return nil
 }
@@ -516,7 +517,7 @@ func (cn *Chain) validateTransactionSignatures(b *types.Block, sp SignerProvider
 			return nil, nil, err
 		}
 	}
-	log.Println("HitRate", b.Header.Height, hitCount, len(b.Transactions))
+	logger.Debug("Transaction cache hit rate", "height", b.Header.Height, "hits", hitCount, "txs", len(b.Transactions))
 
 	if h, err := BuildLevelRoot(TxHashes); err != nil {
 		return nil, nil, err
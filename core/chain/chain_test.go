@@ -0,0 +1,255 @@
+package chain
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	_ "github.com/fletaio/fleta_testnet/core/backend/buntdb_driver"
+	"github.com/fletaio/fleta_testnet/core/pile"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+var errTestFail = errors.New("test fail")
+
+var testKey = []byte("key")
+
+type testConsensus struct {
+	ConsensusBase
+}
+
+func (cs *testConsensus) Init(cn *Chain, ct Committer) error {
+	return nil
+}
+
+type testAccount struct {
+	Address_ common.Address
+	Name_    string
+}
+
+func (acc *testAccount) Address() common.Address { return acc.Address_ }
+func (acc *testAccount) Name() string            { return acc.Name_ }
+func (acc *testAccount) Clone() types.Account {
+	return &testAccount{Address_: acc.Address_, Name_: acc.Name_}
+}
+func (acc *testAccount) Validate(loader types.LoaderWrapper, signers []common.PublicHash) error {
+	return nil
+}
+func (acc *testAccount) MarshalJSON() ([]byte, error) { return json.Marshal(acc.Name_) }
+
+// testTx sets the value of the test key and it deletes the key when the value is empty
+type testTx struct {
+	Timestamp_ uint64
+	Value      []byte
+	Fail       bool
+}
+
+func (tx *testTx) Timestamp() uint64 { return tx.Timestamp_ }
+func (tx *testTx) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	return nil
+}
+func (tx *testTx) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	ctw.SetProcessData(testKey, tx.Value)
+	if tx.Fail {
+		return errTestFail
+	}
+	return nil
+}
+func (tx *testTx) MarshalJSON() ([]byte, error) { return json.Marshal(tx.Value) }
+
+// testProcess sets the test key after transactions so that the key can be set after it is deleted in the same block
+type testProcess struct {
+	types.ProcessBase
+}
+
+func (p *testProcess) ID() uint8       { return 1 }
+func (p *testProcess) Name() string    { return "test.process" }
+func (p *testProcess) Version() string { return "0.0.1" }
+func (p *testProcess) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
+	reg.RegisterAccount(1, &testAccount{})
+	reg.RegisterTransaction(1, &testTx{})
+	return nil
+}
+func (p *testProcess) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	ctw.SetProcessData(testKey, []byte{byte(b.Header.Height)})
+	return nil
+}
+
+type testApp struct {
+	types.ApplicationBase
+}
+
+func (app *testApp) Name() string    { return "test.app" }
+func (app *testApp) Version() string { return "0.0.1" }
+func (app *testApp) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
+	return nil
+}
+func (app *testApp) InitGenesis(ctw *types.ContextWrapper) error {
+	return ctw.CreateAccount(&testAccount{Address_: testGenerator, Name_: "generator"})
+}
+
+var testGenerator = common.NewAddress(0, 1, 0)
+
+func newTestChain(t *testing.T, dir string) *Chain {
+	return newTestChainOf(t, newTestStore(t, filepath.Join(dir, "context"), filepath.Join(dir, "chain")))
+}
+
+// newTestStore returns the store of the context and the pile of paths so that a context can be opened with the pile of another chain
+func newTestStore(t *testing.T, contextPath string, chainPath string) *Store {
+	back, err := backend.Create("buntdb", contextPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdb, err := pile.Open(chainPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := NewStore(back, cdb, 1, "TEST", "testchain", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+func newTestChainOf(t *testing.T, st *Store) *Chain {
+	cn := NewChain(&testConsensus{}, &testApp{}, st)
+	cn.MustAddProcess(&testProcess{})
+	if err := cn.Init(); err != nil {
+		t.Fatal(err)
+	}
+	return cn
+}
+
+// newTestBlock returns the next block of the transactions and the context hash is filled by the untraced execution
+func newTestBlock(t *testing.T, cn *Chain, txs []*testTx) *types.Block {
+	height, lastHash := cn.Provider().LastStatus()
+	b := &types.Block{
+		Header: types.Header{
+			ChainID:   cn.Provider().ChainID(),
+			Version:   1,
+			Height:    height + 1,
+			PrevHash:  lastHash,
+			Timestamp: uint64(time.Now().UnixNano()),
+			Generator: testGenerator,
+		},
+		TransactionTypes:      []uint16{},
+		Transactions:          []types.Transaction{},
+		TransactionSignatures: [][]common.Signature{},
+	}
+	TxHashes := []hash.Hash256{lastHash}
+	for _, tx := range txs {
+		tx.Timestamp_ = b.Header.Timestamp
+		t := uint16(1)<<8 | 1
+		b.TransactionTypes = append(b.TransactionTypes, t)
+		b.Transactions = append(b.Transactions, tx)
+		b.TransactionSignatures = append(b.TransactionSignatures, []common.Signature{})
+		TxHashes = append(TxHashes, types.HashTransactionByType(b.Header.ChainID, t, tx))
+	}
+	LevelRootHash, err := BuildLevelRoot(TxHashes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Header.LevelRootHash = LevelRootHash
+
+	ctx := types.NewContext(cn.store)
+	if err := cn.executeBlockOnContext(b, ctx, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	b.Header.ContextHash = ctx.Hash()
+	return b
+}
+
+func TestTraceBlockContextHash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cn := newTestChain(t, dir)
+	defer cn.Close()
+
+	// the transaction deletes the key and the process sets it again after transactions
+	b := newTestBlock(t, cn, []*testTx{{Value: nil}})
+
+	steps := []*TraceStep{}
+	ctx, err := cn.TraceBlock(b, func(step *TraceStep) {
+		steps = append(steps, step)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Hash() != b.Header.ContextHash {
+		t.Errorf("got context hash %v, want %v", ctx.Hash(), b.Header.ContextHash)
+	}
+	if bs := ctx.ProcessData(1, testKey); len(bs) != 1 || bs[0] != 1 {
+		t.Errorf("got key %v, want [1]", bs)
+	}
+	names := []string{
+		TraceBeforeExecute, TraceBeforeExecute,
+		TraceTransaction,
+		TraceAfterExecute, TraceAfterExecute,
+	}
+	if len(steps) != len(names) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(names))
+	}
+	for i, step := range steps {
+		if step.Name != names[i] {
+			t.Errorf("step %d: got %s, want %s", i, step.Name, names[i])
+		}
+		if step.Err != nil {
+			t.Errorf("step %d: got error %v", i, step.Err)
+		}
+	}
+
+	if err := cn.ConnectBlock(b, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTraceTransaction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cn := newTestChain(t, dir)
+	defer cn.Close()
+
+	tests := []struct {
+		name string
+		tx   *testTx
+		err  error
+	}{
+		{"success", &testTx{Value: []byte{7}}, nil},
+		{"fail", &testTx{Value: []byte{7}, Fail: true}, errTestFail},
+	}
+	for _, tt := range tests {
+		tt.tx.Timestamp_ = uint64(time.Now().UnixNano())
+		step, err := cn.TraceTransaction(uint16(1)<<8|1, tt.tx, nil, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if step.Err != tt.err {
+			t.Errorf("%s: got error %v, want %v", tt.name, step.Err, tt.err)
+		}
+		if step.Diff == nil {
+			t.Fatalf("%s: diff is not recorded", tt.name)
+		}
+		if len(step.Diff.ProcessData) != 1 {
+			t.Errorf("%s: got %d process data diffs, want 1", tt.name, len(step.Diff.ProcessData))
+		}
+	}
+
+	// the traced transaction is not applied to the chain
+	ctx := types.NewContext(cn.store)
+	if bs := ctx.ProcessData(1, testKey); len(bs) != 0 {
+		t.Errorf("got key %v, want empty", bs)
+	}
+}
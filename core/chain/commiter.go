@@ -34,7 +34,7 @@ func (ct *chainCommiter) ExecuteBlockOnContext(b *types.Block, ctx *types.Contex
 	ct.cn.Lock()
 	defer ct.cn.Unlock()
 
-	return ct.cn.executeBlockOnContext(b, ctx, sp)
+	return ct.cn.executeBlockOnContext(b, ctx, sp, nil)
 }
 
 func (ct *chainCommiter) ConnectBlockWithContext(b *types.Block, ctx *types.Context) error {
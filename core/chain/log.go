@@ -0,0 +1,6 @@
+package chain
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the chain
+var logger = logging.New("chain")
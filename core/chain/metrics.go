@@ -0,0 +1,13 @@
+package chain
+
+import "github.com/fletaio/fleta_testnet/common/metrics"
+
+// metrics of the chain
+var (
+	heightGauge          = metrics.NewGauge("fleta_chain_height", "height of the last connected block")
+	connectedBlocks      = metrics.NewCounter("fleta_chain_connected_blocks_total", "number of connected blocks")
+	connectedTxs         = metrics.NewCounter("fleta_chain_connected_transactions_total", "number of transactions in connected blocks")
+	connectBlockSeconds  = metrics.NewHistogram("fleta_chain_connect_block_seconds", "time to validate, execute and store a block", nil)
+	storeBlockSeconds    = metrics.NewHistogram("fleta_chain_store_block_seconds", "time to store a block and the context data of it", nil)
+	blockTransactionSize = metrics.NewHistogram("fleta_chain_block_transactions", "number of transactions per block", []float64{0, 10, 100, 500, 1000, 2000, 5000, 10000})
+)
@@ -847,6 +847,73 @@ func (st *Store) StoreBlock(b *types.Block, ctd *types.ContextData) error {
 	return nil
 }
 
+// CheckConsistency checks the context can be continued by blocks of the pile
+// blocks that are stored in the pile but not applied to the context are connected by IterBlockAfterContext
+// the context that is ahead of the pile is cleared because it has no undo data of blocks,
+// then the genesis is stored again by the chain and blocks of the pile are replayed to the pile height
+func (st *Store) CheckConsistency() error {
+	height := st.Height()
+	if height == 0 {
+		return nil
+	}
+
+	st.closeLock.RLock()
+	if st.isClose {
+		st.closeLock.RUnlock()
+		return ErrStoreClosed
+	}
+	PileHeight := st.cdb.Height()
+	st.closeLock.RUnlock()
+
+	if PileHeight < height {
+		logger.Warn("Context is ahead of the pile and is replayed from the genesis", "context_height", height, "pile_height", PileHeight)
+		return st.clearContext()
+	}
+	if _, err := st.Header(height); err != nil {
+		return err
+	}
+	if PileHeight > height {
+		LastHash, err := st.Hash(height)
+		if err != nil {
+			return err
+		}
+		bh, err := st.Header(height + 1)
+		if err != nil {
+			return err
+		}
+		if bh.PrevHash != LastHash {
+			return ErrInvalidPrevHash
+		}
+		logger.Info("Store has blocks that are not applied to the context", "from", height+1, "to", PileHeight)
+	}
+	return nil
+}
+
+// clearContext removes all data of the context so that the chain is replayed from the genesis
+func (st *Store) clearContext() error {
+	st.closeLock.RLock()
+	defer st.closeLock.RUnlock()
+	if st.isClose {
+		return ErrStoreClosed
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	if err := backend.DeleteAll(st.db); err != nil {
+		return err
+	}
+
+	st.cache.cached = false
+	st.cache.height = 0
+	st.cache.heightBlock = nil
+	st.timeSlotLock.Lock()
+	st.timeSlotMap = map[uint32]map[string]bool{}
+	st.timeSlotLock.Unlock()
+	return nil
+}
+
+// IterBlockAfterContext connects blocks that are stored in the pile but not applied to the context by the function
 func (st *Store) IterBlockAfterContext(fn func(b *types.Block) error) error {
 	for h := st.Height() + 1; ; h++ {
 		b, err := st.Block(h)
@@ -861,7 +928,11 @@ func (st *Store) IterBlockAfterContext(fn func(b *types.Block) error) error {
 			return err
 		}
 	}
+	return st.LoadTimeSlots()
+}
 
+// LoadTimeSlots loads hashes of transactions of recent blocks to the time slot map of the store
+func (st *Store) LoadTimeSlots() error {
 	Height := st.Height()
 	if Height > 0 {
 		st.timeSlotLock.Lock()
@@ -0,0 +1,70 @@
+package chain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+func TestCheckConsistency(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the long chain connects three blocks and the short chain connects the first block of them
+	long := filepath.Join(dir, "long")
+	short := filepath.Join(dir, "short")
+	cn := newTestChain(t, long)
+	blocks := []*types.Block{}
+	for i := 0; i < 3; i++ {
+		b := newTestBlock(t, cn, []*testTx{{Value: []byte{7}}})
+		if err := cn.ConnectBlock(b, nil); err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, b)
+	}
+	cn.Close()
+	cn = newTestChain(t, short)
+	if err := cn.ConnectBlock(blocks[0], nil); err != nil {
+		t.Fatal(err)
+	}
+	cn.Close()
+
+	tests := []struct {
+		name          string
+		context       string
+		chain         string
+		checkedHeight uint32
+		height        uint32
+	}{
+		{"context behind the pile", short, long, 1, 3},
+		{"context ahead of the pile", long, short, 0, 1},
+	}
+	for _, tt := range tests {
+		st := newTestStore(t, filepath.Join(tt.context, "context"), filepath.Join(tt.chain, "chain"))
+		if err := st.CheckConsistency(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if h := st.Height(); h != tt.checkedHeight {
+			t.Errorf("%s: got height %d after the check, want %d", tt.name, h, tt.checkedHeight)
+		}
+		cn := newTestChainOf(t, st)
+		if err := st.IterBlockAfterContext(func(b *types.Block) error {
+			return cn.ConnectBlock(b, nil)
+		}); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if h := st.Height(); h != tt.height {
+			t.Errorf("%s: got height %d, want %d", tt.name, h, tt.height)
+		}
+		if bs := types.NewContext(st).ProcessData(1, testKey); len(bs) != 1 || bs[0] != byte(tt.height) {
+			t.Errorf("%s: got key %v, want [%d]", tt.name, bs, tt.height)
+		}
+		cn.Close()
+	}
+}
@@ -0,0 +1,169 @@
+package chain
+
+import (
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// trace step names
+const (
+	TraceBeforeExecute = "BeforeExecuteTransactions"
+	TraceTransaction   = "Transaction"
+	TraceAfterExecute  = "AfterExecuteTransactions"
+)
+
+// TraceStep is the result of a step of the block execution
+// Index is the transaction index and it is -1 when the step is not a transaction
+type TraceStep struct {
+	Name      string
+	ProcessID uint8
+	Index     int
+	TxHash    hash.Hash256
+	TxType    uint16
+	Tx        types.Transaction
+	Signers   []common.PublicHash
+	Diff      *types.ContextDiff
+	Err       error
+}
+
+// TraceBlock executes the block on the new context of the chain like ConnectBlock and calls fn with each step of the execution
+// it stops at the step that is failed and it doesn't store anything to the chain
+func (cn *Chain) TraceBlock(b *types.Block, fn func(step *TraceStep)) (*types.Context, error) {
+	cn.closeLock.RLock()
+	defer cn.closeLock.RUnlock()
+	if cn.isClose {
+		return nil, ErrChainClosed
+	}
+
+	cn.Lock()
+	defer cn.Unlock()
+
+	if err := cn.validateHeader(&b.Header); err != nil {
+		return nil, err
+	}
+	if err := cn.consensus.ValidateSignature(&b.Header, b.Signatures); err != nil {
+		return nil, err
+	}
+
+	ctx := types.NewContext(cn.store)
+	if err := cn.executeBlockOnContext(b, ctx, nil, fn); err != nil {
+		return ctx, err
+	}
+	if b.Header.ContextHash != ctx.Hash() {
+		return ctx, ErrInvalidContextHash
+	}
+	return ctx, nil
+}
+
+// TraceTransaction executes the transaction on the new context of the chain as the transaction of the index and returns the step of it
+// the range of the time slot is not checked because there is no block of the transaction
+// the context is created while holding the lock so that it is not changed by the block that is connected in the middle
+// the step always has the error and the diff of the transaction because it is reported to the callback that does nothing
+func (cn *Chain) TraceTransaction(t uint16, tx types.Transaction, signers []common.PublicHash, index uint16) (*TraceStep, error) {
+	cn.closeLock.RLock()
+	defer cn.closeLock.RUnlock()
+	if cn.isClose {
+		return nil, ErrChainClosed
+	}
+
+	cn.Lock()
+	defer cn.Unlock()
+
+	ctx := types.NewContext(cn.store)
+	TxHash := types.HashTransactionByType(cn.store.chainID, t, tx)
+	step := &TraceStep{
+		Name:      TraceTransaction,
+		ProcessID: uint8(t >> 8),
+		Index:     int(index),
+		TxHash:    TxHash,
+		TxType:    t,
+		Tx:        tx,
+		Signers:   signers,
+	}
+	cn.executeTransaction(ctx, step, types.ToTimeSlot(tx.Timestamp()), string(TxHash[:]), nil, func(step *TraceStep) {})
+	return step, nil
+}
+
+// executeHook runs the hook directly on the context and checks that the context is not dirty
+// when fn is not nil, the hook runs on the snapshot first to report the step and the snapshot is reverted before the hook runs on the context
+// because the snapshot that is committed doesn't clear the deleted data of the parent, so the context hash can be different
+func executeHook(ctx *types.Context, step *TraceStep, hook func(ctw *types.ContextWrapper) error, fn func(step *TraceStep)) error {
+	if fn != nil {
+		sn := ctx.Snapshot()
+		err := hook(types.NewContextWrapper(step.ProcessID, ctx))
+		if err == nil && ctx.StackSize() != sn {
+			err = ErrDirtyContext
+		}
+		if ctx.StackSize() >= sn {
+			step.Diff = ctx.Top().Diff()
+		}
+		ctx.Revert(sn)
+		reportStep(step, err, fn)
+	}
+	if err := hook(types.NewContextWrapper(step.ProcessID, ctx)); err != nil {
+		return err
+	} else if ctx.StackSize() > 1 {
+		return ErrDirtyContext
+	}
+	return nil
+}
+
+// reportStep reports the result of the step to fn and returns the error of it
+func reportStep(step *TraceStep, err error, fn func(step *TraceStep)) error {
+	if fn != nil {
+		step.Err = err
+		fn(step)
+	}
+	return err
+}
+
+// traceStep records the diff of the top snapshot to the step and reports it to fn
+func traceStep(ctx *types.Context, step *TraceStep, err error, fn func(step *TraceStep)) error {
+	if fn != nil {
+		step.Diff = ctx.Top().Diff()
+	}
+	return reportStep(step, err, fn)
+}
+
+// executeTransaction validates and executes the transaction of the step on the snapshot of the context
+// the generator is checked that it is not deleted by the transaction when it is not nil
+func (cn *Chain) executeTransaction(ctx *types.Context, step *TraceStep, slot uint32, slotKey string, Generator *common.Address, fn func(step *TraceStep)) error {
+	p, err := cn.Process(step.ProcessID)
+	if err != nil {
+		return reportStep(step, err, fn)
+	}
+	ctw := types.NewContextWrapper(step.ProcessID, ctx)
+
+	sn := ctw.Snapshot()
+	if err := ctx.UseTimeSlot(slot, slotKey); err != nil {
+		return traceStep(ctx, step, err, fn)
+	}
+	if err := step.Tx.Validate(p, ctw, step.Signers); err != nil {
+		traceStep(ctx, step, err, fn)
+		ctw.Revert(sn)
+		return err
+	}
+	if err := step.Tx.Execute(p, ctw, uint16(step.Index)); err != nil {
+		traceStep(ctx, step, err, fn)
+		ctw.Revert(sn)
+		return err
+	}
+	if Generator != nil {
+		if Has, err := ctw.HasAccount(*Generator); err != nil {
+			if err == types.ErrDeletedAccount {
+				err = ErrCannotDeleteGeneratorAccount
+			}
+			traceStep(ctx, step, err, fn)
+			ctw.Revert(sn)
+			return err
+		} else if !Has {
+			traceStep(ctx, step, ErrCannotDeleteGeneratorAccount, fn)
+			ctw.Revert(sn)
+			return ErrCannotDeleteGeneratorAccount
+		}
+	}
+	traceStep(ctx, step, nil, fn)
+	ctw.Commit(sn)
+	return nil
+}
@@ -1,7 +1,6 @@
 package pile
 
 import (
-	"log"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -59,7 +58,7 @@ func Open(path string) (*DB, error) {
 			}
 		}
 	}
-	log.Println("PileDB is opened in", time.Now().Sub(start))
+	logger.Info("PileDB is opened", "height", MaxHeight, "elapsed", time.Now().Sub(start))
 	db := &DB{
 		path:         path,
 		piles:        piles,
@@ -118,7 +117,7 @@ func (db *DB) Close() {
 	for _, p := range db.piles {
 		p.Close()
 	}
-	log.Println("PileDB is closed in", time.Now().Sub(start))
+	logger.Info("PileDB is closed", "elapsed", time.Now().Sub(start))
 	db.piles = []*Pile{}
 }
 
@@ -174,6 +173,17 @@ func (db *DB) AppendData(Height uint32, DataHash hash.Hash256, Datas [][]byte) e
 	return nil
 }
 
+// Height returns the height of the top data
+func (db *DB) Height() uint32 {
+	db.Lock()
+	defer db.Unlock()
+
+	if len(db.piles) == 0 {
+		return 0
+	}
+	return db.piles[len(db.piles)-1].HeadHeight
+}
+
 // GetHash returns a hash value of the height
 func (db *DB) GetHash(Height uint32) (hash.Hash256, error) {
 	db.Lock()
@@ -0,0 +1,6 @@
+package pile
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the pile DB
+var logger = logging.New("store")
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"compress/gzip"
 	"io/ioutil"
-	"log"
 	"os"
 	"sync"
 
@@ -136,7 +135,7 @@ func LoadPile(path string) (*Pile, error) {
 			}
 			HeadHeightCheckB = HeadHeight
 		} else {
-			log.Println("PileDB height crashed", HeadHeight, HeadHeightCheckA, HeadHeightCheckB)
+			logger.Error("PileDB height crashed", "path", path, "head_height", HeadHeight, "check_a", HeadHeightCheckA, "check_b", HeadHeightCheckB)
 			return nil, ErrHeightCrashed
 		}
 	}
@@ -0,0 +1,6 @@
+package txpool
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the transaction pool
+var logger = logging.New("txpool")
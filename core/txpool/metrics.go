@@ -0,0 +1,11 @@
+package txpool
+
+import "github.com/fletaio/fleta_testnet/common/metrics"
+
+// metrics of the transaction pool
+var (
+	sizeGauge     = metrics.NewGauge("fleta_txpool_size", "number of transactions in the pool")
+	pushedTxs     = metrics.NewCounter("fleta_txpool_pushed_total", "number of transactions pushed to the pool")
+	duplicatedTxs = metrics.NewCounter("fleta_txpool_duplicated_total", "number of transactions that are rejected by the pool because they are already in it")
+	expiredTxs    = metrics.NewCounter("fleta_txpool_expired_total", "number of transactions removed from the pool by the expiration")
+)
@@ -54,6 +54,7 @@ func (tp *TransactionPool) Push(t uint16, TxHash hash.Hash256, tx types.Transact
 	defer tp.Unlock()
 
 	if _, has := tp.txhashMap[TxHash]; has {
+		duplicatedTxs.Inc()
 		return ErrExistTransaction
 	}
 
@@ -72,6 +73,8 @@ func (tp *TransactionPool) Push(t uint16, TxHash hash.Hash256, tx types.Transact
 	}
 	q.Push(TxHash, item)
 	tp.txhashMap[TxHash] = item
+	pushedTxs.Inc()
+	sizeGauge.Set(float64(len(tp.txhashMap)))
 	return nil
 }
 
@@ -115,6 +118,7 @@ func (tp *TransactionPool) Remove(TxHash hash.Hash256, tx types.Transaction) {
 	if has {
 		q.Remove(TxHash)
 		delete(tp.txhashMap, TxHash)
+		sizeGauge.Set(float64(len(tp.txhashMap)))
 	}
 }
 
@@ -139,6 +143,11 @@ func (tp *TransactionPool) Clean(currentSlot uint32) []types.Transaction {
 			delete(tp.slotMap, v)
 		}
 	}
+	if len(items) > 0 {
+		expiredTxs.Add(uint64(len(items)))
+		sizeGauge.Set(float64(len(tp.txhashMap)))
+		logger.Debug("Transactions expired", "slot", currentSlot, "count", len(items), "pool_size", len(tp.txhashMap))
+	}
 	return items
 }
 
@@ -0,0 +1,160 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"unicode/utf8"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/encoding"
+)
+
+// ContextDiff is changes of the context data from the parent of it
+type ContextDiff struct {
+	Accounts        []*AccountDiff   `json:"accounts"`
+	DeletedAccounts []common.Address `json:"deleted_accounts"`
+	AccountData     []*DataDiff      `json:"account_data"`
+	ProcessData     []*DataDiff      `json:"process_data"`
+	Events          []Event          `json:"events"`
+}
+
+// IsEmpty returns the diff has no changes or not
+func (diff *ContextDiff) IsEmpty() bool {
+	return len(diff.Accounts) == 0 && len(diff.DeletedAccounts) == 0 && len(diff.AccountData) == 0 && len(diff.ProcessData) == 0 && len(diff.Events) == 0
+}
+
+// AccountDiff is the account before and after the change
+// Before is nil when the account is created
+type AccountDiff struct {
+	Address common.Address `json:"address"`
+	Before  Account        `json:"before"`
+	After   Account        `json:"after"`
+}
+
+// DataDiff is the hex value before and after the change of the data of the process
+// Address is nil when it is the process data
+type DataDiff struct {
+	Address   *common.Address `json:"address,omitempty"`
+	ProcessID uint8           `json:"process_id"`
+	Name      string          `json:"name"`
+	Before    string          `json:"before"`
+	After     string          `json:"after"`
+}
+
+// Diff returns changes of the context data from the parent of it or the loader when it has no parent
+// values that are only loaded to the context data without the change are excluded
+func (ctd *ContextData) Diff() *ContextDiff {
+	diff := &ContextDiff{
+		Accounts:        []*AccountDiff{},
+		DeletedAccounts: []common.Address{},
+		AccountData:     []*DataDiff{},
+		ProcessData:     []*DataDiff{},
+		Events:          ctd.Events,
+	}
+	ctd.AccountMap.EachAll(func(addr common.Address, acc Account) bool {
+		before, err := ctd.parentAccount(addr)
+		if err != nil {
+			before = nil
+		}
+		if before != nil && encoding.Hash(before) == encoding.Hash(acc) {
+			return true
+		}
+		diff.Accounts = append(diff.Accounts, &AccountDiff{
+			Address: addr,
+			Before:  before,
+			After:   acc,
+		})
+		return true
+	})
+	ctd.DeletedAccountMap.EachAll(func(addr common.Address, acc Account) bool {
+		diff.DeletedAccounts = append(diff.DeletedAccounts, addr)
+		return true
+	})
+	ctd.AccountDataMap.EachAll(func(key string, value []byte) bool {
+		if d := ctd.accountDataDiff(key, value); d != nil {
+			diff.AccountData = append(diff.AccountData, d)
+		}
+		return true
+	})
+	ctd.DeletedAccountDataMap.EachAll(func(key string, value bool) bool {
+		if d := ctd.accountDataDiff(key, nil); d != nil {
+			diff.AccountData = append(diff.AccountData, d)
+		}
+		return true
+	})
+	ctd.ProcessDataMap.EachAll(func(key string, value []byte) bool {
+		if d := ctd.processDataDiff(key, value); d != nil {
+			diff.ProcessData = append(diff.ProcessData, d)
+		}
+		return true
+	})
+	ctd.DeletedProcessDataMap.EachAll(func(key string, value bool) bool {
+		if d := ctd.processDataDiff(key, nil); d != nil {
+			diff.ProcessData = append(diff.ProcessData, d)
+		}
+		return true
+	})
+	return diff
+}
+
+func (ctd *ContextData) parentAccount(addr common.Address) (Account, error) {
+	if ctd.Parent != nil {
+		return ctd.Parent.Account(addr)
+	}
+	return ctd.loader.Account(addr)
+}
+
+// accountDataDiff returns the diff of the key of the account data that is composed of the address, the process id and the name
+func (ctd *ContextData) accountDataDiff(key string, value []byte) *DataDiff {
+	if len(key) <= common.AddressSize {
+		return nil
+	}
+	var addr common.Address
+	copy(addr[:], key[:common.AddressSize])
+	r, size := utf8.DecodeRuneInString(key[common.AddressSize:])
+	pid := uint8(r)
+	name := []byte(key[common.AddressSize+size:])
+
+	var before []byte
+	if ctd.Parent != nil {
+		before = ctd.Parent.AccountData(addr, pid, name)
+	} else {
+		before = ctd.loader.AccountData(addr, pid, name)
+	}
+	if bytes.Equal(before, value) {
+		return nil
+	}
+	return &DataDiff{
+		Address:   &addr,
+		ProcessID: pid,
+		Name:      hex.EncodeToString(name),
+		Before:    hex.EncodeToString(before),
+		After:     hex.EncodeToString(value),
+	}
+}
+
+// processDataDiff returns the diff of the key of the process data that is composed of the process id and the name
+func (ctd *ContextData) processDataDiff(key string, value []byte) *DataDiff {
+	if len(key) == 0 {
+		return nil
+	}
+	r, size := utf8.DecodeRuneInString(key)
+	pid := uint8(r)
+	name := []byte(key[size:])
+
+	var before []byte
+	if ctd.Parent != nil {
+		before = ctd.Parent.ProcessData(pid, name)
+	} else {
+		before = ctd.loader.ProcessData(pid, name)
+	}
+	if bytes.Equal(before, value) {
+		return nil
+	}
+	return &DataDiff{
+		ProcessID: pid,
+		Name:      hex.EncodeToString(name),
+		Before:    hex.EncodeToString(before),
+		After:     hex.EncodeToString(value),
+	}
+}
@@ -31,4 +31,6 @@ var (
 	ErrInvalidTransactionIDFormat    = errors.New("invalid transaction id format")
 	ErrUsedTimeSlot                  = errors.New("used timeslot")
 	ErrInvalidTransactionTimeSlot    = errors.New("invalid transaction timeslot")
+	ErrInvalidTransactionJSON        = errors.New("invalid transaction json")
+	ErrInvalidTransactionChainID     = errors.New("invalid transaction chain id")
 )
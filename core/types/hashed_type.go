@@ -1,7 +1,6 @@
 package types
 
 import (
-	"log"
 	"sync"
 
 	"github.com/fletaio/fleta_testnet/common/binutil"
@@ -26,7 +25,7 @@ func DefineHashedType(Name string) uint16 {
 		panic("Type is collapsed (" + old + ", " + Name + ")")
 	}
 	gDefineMap[t] = Name
-	log.Println("Type Defined", t, Name)
+	logger.Debug("Type defined", "type", t, "name", Name)
 	return t
 }
 
@@ -0,0 +1,6 @@
+package types
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the chain types
+var logger = logging.New("chain")
@@ -9,6 +9,12 @@ type Service interface {
 	OnTransactionInPoolExpired(txs []Transaction)
 }
 
+// ServiceCloser is a service that has resources to be released when the chain is closed
+// it is closed after the current block commit so that its store keeps up with the chain
+type ServiceCloser interface {
+	Close()
+}
+
 // ServiceBase is a base handler of the chain service
 type ServiceBase struct{}
 
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/encoding"
+)
+
+// TransactionJSON is the json form of the transaction with signatures that is used by dry runs
+// Tx is the hex string of the transaction that is encoded by EncodeTransaction or the object of fields of the transaction of the type
+// keys of the object are matched to field names without underscores and cases, so the json of the transaction itself is accepted
+// Signers are used instead of recovering them from Signatures when they are given and then signatures are not checked
+type TransactionJSON struct {
+	Type       uint16              `json:"type"`
+	Tx         json.RawMessage     `json:"tx"`
+	Signatures []common.Signature  `json:"signatures"`
+	Signers    []common.PublicHash `json:"signers"`
+}
+
+// Transaction returns the type and the transaction of the json
+func (tj *TransactionJSON) Transaction(ChainID uint8) (uint16, Transaction, error) {
+	if len(tj.Tx) == 0 {
+		return 0, nil, ErrInvalidTransactionJSON
+	}
+	fc := encoding.Factory("transaction")
+
+	var str string
+	if err := json.Unmarshal(tj.Tx, &str); err == nil {
+		bs, err := hex.DecodeString(str)
+		if err != nil {
+			return 0, nil, err
+		}
+		cid, tx, t, err := DecodeTransaction(fc, bs)
+		if err != nil {
+			return 0, nil, err
+		}
+		if cid != ChainID {
+			return 0, nil, ErrInvalidTransactionChainID
+		}
+		return t, tx, nil
+	}
+
+	v, err := fc.Create(tj.Type)
+	if err != nil {
+		return 0, nil, err
+	}
+	tx, is := v.(Transaction)
+	if !is {
+		return 0, nil, ErrInvalidTransactionJSON
+	}
+	var fieldMap map[string]json.RawMessage
+	if err := json.Unmarshal(tj.Tx, &fieldMap); err != nil {
+		return 0, nil, err
+	}
+	keyMap := map[string]json.RawMessage{}
+	for k, v := range fieldMap {
+		keyMap[jsonFieldKey(k)] = v
+	}
+	if err := unmarshalJSONFields(keyMap, reflect.ValueOf(tx).Elem()); err != nil {
+		return 0, nil, err
+	}
+	return tj.Type, tx, nil
+}
+
+// IsSignatureUnchecked returns true when signers are given instead of being recovered from signatures
+func (tj *TransactionJSON) IsSignatureUnchecked() bool {
+	return len(tj.Signers) > 0
+}
+
+// SignerHashes returns signers of the transaction of the hash that are given or recovered from signatures
+func (tj *TransactionJSON) SignerHashes(TxHash hash.Hash256) ([]common.PublicHash, error) {
+	if len(tj.Signers) > 0 {
+		return tj.Signers, nil
+	}
+	signers := make([]common.PublicHash, 0, len(tj.Signatures))
+	for _, sig := range tj.Signatures {
+		pubkey, err := common.RecoverPubkey(TxHash, sig)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, common.NewPublicHash(pubkey))
+	}
+	return signers, nil
+}
+
+func jsonFieldKey(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "", -1))
+}
+
+func unmarshalJSONFields(keyMap map[string]json.RawMessage, rv reflect.Value) error {
+	if rv.Kind() != reflect.Struct {
+		return ErrInvalidTransactionJSON
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := unmarshalJSONFields(keyMap, rv.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(f.PkgPath) > 0 {
+			continue
+		}
+		if raw, has := keyMap[jsonFieldKey(f.Name)]; has {
+			if err := json.Unmarshal(raw, rv.Field(i).Addr().Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -7,10 +7,8 @@ import (
 
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/binutil"
-	"github.com/fletaio/fleta_testnet/common/debug"
 	"github.com/fletaio/fleta_testnet/common/hash"
 	"github.com/fletaio/fleta_testnet/common/key"
-	"github.com/fletaio/fleta_testnet/common/rlog"
 	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/service/p2p"
 	"github.com/fletaio/fleta_testnet/service/p2p/peer"
@@ -48,7 +46,7 @@ func (ms *FormulatorNodeMesh) Run() {
 					ms.Unlock()
 					if !has {
 						if err := ms.client(NetAddr, pubhash); err != nil {
-							rlog.Println("[client]", err, NetAddr)
+							logger.Debug("Observer connection failed", "addr", NetAddr, "err", err)
 						}
 					}
 				}
@@ -119,12 +117,12 @@ func (ms *FormulatorNodeMesh) client(Address string, TargetPubHash common.Public
 	defer conn.Close()
 
 	if err := ms.recvHandshake(conn); err != nil {
-		rlog.Println("[recvHandshake]", err)
+		logger.Warn("Handshake failed", "step", "recv", "err", err)
 		return err
 	}
 	pubhash, err := ms.sendHandshake(conn)
 	if err != nil {
-		rlog.Println("[sendHandshake]", err)
+		logger.Warn("Handshake failed", "step", "send", "err", err)
 		return err
 	}
 	if pubhash != TargetPubHash {
@@ -143,24 +141,26 @@ func (ms *FormulatorNodeMesh) client(Address string, TargetPubHash common.Public
 	defer ms.RemovePeer(p.ID())
 
 	if err := ms.handleConnection(p); err != nil {
-		rlog.Println("[handleConnection]", err)
+		logger.Debug("Observer disconnected", "peer", p.Name(), "err", err)
 	}
 	return nil
 }
 
 func (ms *FormulatorNodeMesh) handleConnection(p peer.Peer) error {
-	if debug.DEBUG {
-		rlog.Println("Formulator", common.NewPublicHash(ms.key.PublicKey()).String(), "Observer Connected", p.Name())
-	}
+	logger.Info("Observer connected", "formulator", ms.fr.Config.Formulator, "peer", p.Name())
 
 	ms.fr.OnObserverConnected(p)
 	defer ms.fr.OnObserverDisconnected(p)
 
+	peersGauge.With(formulatorMesh).Inc()
+	defer peersGauge.With(formulatorMesh).Dec()
+
 	for {
 		bs, err := p.ReadPacket()
 		if err != nil {
 			return err
 		}
+		recvMessages.With(formulatorMesh, p2p.PacketMessageName(bs)).Inc()
 		if err := ms.fr.onObserverRecv(p, bs); err != nil {
 			return err
 		}
@@ -2,7 +2,6 @@ package pof
 
 import (
 	"bytes"
-	"log"
 	"runtime"
 	"sync"
 	"time"
@@ -203,7 +202,7 @@ func (fr *FormulatorNode) Run(BindAddress string) {
 					}
 					if err := fr.addTx(ctw, item.TxHash, item.Type, item.Tx, item.Sigs); err != nil {
 						if err != p2p.ErrInvalidUTXO && err != txpool.ErrExistTransaction && err != txpool.ErrTransactionPoolOverflowed && err != types.ErrUsedTimeSlot && err != types.ErrInvalidTransactionTimeSlot {
-							rlog.Println("TransactionError", item.TxHash.String(), err.Error())
+							logger.Debug("Transaction rejected", "tx_hash", item.TxHash, "peer", common.PublicHashFromPeerID(item.PeerID), "err", err)
 							if len(item.PeerID) > 0 {
 								fr.nm.AddBadPoint(item.PeerID, 1)
 							}
@@ -266,12 +265,12 @@ func (fr *FormulatorNode) Run(BindAddress string) {
 				}
 				m, err := p2p.PacketToMessage(item.Packet)
 				if err != nil {
-					log.Println("PacketToMessage", err)
+					logger.Warn("Invalid peer packet", "peer", common.PublicHashFromPeerID(item.PeerID), "err", err)
 					fr.nm.RemovePeer(item.PeerID)
 					continue
 				}
 				if err := fr.handlePeerMessage(item.PeerID, m); err != nil {
-					log.Println("handlePeerMessage", err)
+					logger.Warn("Peer message failed", "peer", common.PublicHashFromPeerID(item.PeerID), "err", err)
 					fr.nm.RemovePeer(item.PeerID)
 					continue
 				}
@@ -321,7 +320,7 @@ func (fr *FormulatorNode) Run(BindAddress string) {
 				if gi.BlockGen != nil && gi.Context != nil {
 					if gi.BlockGen.Block.Header.Generator == b.Header.Generator {
 						if err := fr.cs.ct.ConnectBlockWithContext(b, gi.Context); err != nil {
-							log.Println("blockQ.ConnectBlockWithContext", err)
+							logger.Warn("Block connect with context failed", "height", b.Header.Height, "err", err)
 						} else {
 							isConnected = true
 						}
@@ -335,7 +334,7 @@ func (fr *FormulatorNode) Run(BindAddress string) {
 			}
 			delete(fr.blockWaitMap, b.Header.Height)
 			fr.cleanPool(b)
-			rlog.Println("Formulator", fr.Config.Formulator.String(), "BlockConnected", b.Header.Generator.String(), b.Header.Height, len(b.Transactions))
+			logger.Info("Block connected", "formulator", fr.Config.Formulator, "height", b.Header.Height, "generator", b.Header.Generator, "txs", len(b.Transactions))
 
 			txs := fr.txpool.Clean(types.ToTimeSlot(b.Header.Timestamp))
 			svcs := fr.cs.cn.Services()
@@ -2,13 +2,11 @@ package pof
 
 import (
 	"bytes"
-	"log"
 	"runtime"
 	"time"
 
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/hash"
-	"github.com/fletaio/fleta_testnet/common/rlog"
 	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/encoding"
@@ -59,7 +57,7 @@ func (fr *FormulatorNode) handleObserverMessage(p peer.Peer, m interface{}, Retr
 
 	switch msg := m.(type) {
 	case *BlockReqMessage:
-		rlog.Println("Formulator", fr.Config.Formulator.String(), "BlockReqMessage", msg.TargetHeight)
+		logger.Debug("Block requested", "formulator", fr.Config.Formulator, "target_height", msg.TargetHeight)
 
 		TargetHeight := fr.cs.cn.Provider().Height() + 1
 		if msg.TargetHeight < TargetHeight {
@@ -156,7 +154,7 @@ func (fr *FormulatorNode) handleObserverMessage(p peer.Peer, m interface{}, Retr
 		}(p.ID(), msg)
 		return nil
 	case *BlockGenMessage:
-		rlog.Println("Formulator", fr.Config.Formulator.String(), "Recv.BlockGenMessage", msg.Block.Header.Height)
+		logger.Debug("Block gen received", "formulator", fr.Config.Formulator, "height", msg.Block.Header.Height)
 
 		TargetHeight := fr.cs.cn.Provider().Height() + 1
 		if msg.Block.Header.Height < TargetHeight {
@@ -192,7 +190,7 @@ func (fr *FormulatorNode) handleObserverMessage(p peer.Peer, m interface{}, Retr
 		go fr.updateByGenItem()
 		return nil
 	case *BlockObSignMessage:
-		rlog.Println("Formulator", fr.Config.Formulator.String(), "Recv.BlockObSignMessage", msg.TargetHeight)
+		logger.Debug("Observer sign received", "formulator", fr.Config.Formulator, "target_height", msg.TargetHeight)
 
 		TargetHeight := fr.cs.cn.Provider().Height() + 1
 		if msg.TargetHeight < TargetHeight {
@@ -228,7 +226,7 @@ func (fr *FormulatorNode) handleObserverMessage(p peer.Peer, m interface{}, Retr
 		go fr.updateByGenItem()
 		return nil
 	case *p2p.BlockMessage:
-		log.Println("Recv.Ob.BlockMessage", msg.Blocks[0].Header.Height)
+		logger.Debug("Blocks received from the observer", "formulator", fr.Config.Formulator, "height", msg.Blocks[0].Header.Height, "count", len(msg.Blocks))
 		for _, b := range msg.Blocks {
 			if err := fr.addBlock(b); err != nil {
 				if err == chain.ErrFoundForkedBlock {
@@ -353,7 +351,7 @@ func (fr *FormulatorNode) updateByGenItem() {
 					ctx = fr.cs.cn.NewContext()
 				}
 				if err := fr.cs.ct.ExecuteBlockOnContext(item.BlockGen.Block, ctx, fr.txpool); err != nil {
-					log.Println("updateByGenItem.prevItem.ConnectBlockWithContext", err)
+					logger.Warn("Block gen execution failed", "formulator", fr.Config.Formulator, "height", item.BlockGen.Block.Header.Height, "err", err)
 					return
 				}
 				target.Context = ctx
@@ -367,7 +365,7 @@ func (fr *FormulatorNode) updateByGenItem() {
 			}
 			return
 		}
-		log.Println("updateByGenItem", TargetHeight, item.BlockGen != nil, item.ObSign != nil, item.Context != nil)
+		logger.Debug("Gen item updated", "formulator", fr.Config.Formulator, "target_height", TargetHeight, "has_block_gen", item.BlockGen != nil, "has_ob_sign", item.ObSign != nil, "has_context", item.Context != nil)
 
 		b := &types.Block{
 			Header:                item.BlockGen.Block.Header,
@@ -378,14 +376,14 @@ func (fr *FormulatorNode) updateByGenItem() {
 		}
 		if item.Context != nil {
 			if err := fr.cs.ct.ConnectBlockWithContext(b, item.Context); err != nil {
-				log.Println("updateByGenItem.ConnectBlockWithContext", err)
+				logger.Warn("Block connect with context failed", "formulator", fr.Config.Formulator, "height", b.Header.Height, "err", err)
 				delete(fr.lastGenItemMap, b.Header.Height)
 				go fr.tryRequestBlocks()
 				return
 			}
 		} else {
 			if err := fr.cs.cn.ConnectBlock(b, fr.txpool); err != nil {
-				log.Println("updateByGenItem.ConnectBlock", err)
+				logger.Warn("Block connect failed", "formulator", fr.Config.Formulator, "height", b.Header.Height, "err", err)
 				delete(fr.lastGenItemMap, b.Header.Height)
 				go fr.tryRequestBlocks()
 				return
@@ -393,7 +391,7 @@ func (fr *FormulatorNode) updateByGenItem() {
 		}
 		fr.broadcastStatus()
 		fr.cleanPool(b)
-		rlog.Println("Formulator", fr.Config.Formulator.String(), "BlockConnected", b.Header.Generator.String(), b.Header.Height, len(b.Transactions))
+		logger.Info("Block connected", "formulator", fr.Config.Formulator, "height", b.Header.Height, "generator", b.Header.Generator, "txs", len(b.Transactions))
 		delete(fr.lastGenItemMap, b.Header.Height)
 
 		txs := fr.txpool.Clean(types.ToTimeSlot(b.Header.Timestamp))
@@ -426,7 +424,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 		StartBlockTime = LastTimestamp + uint64(time.Millisecond)
 	}
 
-	rlog.Println("Formulator", fr.Config.Formulator.String(), "BlockGenBegin", msg.TargetHeight, fr.txpool.Size())
+	logger.Debug("Block gen begins", "formulator", fr.Config.Formulator, "target_height", msg.TargetHeight, "pool_size", fr.txpool.Size())
 
 	MaxTxPerBlock := fr.Config.MaxTransactionsPerBlock
 	EvenTxPerBlock := int(float64(fr.txpool.Size()) / float64(RemainBlocks) * 1.2)
@@ -482,6 +480,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 		if err := enc.EncodeUint32(TimeoutCount); err != nil {
 			return err
 		}
+		genStart := time.Now()
 		bc := chain.NewBlockCreator(fr.cs.cn, ctx, msg.Formulator, buffer.Bytes(), Timestamp)
 		if err := bc.Init(); err != nil {
 			return err
@@ -509,7 +508,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 					break TxLoop
 				}
 				if err := bc.UnsafeAddTx(fr.Config.Formulator, item.TxType, item.TxHash, item.Transaction, item.Signatures, item.Signers); err != nil {
-					rlog.Println("UnsafeAddTx", err)
+					logger.Debug("Transaction skipped in the block gen", "formulator", fr.Config.Formulator, "tx_hash", item.TxHash, "err", err)
 					continue
 				}
 				Count++
@@ -524,6 +523,7 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 		if err != nil {
 			return err
 		}
+		generateBlockSeconds.ObserveSince(genStart)
 
 		sm := &BlockGenMessage{
 			Block: b,
@@ -536,8 +536,9 @@ func (fr *FormulatorNode) genBlock(ID string, msg *BlockReqMessage) error {
 			sm.GeneratorSignature = sig
 		}
 		fr.ms.SendTo(ID, sm)
+		generatedBlocks.Inc()
 
-		rlog.Println("Formulator", fr.Config.Formulator.String(), "Send.BlockGenMessage", sm.Block.Header.Height, len(sm.Block.Transactions))
+		logger.Info("Block generated", "formulator", fr.Config.Formulator, "height", sm.Block.Header.Height, "txs", len(sm.Block.Transactions))
 
 		fr.lastGenItemMap[sm.Block.Header.Height] = &genItem{
 			BlockGen: sm,
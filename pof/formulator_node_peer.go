@@ -1,12 +1,10 @@
 package pof
 
 import (
-	"log"
 	"math/rand"
 
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/hash"
-	"github.com/fletaio/fleta_testnet/common/rlog"
 	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/service/p2p"
@@ -103,12 +101,12 @@ func (fr *FormulatorNode) handlePeerMessage(ID string, m interface{}) error {
 			}
 			if h != msg.LastHash {
 				//TODO : critical error signal
-				rlog.Println(chain.ErrFoundForkedBlock, ID, h.String(), msg.LastHash.String(), msg.Height)
+				logger.Error("Forked block found", "peer", SenderPublicHash, "height", msg.Height, "hash", h, "peer_hash", msg.LastHash)
 				fr.nm.RemovePeer(ID)
 			}
 		}
 	case *p2p.BlockMessage:
-		log.Println("Recv.BlockMessage", SenderPublicHash.String(), msg.Blocks[0].Header.Height)
+		logger.Debug("Blocks received", "peer", SenderPublicHash, "height", msg.Blocks[0].Header.Height, "count", len(msg.Blocks))
 		for _, b := range msg.Blocks {
 			if err := fr.addBlock(b); err != nil {
 				if err == chain.ErrFoundForkedBlock {
@@ -1,7 +1,6 @@
 package pof
 
 import (
-	"log"
 	"time"
 
 	"github.com/fletaio/fleta_testnet/common"
@@ -113,7 +112,7 @@ func (fr *FormulatorNode) sendRequestBlockToNode(TargetPubHash common.PublicHash
 	}
 	for i := uint32(0); i < uint32(Count); i++ {
 		if fr.requestTimer.Add(Height+i, 5*time.Second, string(TargetPubHash[:])) {
-			log.Println("sendRequestBlockToNode", TargetPubHash.String(), Height, Count)
+			logger.Debug("Blocks requested", "peer", TargetPubHash, "height", Height+i, "count", Count)
 
 			nm := &p2p.RequestMessage{
 				Height: Height,
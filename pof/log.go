@@ -0,0 +1,6 @@
+package pof
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the pof consensus
+var logger = logging.New("pof")
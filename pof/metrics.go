@@ -0,0 +1,19 @@
+package pof
+
+import "github.com/fletaio/fleta_testnet/common/metrics"
+
+// metrics of the pof consensus
+var (
+	peersGauge           = metrics.NewGaugeVec("fleta_pof_peers", "number of connected peers by the mesh", "mesh")
+	recvMessages         = metrics.NewCounterVec("fleta_pof_received_messages_total", "number of messages received by the mesh and the message type", "mesh", "type")
+	roundFailures        = metrics.NewCounter("fleta_pof_round_failures_total", "number of vote rounds that are failed and reset by the observer")
+	generatedBlocks      = metrics.NewCounter("fleta_pof_generated_blocks_total", "number of blocks generated by the formulator")
+	generateBlockSeconds = metrics.NewHistogram("fleta_pof_generate_block_seconds", "time to generate a block by the formulator", nil)
+)
+
+// mesh names of metrics
+const (
+	observerMesh      = "observer"
+	formulatorService = "formulator_service"
+	formulatorMesh    = "formulator"
+)
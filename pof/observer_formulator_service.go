@@ -8,10 +8,8 @@ import (
 
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/binutil"
-	"github.com/fletaio/fleta_testnet/common/debug"
 	"github.com/fletaio/fleta_testnet/common/hash"
 	"github.com/fletaio/fleta_testnet/common/key"
-	"github.com/fletaio/fleta_testnet/common/rlog"
 	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/service/p2p"
 	"github.com/fletaio/fleta_testnet/service/p2p/peer"
@@ -89,9 +87,7 @@ func (ms *FormulatorService) SendTo(addr common.Address, bs []byte) error {
 }
 
 func (ms *FormulatorService) server(BindAddress string) error {
-	if debug.DEBUG {
-		rlog.Println("FormulatorService", common.NewPublicHash(ms.key.PublicKey()), "Start to Listen", BindAddress)
-	}
+	logger.Info("Formulator service listens", "public_hash", common.NewPublicHash(ms.key.PublicKey()), "addr", BindAddress)
 
 	var upgrader = websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
@@ -108,16 +104,16 @@ func (ms *FormulatorService) server(BindAddress string) error {
 
 		pubhash, err := ms.sendHandshake(conn)
 		if err != nil {
-			rlog.Println("[sendHandshake]", err)
+			logger.Warn("Formulator handshake failed", "step", "send", "err", err)
 			return err
 		}
 		Formulator, err := ms.recvHandshake(conn)
 		if err != nil {
-			rlog.Println("[recvHandshakeAck]", err)
+			logger.Warn("Formulator handshake failed", "step", "recv ack", "err", err)
 			return err
 		}
 		if !ms.ob.cs.rt.IsFormulator(Formulator, pubhash) {
-			rlog.Println("[IsFormulator]", Formulator.String(), pubhash.String())
+			logger.Warn("Formulator handshake failed", "step", "not formulator", "formulator", Formulator, "public_hash", pubhash)
 			return err
 		}
 
@@ -130,7 +126,7 @@ func (ms *FormulatorService) server(BindAddress string) error {
 		defer ms.RemovePeer(p.ID())
 
 		if err := ms.handleConnection(p); err != nil {
-			rlog.Println("[handleConnection]", err)
+			logger.Debug("Formulator disconnected", "peer", p.Name(), "err", err)
 			return nil
 		}
 		return nil
@@ -139,18 +135,20 @@ func (ms *FormulatorService) server(BindAddress string) error {
 }
 
 func (ms *FormulatorService) handleConnection(p peer.Peer) error {
-	if debug.DEBUG {
-		rlog.Println("Observer", common.NewPublicHash(ms.key.PublicKey()).String(), "Fromulator Connected", p.Name())
-	}
+	logger.Info("Formulator connected", "peer", p.Name())
 
 	ms.ob.OnFormulatorConnected(p)
 	defer ms.ob.OnFormulatorDisconnected(p)
 
+	peersGauge.With(formulatorService).Inc()
+	defer peersGauge.With(formulatorService).Dec()
+
 	for {
 		bs, err := p.ReadPacket()
 		if err != nil {
 			return err
 		}
+		recvMessages.With(formulatorService, p2p.PacketMessageName(bs)).Inc()
 		if err := ms.ob.onFormulatorRecv(p, bs); err != nil {
 			return err
 		}
@@ -8,10 +8,8 @@ import (
 
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/binutil"
-	"github.com/fletaio/fleta_testnet/common/debug"
 	"github.com/fletaio/fleta_testnet/common/hash"
 	"github.com/fletaio/fleta_testnet/common/key"
-	"github.com/fletaio/fleta_testnet/common/rlog"
 	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/service/p2p"
 	"github.com/fletaio/fleta_testnet/service/p2p/peer"
@@ -52,7 +50,7 @@ func (ms *ObserverNodeMesh) Run(BindAddress string) {
 					ms.Unlock()
 					if !hasC && !hasS {
 						if err := ms.client(NetAddr, pubhash); err != nil {
-							rlog.Println("[client]", err, NetAddr)
+							logger.Debug("Observer connection failed", "addr", NetAddr, "err", err)
 						}
 					}
 					time.Sleep(1 * time.Second)
@@ -187,12 +185,12 @@ func (ms *ObserverNodeMesh) client(Address string, TargetPubHash common.PublicHa
 
 	start := time.Now()
 	if err := ms.recvHandshake(conn); err != nil {
-		rlog.Println("[recvHandshake]", err)
+		logger.Warn("Observer handshake failed", "step", "recv", "err", err)
 		return err
 	}
 	pubhash, err := ms.sendHandshake(conn)
 	if err != nil {
-		rlog.Println("[sendHandshake]", err)
+		logger.Warn("Observer handshake failed", "step", "send", "err", err)
 		return err
 	}
 	if pubhash != TargetPubHash {
@@ -211,7 +209,7 @@ func (ms *ObserverNodeMesh) client(Address string, TargetPubHash common.PublicHa
 	defer ms.removePeerInMap(p.ID(), ms.clientPeerMap)
 
 	if err := ms.handleConnection(p); err != nil {
-		rlog.Println("[handleConnection]", err)
+		logger.Debug("Observer disconnected", "peer", p.Name(), "err", err)
 	}
 	return nil
 }
@@ -221,7 +219,7 @@ func (ms *ObserverNodeMesh) server(BindAddress string) error {
 	if err != nil {
 		return err
 	}
-	rlog.Println(common.NewPublicHash(ms.key.PublicKey()), "Start to Listen", BindAddress)
+	logger.Info("Observer mesh listens", "public_hash", common.NewPublicHash(ms.key.PublicKey()), "addr", BindAddress)
 	for {
 		conn, err := lstn.Accept()
 		if err != nil {
@@ -233,15 +231,15 @@ func (ms *ObserverNodeMesh) server(BindAddress string) error {
 			start := time.Now()
 			pubhash, err := ms.sendHandshake(conn)
 			if err != nil {
-				rlog.Println("[sendHandshake]", err)
+				logger.Warn("Observer handshake failed", "step", "send", "err", err)
 				return
 			}
 			if _, has := ms.netAddressMap[pubhash]; !has {
-				rlog.Println("ErrInvalidPublicHash")
+				logger.Warn("Observer handshake failed", "step", "not observer", "public_hash", pubhash)
 				return
 			}
 			if err := ms.recvHandshake(conn); err != nil {
-				rlog.Println("[recvHandshakeAck]", err)
+				logger.Warn("Observer handshake failed", "step", "recv ack", "err", err)
 				return
 			}
 
@@ -254,22 +252,24 @@ func (ms *ObserverNodeMesh) server(BindAddress string) error {
 			defer ms.removePeerInMap(p.ID(), ms.serverPeerMap)
 
 			if err := ms.handleConnection(p); err != nil {
-				rlog.Println("[handleConnection]", err)
+				logger.Debug("Observer disconnected", "peer", p.Name(), "err", err)
 			}
 		}()
 	}
 }
 
 func (ms *ObserverNodeMesh) handleConnection(p peer.Peer) error {
-	if debug.DEBUG {
-		rlog.Println("Observer", common.NewPublicHash(ms.key.PublicKey()).String(), "Observer Connected", p.Name())
-	}
+	logger.Info("Observer connected", "peer", p.Name())
+
+	peersGauge.With(observerMesh).Inc()
+	defer peersGauge.With(observerMesh).Dec()
 
 	for {
 		bs, err := p.ReadPacket()
 		if err != nil {
 			return err
 		}
+		recvMessages.With(observerMesh, p2p.PacketMessageName(bs)).Inc()
 		if err := ms.ob.onObserverRecv(p, bs); err != nil {
 			return err
 		}
@@ -1,15 +1,14 @@
 package pof
 
 import (
-	"log"
 	"sync"
 	"time"
 
 	"github.com/bluele/gcache"
 
 	"github.com/fletaio/fleta_testnet/common"
-	"github.com/fletaio/fleta_testnet/common/debug"
 	"github.com/fletaio/fleta_testnet/common/key"
+	"github.com/fletaio/fleta_testnet/common/logging"
 	"github.com/fletaio/fleta_testnet/common/queue"
 	"github.com/fletaio/fleta_testnet/common/rlog"
 	"github.com/fletaio/fleta_testnet/core/chain"
@@ -56,18 +55,19 @@ type ObserverNode struct {
 // NewObserverNode returns a ObserverNode
 func NewObserverNode(key key.Key, NetAddressMap map[common.PublicHash]string, cs *Consensus) *ObserverNode {
 	ob := &ObserverNode{
-		key:          key,
-		cs:           cs,
-		round:        NewVoteRound(cs.cn.Provider().Height()+1, cs.maxBlocksPerFormulator),
-		ignoreMap:    map[common.Address]int64{},
-		myPublicHash: common.NewPublicHash(key.PublicKey()),
-		statusMap:    map[string]*p2p.Status{},
-		blockQ:       queue.NewSortedQueue(),
-		messageQueue: queue.NewQueue(),
-		recvChan:     make(chan *p2p.RecvMessageItem, 1000),
-		sendChan:     make(chan *p2p.SendMessageItem, 1000),
-		singleCache:  gcache.New(500).LRU().Build(),
-		batchCache:   gcache.New(500).LRU().Build(),
+		key:              key,
+		cs:               cs,
+		round:            NewVoteRound(cs.cn.Provider().Height()+1, cs.maxBlocksPerFormulator),
+		ignoreMap:        map[common.Address]int64{},
+		myPublicHash:     common.NewPublicHash(key.PublicKey()),
+		statusMap:        map[string]*p2p.Status{},
+		blockQ:           queue.NewSortedQueue(),
+		messageQueue:     queue.NewQueue(),
+		recvChan:         make(chan *p2p.RecvMessageItem, 1000),
+		sendChan:         make(chan *p2p.SendMessageItem, 1000),
+		singleCache:      gcache.New(500).LRU().Build(),
+		batchCache:       gcache.New(500).LRU().Build(),
+		prevRoundEndTime: time.Now().UnixNano(),
 	}
 	ob.ms = NewObserverNodeMesh(key, NetAddressMap, ob)
 	ob.fs = NewFormulatorService(ob)
@@ -154,13 +154,13 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 				}
 				m, err := p2p.PacketToMessage(item.Packet)
 				if err != nil {
-					log.Println("PacketToMessage", err)
+					logger.Warn("Invalid formulator packet", "err", err)
 					ob.fs.RemovePeer(item.PeerID)
 					continue
 				}
 				if p, has := ob.fs.Peer(item.PeerID); has {
 					if err := ob.handleFormulatorMessage(p, m, item.Packet); err != nil {
-						log.Println("Formulator Error", p.Name(), err)
+						logger.Warn("Formulator message failed", "peer", p.Name(), "err", err)
 						ob.fs.RemovePeer(item.PeerID)
 						continue
 					}
@@ -196,6 +196,10 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 		case <-blockTimer.C:
 			cp := ob.cs.cn.Provider()
 			ob.Lock()
+			if ob.isClose {
+				ob.Unlock()
+				break
+			}
 			hasItem := false
 			TargetHeight := uint64(cp.Height() + 1)
 			Count := 0
@@ -203,12 +207,12 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 			for item != nil {
 				b := item.(*types.Block)
 				if err := ob.cs.cn.ConnectBlock(b, nil); err != nil {
-					rlog.Println(err)
+					logger.Error("Block connect failed", "height", b.Header.Height, "err", err)
 					panic(err)
 					break
 				}
-				if debug.DEBUG {
-					rlog.Println(cp.Height(), "BlockConnectedQ", b.Header.Generator.String(), ob.round.RoundState, b.Header.Height, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond), len(b.Transactions))
+				if logger.IsEnabled(logging.DebugLevel) {
+					logger.Debug("Queued block connected", ob.roundFields("generator", b.Header.Generator, "block_height", b.Header.Height, "txs", len(b.Transactions))...)
 				}
 				TargetHeight++
 				Count++
@@ -233,6 +237,10 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 				i++
 				item := v.(*messageItem)
 				ob.Lock()
+				if ob.isClose {
+					ob.Unlock()
+					break
+				}
 				ob.handleObserverMessage(item.PublicHash, item.Message, item.Packet)
 				ob.Unlock()
 				v = ob.messageQueue.Pop()
@@ -240,17 +248,20 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 			queueTimer.Reset(10 * time.Millisecond)
 		case <-voteTimer.C:
 			ob.Lock()
-			cp := ob.cs.cn.Provider()
+			if ob.isClose {
+				ob.Unlock()
+				break
+			}
 			ob.syncVoteRound()
 			IsFailable := true
 			if len(ob.adjustFormulatorMap()) > 0 {
 				if ob.round.MinRoundVoteAck != nil {
-					if debug.DEBUG {
-						rlog.Println(cp.Height(), "Current State", ob.round.MinRoundVoteAck.Formulator.String(), ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+					if logger.IsEnabled(logging.DebugLevel) {
+						logger.Debug("Current state", ob.roundFields("formulator", ob.round.MinRoundVoteAck.Formulator)...)
 					}
 				} else {
-					if debug.DEBUG {
-						rlog.Println(cp.Height(), "Current State", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+					if logger.IsEnabled(logging.DebugLevel) {
+						logger.Debug("Current state", ob.roundFields()...)
 					}
 				}
 				if ob.round.RoundState == RoundVoteState {
@@ -260,8 +271,8 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 					br, has := ob.round.BlockRoundMap[ob.round.TargetHeight]
 					if has {
 						ob.sendBlockVote(br.BlockGenMessage)
-						if debug.DEBUG {
-							rlog.Println(cp.Height(), "sendBlockVote", ob.round.MinRoundVoteAck.Formulator.String(), encoding.Hash(br.BlockGenMessage.Block.Header), ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+						if logger.IsEnabled(logging.DebugLevel) {
+							logger.Debug("Block vote sent", ob.roundFields("formulator", ob.round.MinRoundVoteAck.Formulator, "hash", encoding.Hash(br.BlockGenMessage.Block.Header))...)
 						}
 						IsFailable = false
 					}
@@ -269,6 +280,7 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 				if IsFailable {
 					ob.round.VoteFailCount++
 					if ob.round.VoteFailCount > 30 {
+						roundFailures.Inc()
 						if ob.round.MinRoundVoteAck != nil {
 							addr := ob.round.MinRoundVoteAck.Formulator
 							if _, has := ob.ignoreMap[addr]; has {
@@ -277,20 +289,20 @@ func (ob *ObserverNode) Run(BindObserver string, BindFormulator string) {
 							} else {
 								ob.ignoreMap[addr] = time.Now().UnixNano() + int64(30*time.Second)
 							}
-							if debug.DEBUG {
-								rlog.Println(cp.Height(), "Failure", ob.round.MinRoundVoteAck.Formulator.String(), ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+							if logger.IsEnabled(logging.DebugLevel) {
+								logger.Debug("Vote round failed", ob.roundFields("formulator", ob.round.MinRoundVoteAck.Formulator)...)
 							}
 						} else {
-							if debug.DEBUG {
-								rlog.Println(cp.Height(), "Failure", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+							if logger.IsEnabled(logging.DebugLevel) {
+								logger.Debug("Vote round failed", ob.roundFields()...)
 							}
 						}
 						ob.resetVoteRound(true)
 					}
 				}
 			} else {
-				if debug.DEBUG {
-					rlog.Println(cp.Height(), "No Formulator", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+				if logger.IsEnabled(logging.DebugLevel) {
+					logger.Debug("No formulator", ob.roundFields()...)
 				}
 			}
 			ob.Unlock()
@@ -373,8 +385,8 @@ func (ob *ObserverNode) syncVoteRound() {
 			}
 		}
 		if !IsContinue {
-			if debug.DEBUG {
-				rlog.Println(ob.cs.cn.Provider().Height(), "Turn Over", ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+			if logger.IsEnabled(logging.DebugLevel) {
+				logger.Debug("Turn over", ob.roundFields()...)
 			}
 			ob.resetVoteRound(false)
 		}
@@ -389,3 +401,14 @@ func (ob *ObserverNode) resetVoteRound(resetStat bool) {
 		ob.roundFirstHeight = 0
 	}
 }
+
+// roundFields appends fields of the current vote round to the given fields for logs
+func (ob *ObserverNode) roundFields(kvs ...interface{}) []interface{} {
+	return append(kvs,
+		"height", ob.cs.cn.Provider().Height(),
+		"round_state", ob.round.RoundState,
+		"formulators", len(ob.adjustFormulatorMap()),
+		"peers", ob.fs.PeerCount(),
+		"elapsed_ms", (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond),
+	)
+}
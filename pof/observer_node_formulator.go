@@ -1,10 +1,6 @@
 package pof
 
 import (
-	"log"
-
-	"github.com/fletaio/fleta_testnet/common/rlog"
-	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/service/p2p"
 	"github.com/fletaio/fleta_testnet/service/p2p/peer"
 )
@@ -42,7 +38,7 @@ func (ob *ObserverNode) onFormulatorRecv(p peer.Peer, bs []byte) error {
 	case BlockGenMessageType:
 		m, err := p2p.PacketToMessage(bs)
 		if err != nil {
-			log.Println("PacketToMessage", err)
+			logger.Warn("Invalid formulator packet", "err", err)
 			ob.fs.RemovePeer(item.PeerID)
 			break
 		}
@@ -142,7 +138,7 @@ func (ob *ObserverNode) handleFormulatorMessage(p peer.Peer, m interface{}, bs [
 			}
 			if h != msg.LastHash {
 				//TODO : critical error signal
-				rlog.Println(chain.ErrFoundForkedBlock, p.Name(), h.String(), msg.LastHash.String(), msg.Height)
+				logger.Error("Forked block found", "peer", p.Name(), "height", msg.Height, "hash", h, "peer_hash", msg.LastHash)
 				ob.fs.RemovePeer(p.ID())
 			}
 		}
@@ -5,8 +5,7 @@ import (
 
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/binutil"
-	"github.com/fletaio/fleta_testnet/common/debug"
-	"github.com/fletaio/fleta_testnet/common/rlog"
+	"github.com/fletaio/fleta_testnet/common/logging"
 	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/encoding"
@@ -60,24 +59,24 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			return ErrInvalidVote
 		}
 		if msg.RoundVote.ChainID != cp.ChainID() {
-			rlog.Println("if msg.RoundVote.ChainID != cp.ChainID() {")
+			logger.Debug("Round vote rejected", "reason", "chain id mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 		if msg.RoundVote.LastHash != cp.LastHash() {
-			rlog.Println("if msg.RoundVote.LastHash != cp.LastHash() {", msg.RoundVote.LastHash.String(), cp.LastHash().String())
+			logger.Debug("Round vote rejected", "reason", "last hash mismatch", "sender", SenderPublicHash, "vote_last_hash", msg.RoundVote.LastHash, "last_hash", cp.LastHash())
 			return ErrInvalidVote
 		}
 		Top, err := ob.cs.rt.TopRank(int(msg.RoundVote.TimeoutCount))
 		if err != nil {
-			rlog.Println("Top, err := ob.cs.rt.TopRank(int(msg.RoundVote.TimeoutCount))", msg.RoundVote.TimeoutCount)
+			logger.Debug("Round vote rejected", "reason", "no top rank", "sender", SenderPublicHash, "timeout_count", msg.RoundVote.TimeoutCount, "err", err)
 			return err
 		}
 		if msg.RoundVote.Formulator != Top.Address {
-			rlog.Println("if msg.RoundVote.Formulator != Top.Address {", msg.RoundVote.Formulator.String(), Top.Address.String(), msg.RoundVote.TimeoutCount)
+			logger.Debug("Round vote rejected", "reason", "formulator mismatch", "sender", SenderPublicHash, "formulator", msg.RoundVote.Formulator, "top", Top.Address, "timeout_count", msg.RoundVote.TimeoutCount)
 			return ErrInvalidVote
 		}
 		if msg.RoundVote.FormulatorPublicHash != Top.PublicHash {
-			rlog.Println("if msg.RoundVote.FormulatorPublicHash != Top.PublicHash {")
+			logger.Debug("Round vote rejected", "reason", "formulator public hash mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 
@@ -146,11 +145,11 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			return ErrInvalidVote
 		}
 		if msg.RoundVoteAck.ChainID != cp.ChainID() {
-			rlog.Println("if msg.RoundVoteAck.ChainID != cp.ChainID() {")
+			logger.Debug("Round vote ack rejected", "reason", "chain id mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 		if msg.RoundVoteAck.LastHash != cp.LastHash() {
-			rlog.Println("if msg.RoundVoteAck.LastHash != cp.LastHash() {")
+			logger.Debug("Round vote ack rejected", "reason", "last hash mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 		Top, err := ob.cs.rt.TopRank(int(msg.RoundVoteAck.TimeoutCount))
@@ -158,11 +157,11 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			return err
 		}
 		if msg.RoundVoteAck.Formulator != Top.Address {
-			rlog.Println("if msg.RoundVoteAck.Formulator != Top.Address {")
+			logger.Debug("Round vote ack rejected", "reason", "formulator mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 		if msg.RoundVoteAck.FormulatorPublicHash != Top.PublicHash {
-			rlog.Println("if msg.RoundVoteAck.FormulatorPublicHash != Top.PublicHash {")
+			logger.Debug("Round vote ack rejected", "reason", "formulator public hash mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 
@@ -195,7 +194,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		}
 		ob.round.RoundVoteAckMessageMap[SenderPublicHash] = msg
 
-		rlog.Println(cp.Height(), "RoundVoteAckMessage", ob.round.RoundState, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+		logger.Debug("Round vote ack received", "height", cp.Height(), "sender", SenderPublicHash, "round_state", ob.round.RoundState, "elapsed_ms", (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 
 		if !msg.RoundVoteAck.IsReply && SenderPublicHash != ob.myPublicHash {
 			ob.sendRoundVoteAckTo(SenderPublicHash)
@@ -238,8 +237,8 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 				}
 
 				if ob.round.MinRoundVoteAck.PublicHash == ob.myPublicHash {
-					if debug.DEBUG {
-						rlog.Println("Observer", "BlockReqMessage", ob.round.MinRoundVoteAck.Formulator.String(), ob.round.MinRoundVoteAck.TimeoutCount, cp.Height())
+					if logger.IsEnabled(logging.DebugLevel) {
+						logger.Debug("Block requested", "height", cp.Height(), "formulator", ob.round.MinRoundVoteAck.Formulator, "timeout_count", ob.round.MinRoundVoteAck.TimeoutCount)
 					}
 					nm := &BlockReqMessage{
 						PrevHash:             ob.round.MinRoundVoteAck.LastHash,
@@ -266,16 +265,16 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			}
 		}
 	case *BlockGenMessage:
-		rlog.Println(cp.Height(), "BlockGenMessage", ob.round.RoundState, msg.Block.Header.Height, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+		logger.Debug("Block gen received", "height", cp.Height(), "block_height", msg.Block.Header.Height, "round_state", ob.round.RoundState, "elapsed_ms", (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 
 		//[check round]
 		br, has := ob.round.BlockRoundMap[msg.Block.Header.Height]
 		if !has {
-			rlog.Println(msg.Block.Header.Generator.String(), "br, has := ob.round.BlockRoundMap[msg.Block.Header.Height]", msg.Block.Header.Height, ob.round.TargetHeight)
+			logger.Debug("Block gen rejected", "reason", "no block round", "generator", msg.Block.Header.Generator, "block_height", msg.Block.Header.Height, "target_height", ob.round.TargetHeight)
 			return ErrInvalidVote
 		}
 		if br.BlockGenMessage != nil {
-			rlog.Println(msg.Block.Header.Generator.String(), "if br.BlockGenMessage != nil {", msg.Block.Header.Height, ob.round.TargetHeight)
+			logger.Debug("Block gen rejected", "reason", "already received", "generator", msg.Block.Header.Generator, "block_height", msg.Block.Header.Height, "target_height", ob.round.TargetHeight)
 			return ErrInvalidVote
 		}
 
@@ -283,8 +282,8 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			if ob.round.MinRoundVoteAck.PublicHash == ob.myPublicHash {
 				if len(raw) > 0 {
 					ob.ms.BroadcastPacket(raw)
-					if debug.DEBUG {
-						rlog.Println(cp.Height(), "BlockGenBroadcast", msg.Block.Header.Height, ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+					if logger.IsEnabled(logging.DebugLevel) {
+						logger.Debug("Block gen broadcasted", ob.roundFields("block_height", msg.Block.Header.Height)...)
 					}
 				}
 			} else {
@@ -329,8 +328,8 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 						}
 						if NextTop != nil {
 							ob.sendMessagePacket(1, NextTop.Address, raw)
-							if debug.DEBUG {
-								rlog.Println(cp.Height(), "BlockGenToNextTop", msg.Block.Header.Height, ob.round.RoundState, len(ob.adjustFormulatorMap()), ob.fs.PeerCount(), (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+							if logger.IsEnabled(logging.DebugLevel) {
+								logger.Debug("Block gen sent to the next top", ob.roundFields("block_height", msg.Block.Header.Height)...)
 							}
 						}
 					}
@@ -342,7 +341,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			if msg.Block.Header.Height > ob.round.TargetHeight {
 				br.BlockGenMessageWait = msg
 			}
-			rlog.Println(msg.Block.Header.Generator.String(), "if msg.Block.Header.Height != ob.round.TargetHeight {", msg.Block.Header.Height, ob.round.TargetHeight)
+			logger.Debug("Block gen rejected", "reason", "target height mismatch", "generator", msg.Block.Header.Generator, "block_height", msg.Block.Header.Height, "target_height", ob.round.TargetHeight)
 			return ErrInvalidVote
 		}
 
@@ -351,7 +350,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			if ob.round.RoundState < BlockWaitState {
 				br.BlockGenMessageWait = msg
 			}
-			rlog.Println(msg.Block.Header.Generator.String(), "if ob.round.RoundState != BlockWaitState {", ob.round.RoundState, BlockWaitState)
+			logger.Debug("Block gen rejected", "reason", "not block wait state", "generator", msg.Block.Header.Generator, "round_state", ob.round.RoundState)
 			return ErrInvalidVote
 		}
 		TimeoutCount, err := ob.cs.DecodeConsensusData(msg.Block.Header.ConsensusData)
@@ -363,17 +362,17 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			return err
 		}
 		if msg.Block.Header.Generator != Top.Address {
-			rlog.Println(msg.Block.Header.Generator.String(), "if msg.Block.Header.Generator != Top.Address {", msg.Block.Header.Generator.String(), Top.Address.String(), TimeoutCount)
+			logger.Debug("Block gen rejected", "reason", "generator is not the top", "generator", msg.Block.Header.Generator, "top", Top.Address, "timeout_count", TimeoutCount)
 			return ErrInvalidVote
 		}
 		if msg.Block.Header.Generator != ob.round.MinRoundVoteAck.Formulator {
-			rlog.Println(msg.Block.Header.Generator.String(), "if msg.Block.Header.Generator != ob.round.MinRoundVoteAck.Formulator {")
+			logger.Debug("Block gen rejected", "reason", "generator is not the voted formulator", "generator", msg.Block.Header.Generator)
 			return ErrInvalidVote
 		}
 		bh := encoding.Hash(msg.Block.Header)
 		if br.BlockGenMessageWait != nil {
 			if bh != encoding.Hash(br.BlockGenMessageWait.Block.Header) {
-				rlog.Println(msg.Block.Header.Generator.String(), "if bh != encoding.Hash(br.BlockGenMessageWait.Block.Header) {")
+				logger.Debug("Block gen rejected", "reason", "waiting block hash mismatch", "generator", msg.Block.Header.Generator)
 				return ErrFoundForkedBlockGen
 			}
 		}
@@ -382,28 +381,28 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		} else if Signer := common.NewPublicHash(pubkey); Signer != Top.PublicHash {
 			return ErrInvalidTopSignature
 		} else if Signer != ob.round.MinRoundVoteAck.FormulatorPublicHash {
-			rlog.Println(msg.Block.Header.Generator.String(), "if Signer != ob.round.MinRoundVoteAck.FormulatorPublicHash {")
+			logger.Debug("Block gen rejected", "reason", "signer mismatch", "generator", msg.Block.Header.Generator)
 			return ErrInvalidVote
 		}
 		if err := ob.cs.ct.ValidateHeader(&msg.Block.Header); err != nil {
-			rlog.Println(msg.Block.Header.Generator.String(), "if err := ob.cs.ct.ValidateHeader(&msg.Block.Header); err != nil {", err)
+			logger.Warn("Block gen rejected", "reason", "invalid header", "generator", msg.Block.Header.Generator, "err", err)
 			return err
 		}
 
 		//[if valid block]
 		Now := uint64(time.Now().UnixNano())
 		if msg.Block.Header.Timestamp > Now+uint64(10*time.Second) {
-			rlog.Println(msg.Block.Header.Generator.String(), "if msg.Block.Header.Timestamp > Now+uint64(10*time.Second) {")
+			logger.Warn("Block gen rejected", "reason", "future timestamp", "generator", msg.Block.Header.Generator, "timestamp", msg.Block.Header.Timestamp)
 			return ErrInvalidVote
 		}
 
 		ctx := ob.cs.ct.NewContext()
 		if err := ob.cs.ct.ExecuteBlockOnContext(msg.Block, ctx, nil); err != nil {
-			rlog.Println(msg.Block.Header.Generator.String(), "if err := ob.cs.ct.ExecuteBlockOnContext(msg.Block, ctx); err != nil {", err)
+			logger.Warn("Block gen rejected", "reason", "execution failed", "generator", msg.Block.Header.Generator, "err", err)
 			return err
 		}
 		if msg.Block.Header.ContextHash != ctx.Hash() {
-			rlog.Println(msg.Block.Header.Generator.String(), "if msg.Block.Header.ContextHash != ctx.Hash() {")
+			logger.Warn("Block gen rejected", "reason", "context hash mismatch", "generator", msg.Block.Header.Generator)
 			return chain.ErrInvalidContextHash
 		}
 
@@ -432,7 +431,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		//[check round]
 		br, has := ob.round.BlockRoundMap[msg.BlockGenRequest.TargetHeight]
 		if !has {
-			rlog.Println("br, has := ob.round.BlockRoundMap[msg.BlockGenRequest.TargetHeight]")
+			logger.Debug("Block gen request rejected", "reason", "no block round", "sender", SenderPublicHash, "target_height", msg.BlockGenRequest.TargetHeight)
 			return ErrInvalidVote
 		}
 
@@ -445,11 +444,11 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			return ErrInvalidVote
 		}
 		if msg.BlockGenRequest.ChainID != cp.ChainID() {
-			rlog.Println("if msg.BlockGenRequest.ChainID != cp.ChainID() {")
+			logger.Debug("Block gen request rejected", "reason", "chain id mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 		if msg.BlockGenRequest.LastHash != cp.LastHash() {
-			rlog.Println("if msg.BlockGenRequest.LastHash != cp.LastHash() {")
+			logger.Debug("Block gen request rejected", "reason", "last hash mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 		Top, err := ob.cs.rt.TopRank(int(msg.BlockGenRequest.TimeoutCount))
@@ -457,11 +456,11 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			return err
 		}
 		if msg.BlockGenRequest.Formulator != Top.Address {
-			rlog.Println("if msg.BlockGenRequest.Formulator != Top.Address {")
+			logger.Debug("Block gen request rejected", "reason", "formulator mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 		if msg.BlockGenRequest.FormulatorPublicHash != Top.PublicHash {
-			rlog.Println("if msg.BlockGenRequest.FormulatorPublicHash != Top.PublicHash {")
+			logger.Debug("Block gen request rejected", "reason", "formulator public hash mismatch", "sender", SenderPublicHash)
 			return ErrInvalidVote
 		}
 
@@ -521,7 +520,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			return err
 		}
 		if msg.BlockVote.Header.Generator != Top.Address {
-			rlog.Println("Observer", cp.Height(), ob.myPublicHash.String(), Top.Address.String(), encoding.Hash(msg.BlockVote.Header), "if msg.BlockVote.Header.Generator != Top.Address {")
+			logger.Debug("Block vote rejected", "reason", "generator is not the top", "height", cp.Height(), "top", Top.Address, "hash", encoding.Hash(msg.BlockVote.Header))
 			return ErrInvalidVote
 		}
 		bh := encoding.Hash(msg.BlockVote.Header)
@@ -531,27 +530,27 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		}
 		Signer := common.NewPublicHash(pubkey)
 		if Signer != Top.PublicHash {
-			rlog.Println(encoding.Hash(msg.BlockVote.Header), "if Signer != Top.PublicHash {")
+			logger.Debug("Block vote rejected", "reason", "signer is not the top", "hash", encoding.Hash(msg.BlockVote.Header))
 			return ErrInvalidTopSignature
 		}
 		if msg.BlockVote.Header.Generator != ob.round.MinRoundVoteAck.Formulator {
-			rlog.Println(encoding.Hash(msg.BlockVote.Header), "if msg.BlockVote.Header.Generator != ob.round.MinRoundVoteAck.Formulator {")
+			logger.Debug("Block vote rejected", "reason", "generator is not the voted formulator", "hash", encoding.Hash(msg.BlockVote.Header))
 			return ErrInvalidVote
 		}
 		if Signer != ob.round.MinRoundVoteAck.FormulatorPublicHash {
-			rlog.Println(encoding.Hash(msg.BlockVote.Header), "if Signer != ob.round.MinRoundVoteAck.FormulatorPublicHash {")
+			logger.Debug("Block vote rejected", "reason", "signer mismatch", "hash", encoding.Hash(msg.BlockVote.Header))
 			return ErrInvalidVote
 		}
 		if msg.BlockVote.Header.PrevHash != cp.LastHash() {
-			rlog.Println(encoding.Hash(msg.BlockVote.Header), "if msg.BlockVote.Header.PrevHash != cp.LastHash() {")
+			logger.Debug("Block vote rejected", "reason", "prev hash mismatch", "hash", encoding.Hash(msg.BlockVote.Header))
 			return ErrInvalidVote
 		}
 		if bh != encoding.Hash(br.BlockGenMessage.Block.Header) {
-			rlog.Println(encoding.Hash(msg.BlockVote.Header), "if bh != encoding.Hash(br.BlockGenMessage.Block.Header) {")
+			logger.Debug("Block vote rejected", "reason", "block hash mismatch", "hash", encoding.Hash(msg.BlockVote.Header))
 			return ErrInvalidVote
 		}
 		if err := ob.cs.ct.ValidateHeader(msg.BlockVote.Header); err != nil {
-			rlog.Println(msg.BlockVote.Header.Generator.String(), "if err := ob.cs.ct.ValidateHeader(msg.BlockVote.Header); err != nil {")
+			logger.Warn("Block vote rejected", "reason", "invalid header", "generator", msg.BlockVote.Header.Generator, "err", err)
 			return err
 		}
 
@@ -573,7 +572,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 		}
 		br.BlockVoteMap[SenderPublicHash] = msg.BlockVote
 
-		rlog.Println(cp.Height(), encoding.Hash(msg.BlockVote.Header), "BlockVoteMessage", ob.round.RoundState, msg.BlockVote.Header.Height, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
+		logger.Debug("Block vote received", "height", cp.Height(), "hash", encoding.Hash(msg.BlockVote.Header), "block_height", msg.BlockVote.Header.Height, "round_state", ob.round.RoundState, "elapsed_ms", (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 
 		//[check state]
 		if !msg.BlockVote.IsReply && SenderPublicHash != ob.myPublicHash {
@@ -661,9 +660,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 					}
 				}
 			}
-			if debug.DEBUG {
-				rlog.Println(cp.Height(), "BlockConnected", b.Header.Generator.String(), ob.round.RoundState, msg.BlockVote.Header.Height, (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond), len(b.Transactions))
-			}
+			logger.Info("Block connected", "height", b.Header.Height, "hash", encoding.Hash(b.Header), "generator", b.Header.Generator, "txs", len(b.Transactions), "round_state", ob.round.RoundState, "elapsed_ms", (time.Now().UnixNano()-ob.prevRoundEndTime)/int64(time.Millisecond))
 
 			NextHeight := ob.round.TargetHeight + 1
 			Top, err := ob.cs.rt.TopRank(0)
@@ -735,7 +732,7 @@ func (ob *ObserverNode) handleObserverMessage(SenderPublicHash common.PublicHash
 			}
 			if h != msg.LastHash {
 				//TODO : critical error signal
-				rlog.Println(SenderPublicHash.String(), h.String(), msg.LastHash.String(), msg.Height)
+				logger.Error("Forked block found", "peer", SenderPublicHash, "height", msg.Height, "hash", h, "peer_hash", msg.LastHash)
 				panic(chain.ErrFoundForkedBlock)
 			}
 		}
@@ -3,6 +3,7 @@ package admin
 import (
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/service/apiserver"
 )
 
 // Admin manages balance of accounts of the chain
@@ -46,10 +47,61 @@ func (p *Admin) InitAdmin(ctw *types.ContextWrapper, addrMap map[string]common.A
 	return nil
 }
 
+// InitCouncil called at OnInitGenesis of an application
+func (p *Admin) InitCouncil(ctw *types.ContextWrapper, councilMap map[string]*Council) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	for name, cl := range councilMap {
+		if err := cl.Validate(); err != nil {
+			return err
+		}
+		if err := p.setCouncil(ctw, name, cl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Init initializes the process
 func (p *Admin) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
 	p.pm = pm
 	p.cn = cn
+
+	reg.RegisterTransaction(1, &UpdateCouncil{})
+	reg.RegisterTransaction(2, &Propose{})
+	reg.RegisterTransaction(3, &ApproveProposal{})
+	reg.RegisterTransaction(4, &ExecuteProposal{})
+	reg.RegisterTransaction(5, &CancelProposal{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s := v.SharedJRPC("admin")
+		s.Set("council", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			name, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Council(loader, name)
+		})
+		s.Set("proposal", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			proposalID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Proposal(loader, proposalID)
+		})
+	}
 	return nil
 }
 
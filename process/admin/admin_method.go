@@ -3,17 +3,124 @@ package admin
 import (
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
 )
 
 // AdminAddress returns the admin address
 func (p *Admin) AdminAddress(loader types.Loader, name string) common.Address {
+	addr, err := p.adminAddress(loader, name)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+func (p *Admin) adminAddress(loader types.Loader, name string) (common.Address, error) {
 	lw := types.NewLoaderWrapper(p.pid, loader)
 
 	if bs := lw.ProcessData(toAdminAddressKey(name)); len(bs) == 0 {
-		panic(ErrNotExistAdminAddress)
+		return common.Address{}, ErrNotExistAdminAddress
 	} else {
 		var addr common.Address
 		copy(addr[:], bs)
-		return addr
+		return addr, nil
+	}
+}
+
+// IsPolicyAdmin returns the address can change policies of the process directly or not
+// When the council of the process is set, policies are only changed by the proposal of the council
+func (p *Admin) IsPolicyAdmin(loader types.Loader, name string, addr common.Address) bool {
+	if addr != p.AdminAddress(loader, name) {
+		return false
+	}
+	if _, err := p.Council(loader, name); err != ErrNotExistCouncil {
+		return false
+	}
+	return true
+}
+
+// Council returns the council of the process
+func (p *Admin) Council(loader types.Loader, name string) (*Council, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(toCouncilKey(name)); len(bs) > 0 {
+		cl := &Council{}
+		if err := encoding.Unmarshal(bs, &cl); err != nil {
+			return nil, err
+		}
+		return cl, nil
+	} else {
+		return nil, ErrNotExistCouncil
+	}
+}
+
+func (p *Admin) setCouncil(ctw *types.ContextWrapper, name string, cl *Council) error {
+	body, err := encoding.Marshal(cl)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toCouncilKey(name), body)
+	return nil
+}
+
+// Proposal returns the proposal of the id
+func (p *Admin) Proposal(loader types.Loader, ID string) (*Proposal, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(toProposalKey(ID)); len(bs) > 0 {
+		pr := &Proposal{}
+		if err := encoding.Unmarshal(bs, &pr); err != nil {
+			return nil, err
+		}
+		return pr, nil
+	} else {
+		return nil, ErrNotExistProposal
+	}
+}
+
+func (p *Admin) setProposal(ctw *types.ContextWrapper, ID string, pr *Proposal) error {
+	body, err := encoding.Marshal(pr)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toProposalKey(ID), body)
+	return nil
+}
+
+// approveProposal adds the approval of the member and starts the timelock when approvals reach to the threshold
+func (p *Admin) approveProposal(ctw *types.ContextWrapper, pr *Proposal, cl *Council, addr common.Address) {
+	pr.Approvals = append(pr.Approvals, addr)
+	if pr.Status == ProposalPending && len(pr.Approvals) >= int(cl.Threshold) {
+		pr.Status = ProposalApproved
+		pr.ExecutableHeight = ctw.TargetHeight() + cl.DelayBlocks
+	}
+}
+
+// proposalTarget returns the decoded transaction of the proposal and the process that executes it
+// parameters of the transaction are validated by the process because signers of it are not checked when it is executed
+func (p *Admin) proposalTarget(loader types.Loader, pr *Proposal) (adminTransaction, types.Process, error) {
+	tx, err := decodeTransaction(pr.TxType, pr.TxData)
+	if err != nil {
+		return nil, nil, err
+	}
+	tp, err := p.pm.Process(uint8(pr.TxType >> 8))
+	if err != nil {
+		return nil, nil, err
+	}
+	if tp.ID() == p.pid {
+		if utx, is := tx.(*UpdateCouncil); !is || utx.Name != pr.Name {
+			return nil, nil, ErrInvalidProposal
+		}
+	} else if tp.Name() != pr.Name {
+		return nil, nil, ErrInvalidProposal
+	}
+	if addr, err := p.adminAddress(loader, pr.Name); err != nil {
+		return nil, nil, err
+	} else if tx.From() != addr {
+		return nil, nil, ErrUnauthorizedTransaction
+	}
+	if err := tx.ValidateParams(tp, types.NewLoaderWrapper(tp.ID(), loader)); err != nil {
+		return nil, nil, err
 	}
+	return tx, tp, nil
 }
@@ -0,0 +1,228 @@
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
+	"github.com/fletaio/fleta_testnet/process/processtest"
+)
+
+var errTestInvalidPolicy = errors.New("invalid test policy")
+
+// testProcess is the process of the test that has a policy updated by the admin
+type testProcess struct {
+	types.ProcessBase
+	pid uint8
+}
+
+func (p *testProcess) ID() uint8 {
+	return p.pid
+}
+
+func (p *testProcess) Name() string {
+	return "test.process"
+}
+
+func (p *testProcess) Version() string {
+	return "0.0.1"
+}
+
+func (p *testProcess) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
+	reg.RegisterAccount(1, &testAccount{})
+	reg.RegisterTransaction(1, &testUpdatePolicy{})
+	return nil
+}
+
+func (p *testProcess) OnLoadChain(loader types.LoaderWrapper) error {
+	return nil
+}
+
+func (p *testProcess) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
+	return nil
+}
+
+func (p *testProcess) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	return nil
+}
+
+func (p *testProcess) OnSaveData(b *types.Block, ctw *types.ContextWrapper) error {
+	return nil
+}
+
+// testUpdatePolicy is the admin transaction of the test process and an empty policy is invalid
+type testUpdatePolicy struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Policy     []byte
+}
+
+func (tx *testUpdatePolicy) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+func (tx *testUpdatePolicy) From() common.Address {
+	return tx.From_
+}
+
+func (tx *testUpdatePolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	return tx.ValidateParams(p, loader)
+}
+
+func (tx *testUpdatePolicy) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	if len(tx.Policy) == 0 {
+		return errTestInvalidPolicy
+	}
+	return nil
+}
+
+func (tx *testUpdatePolicy) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	ctw.SetProcessData([]byte{1}, tx.Policy)
+	return nil
+}
+
+func (tx *testUpdatePolicy) MarshalJSON() ([]byte, error) {
+	return []byte(`{}`), nil
+}
+
+// testAccount is the account of the test that is validated by the key hash
+type testAccount struct {
+	Address_ common.Address
+	KeyHash  common.PublicHash
+}
+
+func (acc *testAccount) Address() common.Address {
+	return acc.Address_
+}
+
+func (acc *testAccount) Name() string {
+	return ""
+}
+
+func (acc *testAccount) Clone() types.Account {
+	c := *acc
+	return &c
+}
+
+func (acc *testAccount) Validate(loader types.LoaderWrapper, signers []common.PublicHash) error {
+	if len(signers) != 1 || signers[0] != acc.KeyHash {
+		return types.ErrInvalidAccountSigner
+	}
+	return nil
+}
+
+func (acc *testAccount) MarshalJSON() ([]byte, error) {
+	return []byte(`{}`), nil
+}
+
+var (
+	testAdmin     = common.NewAddress(0, 1, 0)
+	testMember    = common.NewAddress(0, 2, 0)
+	testMemberKey = common.PublicHash{2}
+)
+
+// newTestAdmin returns the admin and the context of the test that has the council of one member for the test process
+func newTestAdmin(t *testing.T) (*Admin, *types.ContextWrapper) {
+	ap := NewAdmin(1)
+	tp := &testProcess{pid: 2}
+	pm := processtest.NewProcessManager(t, ap, tp)
+	ctw := types.NewContextWrapper(ap.ID(), pm.NewContext(t, &processtest.Fixture{
+		AdminAddressMap: map[string]common.Address{tp.Name(): testAdmin},
+		Accounts: []types.Account{
+			&testAccount{Address_: testMember, KeyHash: testMemberKey},
+		},
+	}))
+	if err := ap.InitCouncil(ctw, map[string]*Council{tp.Name(): {Members: []common.Address{testMember}, Threshold: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	return ap, ctw
+}
+
+func testPolicyProposal(t *testing.T, Policy []byte) *Proposal {
+	t.Helper()
+	tx := &testUpdatePolicy{From_: testAdmin, Policy: Policy}
+	TxType, err := encoding.Factory("transaction").TypeOf(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	TxData, err := encoding.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Proposal{Name: "test.process", TxType: TxType, TxData: TxData, Approvals: []common.Address{}}
+}
+
+func TestProposeValidateParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy []byte
+		err    error
+	}{
+		{"ok", []byte{1}, nil},
+		{"empty policy", nil, errTestInvalidPolicy},
+	}
+	for _, tt := range tests {
+		ap, ctw := newTestAdmin(t)
+		pr := testPolicyProposal(t, tt.policy)
+		tx := &Propose{From_: testMember, Name: pr.Name, TxType: pr.TxType, TxData: pr.TxData}
+		if err := tx.Validate(ap, ctw, []common.PublicHash{testMemberKey}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestExecuteProposalValidateParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy []byte
+		err    error
+	}{
+		{"ok", []byte{1}, nil},
+		{"empty policy", nil, errTestInvalidPolicy},
+	}
+	for _, tt := range tests {
+		ap, ctw := newTestAdmin(t)
+		// the proposal is stored directly as it was approved before parameters of proposals are validated
+		pr := testPolicyProposal(t, tt.policy)
+		pr.Approvals = []common.Address{testMember}
+		pr.Status = ProposalApproved
+		if err := ap.setProposal(ctw, "proposal", pr); err != nil {
+			t.Fatal(err)
+		}
+		tx := &ExecuteProposal{From_: testMember, ProposalID: "proposal"}
+		if err := tx.Validate(ap, ctw, []common.PublicHash{testMemberKey}); err != tt.err {
+			t.Errorf("%s: validate: got %v, want %v", tt.name, err, tt.err)
+		}
+		if err := tx.Execute(ap, ctw, 0); err != tt.err {
+			t.Errorf("%s: execute: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestCancelProposal(t *testing.T) {
+	tests := []struct {
+		name     string
+		proposer common.Address
+		status   ProposalStatus
+		err      error
+	}{
+		{"proposer", testMember, ProposalPending, nil},
+		{"not proposer", testAdmin, ProposalPending, ErrNotProposer},
+		{"approved", testMember, ProposalApproved, ErrNotPendingProposal},
+	}
+	for _, tt := range tests {
+		ap, ctw := newTestAdmin(t)
+		pr := testPolicyProposal(t, []byte{1})
+		pr.Proposer = tt.proposer
+		pr.Status = tt.status
+		if err := ap.setProposal(ctw, "proposal", pr); err != nil {
+			t.Fatal(err)
+		}
+		tx := &CancelProposal{From_: testMember, ProposalID: "proposal"}
+		if err := tx.Validate(ap, ctw, []common.PublicHash{testMemberKey}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+)
+
+// Council is a group of members that governs the admin authority of a process
+// A change by the admin authority is executed when approvals of members reach to the threshold and the delay blocks are passed
+type Council struct {
+	Members     []common.Address
+	Threshold   uint8
+	DelayBlocks uint32
+}
+
+// IsMember returns the address is a member of the council or not
+func (cl *Council) IsMember(addr common.Address) bool {
+	for _, v := range cl.Members {
+		if v == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the members and the threshold of the council
+func (cl *Council) Validate() error {
+	if len(cl.Members) == 0 || len(cl.Members) > 255 {
+		return ErrInvalidCouncil
+	}
+	if cl.Threshold == 0 || int(cl.Threshold) > len(cl.Members) {
+		return ErrInvalidCouncil
+	}
+	memberMap := map[common.Address]bool{}
+	for _, v := range cl.Members {
+		if memberMap[v] {
+			return ErrInvalidCouncil
+		}
+		memberMap[v] = true
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (cl *Council) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"members":[`)
+	for i, addr := range cl.Members {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := addr.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"threshold":`)
+	if bs, err := json.Marshal(cl.Threshold); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"delay_blocks":`)
+	if bs, err := json.Marshal(cl.DelayBlocks); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -7,4 +7,16 @@ var (
 	ErrInvalidAdminAddress     = errors.New("invalid admin address")
 	ErrUnauthorizedTransaction = errors.New("unauthorized transaction")
 	ErrNotExistAdminAddress    = errors.New("not exist admin address")
+	ErrInvalidCouncil          = errors.New("invalid council")
+	ErrNotExistCouncil         = errors.New("not exist council")
+	ErrExistCouncil            = errors.New("exist council")
+	ErrNotCouncilMember        = errors.New("not council member")
+	ErrNotProposer             = errors.New("not proposer")
+	ErrInvalidProposal         = errors.New("invalid proposal")
+	ErrNotExistProposal        = errors.New("not exist proposal")
+	ErrNotPendingProposal      = errors.New("not pending proposal")
+	ErrClosedProposal          = errors.New("closed proposal")
+	ErrNotApprovedProposal     = errors.New("not approved proposal")
+	ErrAlreadyApproved         = errors.New("already approved")
+	ErrProposalTimelocked      = errors.New("proposal timelocked")
 )
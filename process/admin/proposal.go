@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
+)
+
+// ProposalStatus is a status of the proposal
+type ProposalStatus uint8
+
+// proposal statuses
+const (
+	ProposalPending   = ProposalStatus(1)
+	ProposalApproved  = ProposalStatus(2)
+	ProposalExecuted  = ProposalStatus(3)
+	ProposalCancelled = ProposalStatus(4)
+)
+
+// Proposal is an admin transaction waiting for approvals of the council
+type Proposal struct {
+	Name             string
+	Proposer         common.Address
+	TxType           uint16
+	TxData           []byte
+	Approvals        []common.Address
+	CreatedHeight    uint32
+	ExecutableHeight uint32
+	Status           ProposalStatus
+}
+
+// HasApproved returns the address has approved the proposal or not
+func (pr *Proposal) HasApproved(addr common.Address) bool {
+	for _, v := range pr.Approvals {
+		if v == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON is a marshaler function
+func (pr *Proposal) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(pr.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"proposer":`)
+	if bs, err := pr.Proposer.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tx_type":`)
+	if bs, err := json.Marshal(pr.TxType); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tx":`)
+	if tx, err := decodeTransaction(pr.TxType, pr.TxData); err != nil {
+		if bs, err := json.Marshal(hex.EncodeToString(pr.TxData)); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	} else if bs, err := tx.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"approvals":[`)
+	for i, addr := range pr.Approvals {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := addr.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"created_height":`)
+	if bs, err := json.Marshal(pr.CreatedHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"executable_height":`)
+	if bs, err := json.Marshal(pr.ExecutableHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"status":`)
+	if bs, err := json.Marshal(pr.Status); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// adminTransaction is a transaction that can be proposed to the council
+type adminTransaction interface {
+	types.Transaction
+	From() common.Address
+	ValidateParams(p types.Process, loader types.LoaderWrapper) error
+}
+
+func decodeTransaction(t uint16, data []byte) (adminTransaction, error) {
+	v, err := encoding.Factory("transaction").Create(t)
+	if err != nil {
+		return nil, err
+	}
+	if err := encoding.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	tx, is := v.(adminTransaction)
+	if !is {
+		return nil, ErrInvalidProposal
+	}
+	return tx, nil
+}
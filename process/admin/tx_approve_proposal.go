@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// ApproveProposal is used to approve the pending proposal by the council member
+type ApproveProposal struct {
+	Timestamp_ uint64
+	From_      common.Address
+	ProposalID string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *ApproveProposal) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *ApproveProposal) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *ApproveProposal) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Admin)
+
+	pr, err := sp.Proposal(loader, tx.ProposalID)
+	if err != nil {
+		return err
+	}
+	if pr.Status != ProposalPending {
+		return ErrNotPendingProposal
+	}
+	cl, err := sp.Council(loader, pr.Name)
+	if err != nil {
+		return err
+	}
+	if !cl.IsMember(tx.From()) {
+		return ErrNotCouncilMember
+	}
+	if pr.HasApproved(tx.From()) {
+		return ErrAlreadyApproved
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *ApproveProposal) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Admin)
+
+	pr, err := sp.Proposal(ctw, tx.ProposalID)
+	if err != nil {
+		return err
+	}
+	cl, err := sp.Council(ctw, pr.Name)
+	if err != nil {
+		return err
+	}
+	sp.approveProposal(ctw, pr, cl, tx.From())
+	return sp.setProposal(ctw, tx.ProposalID, pr)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *ApproveProposal) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"proposal_id":`)
+	if bs, err := json.Marshal(tx.ProposalID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// ExecuteProposal is used to execute the approved proposal after its timelock
+// The inner transaction is executed by the target process as if the admin submitted it
+type ExecuteProposal struct {
+	Timestamp_ uint64
+	From_      common.Address
+	ProposalID string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *ExecuteProposal) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *ExecuteProposal) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *ExecuteProposal) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Admin)
+
+	pr, err := sp.Proposal(loader, tx.ProposalID)
+	if err != nil {
+		return err
+	}
+	if pr.Status != ProposalApproved {
+		return ErrNotApprovedProposal
+	}
+	if loader.TargetHeight() < pr.ExecutableHeight {
+		return ErrProposalTimelocked
+	}
+	cl, err := sp.Council(loader, pr.Name)
+	if err != nil {
+		return err
+	}
+	if !cl.IsMember(tx.From()) {
+		return ErrNotCouncilMember
+	}
+	if _, _, err := sp.proposalTarget(loader, pr); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *ExecuteProposal) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Admin)
+
+	pr, err := sp.Proposal(ctw, tx.ProposalID)
+	if err != nil {
+		return err
+	}
+	itx, tp, err := sp.proposalTarget(ctw, pr)
+	if err != nil {
+		return err
+	}
+	if err := itx.Execute(tp, ctw.Switch(tp.ID()), index); err != nil {
+		return err
+	}
+	pr.Status = ProposalExecuted
+	return sp.setProposal(ctw, tx.ProposalID, pr)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *ExecuteProposal) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"proposal_id":`)
+	if bs, err := json.Marshal(tx.ProposalID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,128 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// Propose is used to propose an admin transaction of the process to the council
+// The proposer approves the proposal by proposing it
+type Propose struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Name       string
+	TxType     uint16
+	TxData     []byte
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *Propose) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *Propose) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *Propose) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Admin)
+
+	cl, err := sp.Council(loader, tx.Name)
+	if err != nil {
+		return err
+	}
+	if !cl.IsMember(tx.From()) {
+		return ErrNotCouncilMember
+	}
+	if _, _, err := sp.proposalTarget(loader, tx.proposal()); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *Propose) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Admin)
+
+	cl, err := sp.Council(ctw, tx.Name)
+	if err != nil {
+		return err
+	}
+	pr := tx.proposal()
+	pr.Proposer = tx.From()
+	pr.CreatedHeight = ctw.TargetHeight()
+	pr.Status = ProposalPending
+	sp.approveProposal(ctw, pr, cl, tx.From())
+	return sp.setProposal(ctw, types.TransactionID(ctw.TargetHeight(), index), pr)
+}
+
+func (tx *Propose) proposal() *Proposal {
+	return &Proposal{
+		Name:      tx.Name,
+		TxType:    tx.TxType,
+		TxData:    tx.TxData,
+		Approvals: []common.Address{},
+	}
+}
+
+// MarshalJSON is a marshaler function
+func (tx *Propose) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(tx.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tx_type":`)
+	if bs, err := json.Marshal(tx.TxType); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tx":`)
+	if itx, err := decodeTransaction(tx.TxType, tx.TxData); err != nil {
+		if bs, err := json.Marshal(hex.EncodeToString(tx.TxData)); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	} else if bs, err := itx.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// UpdateCouncil is used to update the council of the process
+// The admin sets up the first council directly, after that the council is only changed by its proposal
+type UpdateCouncil struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Name       string
+	Council    *Council
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *UpdateCouncil) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *UpdateCouncil) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *UpdateCouncil) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Admin)
+
+	if err := tx.ValidateParams(p, loader); err != nil {
+		return err
+	}
+	if _, err := sp.Council(loader, tx.Name); err == nil {
+		return ErrExistCouncil
+	} else if err != ErrNotExistCouncil {
+		return err
+	}
+	if addr, err := sp.adminAddress(loader, tx.Name); err != nil {
+		return err
+	} else if tx.From() != addr {
+		return ErrUnauthorizedTransaction
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateParams validates parameters of the transaction apart from its signers
+func (tx *UpdateCouncil) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	if tx.Council == nil {
+		return ErrInvalidCouncil
+	}
+	if err := tx.Council.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *UpdateCouncil) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Admin)
+
+	return sp.setCouncil(ctw, tx.Name, tx.Council)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *UpdateCouncil) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(tx.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"council":`)
+	if bs, err := tx.Council.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
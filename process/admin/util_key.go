@@ -3,6 +3,8 @@ package admin
 // tags
 var (
 	tagAdminAddress = []byte{1, 1}
+	tagCouncil      = []byte{2, 1}
+	tagProposal     = []byte{3, 1}
 )
 
 func toAdminAddressKey(Name string) []byte {
@@ -11,3 +13,17 @@ func toAdminAddressKey(Name string) []byte {
 	copy(bs[2:], []byte(Name))
 	return bs
 }
+
+func toCouncilKey(Name string) []byte {
+	bs := make([]byte, 2+len(Name))
+	copy(bs, tagCouncil)
+	copy(bs[2:], []byte(Name))
+	return bs
+}
+
+func toProposalKey(ID string) []byte {
+	bs := make([]byte, 2+len(ID))
+	copy(bs, tagProposal)
+	copy(bs[2:], []byte(ID))
+	return bs
+}
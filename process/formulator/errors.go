@@ -38,4 +38,7 @@ var (
 	ErrNoOverAmount                            = errors.New("no over amount")
 	ErrSigmaCreationNotAllowed                 = errors.New("sigma creation not allowed")
 	ErrOmegaCreationNotAllowed                 = errors.New("omega creation not allowed")
+	ErrNotExistRewardHistory                   = errors.New("not exist reward history")
+	ErrSameHyperFormulator                     = errors.New("same hyper formulator")
+	ErrChangeStakingCooldown                   = errors.New("change staking cooldown")
 )
@@ -7,16 +7,18 @@ import (
 	"github.com/fletaio/fleta_testnet/encoding"
 	"github.com/fletaio/fleta_testnet/process/admin"
 	"github.com/fletaio/fleta_testnet/process/vault"
+	"github.com/fletaio/fleta_testnet/service/apiserver"
 )
 
 // Formulator serves reward system of the chain
 type Formulator struct {
 	*types.ProcessBase
-	pid   uint8
-	pm    types.ProcessManager
-	cn    types.Provider
-	vault *vault.Vault
-	admin *admin.Admin
+	pid                         uint8
+	pm                          types.ProcessManager
+	cn                          types.Provider
+	vault                       *vault.Vault
+	admin                       *admin.Admin
+	changeStakingCooldownHeight uint32
 }
 
 // NewFormulator returns a Formulator
@@ -86,6 +88,77 @@ func (p *Formulator) Init(reg *types.Register, pm types.ProcessManager, cn types
 	reg.RegisterEvent(1, &RewardEvent{})
 	reg.RegisterEvent(2, &RevokedEvent{})
 	reg.RegisterEvent(3, &UnstakedEvent{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("formulator")
+		if err != nil {
+			return err
+		}
+		s.Set("rewardProjection", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 && arg.Len() != 3 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			HyperAddress, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			StakingAmount, err := amount.ParseAmount(arg1)
+			if err != nil {
+				return nil, err
+			}
+			Periods := 10
+			if arg.Len() == 3 {
+				if Periods, err = arg.Int(2); err != nil {
+					return nil, err
+				}
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.ProjectStakingReward(loader, HyperAddress, StakingAmount, Periods)
+		})
+		s.Set("stakingRewardHistory", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 3 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			HyperAddress, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			var StakingAddress *common.Address
+			if len(arg1) > 0 {
+				addr, err := common.ParseAddress(arg1)
+				if err != nil {
+					return nil, err
+				}
+				StakingAddress = &addr
+			}
+			Periods, err := arg.Int(2)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.StakingRewardHistory(loader, HyperAddress, StakingAddress, Periods)
+		})
+	}
 	return nil
 }
 
@@ -172,6 +172,31 @@ func (p *Formulator) setUserAutoStaking(ctw *types.ContextWrapper, HyperAddress
 	}
 }
 
+// GetLastChangeStakingHeight returns the height of the last change staking of the address
+func (p *Formulator) GetLastChangeStakingHeight(loader types.Loader, StakingAddress common.Address) uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.AccountData(StakingAddress, tagLastChangeStakingHeight); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	} else {
+		return 0
+	}
+}
+
+func (p *Formulator) setLastChangeStakingHeight(ctw *types.ContextWrapper, StakingAddress common.Address, Height uint32) {
+	ctw.SetAccountData(StakingAddress, tagLastChangeStakingHeight, binutil.LittleEndian.Uint32ToBytes(Height))
+}
+
+// SetChangeStakingCooldownHeight sets the height from which a staker can change staking once per reward period
+// the cooldown is not applied when the height is zero so that blocks of the running network are executed as before
+func (p *Formulator) SetChangeStakingCooldownHeight(Height uint32) {
+	p.changeStakingCooldownHeight = Height
+}
+
+func (p *Formulator) isChangeStakingCooldownActivated(Height uint32) bool {
+	return p.changeStakingCooldownHeight > 0 && Height >= p.changeStakingCooldownHeight
+}
+
 // GetRevokedFormulatorHeight returns the revoke height of the formulator
 func (p *Formulator) GetRevokedFormulatorHeight(loader types.Loader, addr common.Address) (uint32, error) {
 	lw := types.NewLoaderWrapper(p.pid, loader)
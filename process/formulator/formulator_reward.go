@@ -0,0 +1,181 @@
+package formulator
+
+import (
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// MaxRewardHistoryPeriods is the maximum number of reward periods that are looked up at once
+const MaxRewardHistoryPeriods = 100
+
+// RewardProjection is the expected staking reward of the hyper formulator
+type RewardProjection struct {
+	HyperFormulator      common.Address `json:"hyper_formulator"`
+	StakingAmount        *amount.Amount `json:"staking_amount"`
+	PayRewardEveryBlocks uint32         `json:"pay_reward_every_blocks"`
+	PayOutInterval       uint32         `json:"pay_out_interval"`
+	CommissionRatio1000  uint32         `json:"commission_ratio_1000"`
+	SamplePeriods        int            `json:"sample_periods"`
+	RewardPerPeriod      *amount.Amount `json:"reward_per_period"`
+	CommissionPerPeriod  *amount.Amount `json:"commission_per_period"`
+	RewardPerPayOut      *amount.Amount `json:"reward_per_pay_out"`
+}
+
+// StakingRewardItem is the staking reward of the staker paid at the height
+type StakingRewardItem struct {
+	Height         uint32         `json:"height"`
+	StakingAddress common.Address `json:"staking_address"`
+	Reward         *amount.Amount `json:"reward"`
+	AutoStaked     *amount.Amount `json:"auto_staked"`
+}
+
+// RecentRewardEvents returns reward events of the recent reward periods from the newest one
+// the previous reward height is derived from the generated blocks of the event because the reward period can be changed by the policy
+func (p *Formulator) RecentRewardEvents(loader types.Loader, Periods int) ([]*RewardEvent, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if Periods > MaxRewardHistoryPeriods {
+		Periods = MaxRewardHistoryPeriods
+	}
+
+	list := []*RewardEvent{}
+	Height := p.getLastPaidHeight(lw)
+	for i := 0; i < Periods && Height > 0; i++ {
+		evs, err := p.cn.Events(Height, Height)
+		if err != nil {
+			return nil, err
+		}
+		var Blocks uint32
+		for _, e := range evs {
+			if ev, is := e.(*RewardEvent); is {
+				list = append(list, ev)
+				ev.GenBlockMap.EachAll(func(addr common.Address, GenCount uint32) bool {
+					Blocks += GenCount
+					return true
+				})
+			}
+		}
+		if Blocks == 0 || Height < Blocks {
+			break
+		}
+		Height -= Blocks
+	}
+	return list, nil
+}
+
+// ProjectStakingReward returns the expected staking reward of the amount at the hyper formulator
+// It uses the reward ratio of the recent reward periods because the ratio depends on the reward power of all formulators
+func (p *Formulator) ProjectStakingReward(loader types.Loader, HyperAddress common.Address, StakingAmount *amount.Amount, Periods int) (*RewardProjection, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	acc, err := lw.Account(HyperAddress)
+	if err != nil {
+		return nil, err
+	}
+	frAcc, is := acc.(*FormulatorAccount)
+	if !is {
+		return nil, types.ErrInvalidAccountType
+	}
+	if frAcc.FormulatorType != HyperFormulatorType {
+		return nil, ErrNotHyperFormulator
+	}
+	policy, err := p.GetRewardPolicy(lw)
+	if err != nil {
+		return nil, err
+	}
+	evs, err := p.RecentRewardEvents(lw, Periods)
+	if err != nil {
+		return nil, err
+	}
+
+	RewardSum := amount.NewCoinAmount(0, 0)
+	Samples := 0
+	for _, ev := range evs {
+		GenCount, has := ev.GenBlockMap.Get(HyperAddress)
+		if !has || GenCount == 0 {
+			continue
+		}
+		HyperReward, has := ev.RewardMap.Get(HyperAddress)
+		if !has || HyperReward.IsZero() {
+			continue
+		}
+		HyperPower := frAcc.Amount.MulC(int64(GenCount)).MulC(int64(policy.HyperEfficiency1000)).DivC(1000)
+		if HyperPower.IsZero() {
+			continue
+		}
+		Ratio := HyperReward.Mul(amount.COIN).Div(HyperPower)
+		StakingPower := StakingAmount.MulC(int64(GenCount)).MulC(int64(policy.StakingEfficiency1000)).DivC(1000)
+		RewardSum = RewardSum.Add(StakingPower.Mul(Ratio).Div(amount.COIN))
+		Samples++
+	}
+	if Samples == 0 {
+		return nil, ErrNotExistRewardHistory
+	}
+
+	Reward := RewardSum.DivC(int64(Samples))
+	Commission := Reward.MulC(int64(frAcc.Policy.CommissionRatio1000)).DivC(1000)
+	Reward = Reward.Sub(Commission)
+	return &RewardProjection{
+		HyperFormulator:      HyperAddress,
+		StakingAmount:        StakingAmount,
+		PayRewardEveryBlocks: policy.PayRewardEveryBlocks,
+		PayOutInterval:       frAcc.Policy.PayOutInterval,
+		CommissionRatio1000:  frAcc.Policy.CommissionRatio1000,
+		SamplePeriods:        Samples,
+		RewardPerPeriod:      Reward,
+		CommissionPerPeriod:  Commission,
+		RewardPerPayOut:      Reward.MulC(int64(frAcc.Policy.PayOutInterval)),
+	}, nil
+}
+
+// StakingRewardHistory returns staking rewards of the hyper formulator that are paid in the recent reward periods
+// It returns rewards of all stakers when the staking address is nil
+func (p *Formulator) StakingRewardHistory(loader types.Loader, HyperAddress common.Address, StakingAddress *common.Address, Periods int) ([]*StakingRewardItem, error) {
+	evs, err := p.RecentRewardEvents(loader, Periods)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*StakingRewardItem{}
+	for _, ev := range evs {
+		ItemMap := map[common.Address]*StakingRewardItem{}
+		Addrs := []common.Address{}
+		getItem := func(addr common.Address) *StakingRewardItem {
+			item, has := ItemMap[addr]
+			if !has {
+				item = &StakingRewardItem{
+					Height:         ev.Height(),
+					StakingAddress: addr,
+					Reward:         amount.NewCoinAmount(0, 0),
+					AutoStaked:     amount.NewCoinAmount(0, 0),
+				}
+				ItemMap[addr] = item
+				Addrs = append(Addrs, addr)
+			}
+			return item
+		}
+		if mp, has := ev.StakeRewardMap.Get(HyperAddress); has {
+			mp.EachAll(func(addr common.Address, am *amount.Amount) bool {
+				if StakingAddress == nil || *StakingAddress == addr {
+					item := getItem(addr)
+					item.Reward = item.Reward.Add(am)
+				}
+				return true
+			})
+		}
+		if mp, has := ev.StakedMap.Get(HyperAddress); has {
+			mp.EachAll(func(addr common.Address, am *amount.Amount) bool {
+				if StakingAddress == nil || *StakingAddress == addr {
+					item := getItem(addr)
+					item.AutoStaked = item.AutoStaked.Add(am)
+				}
+				return true
+			})
+		}
+		for _, addr := range Addrs {
+			list = append(list, ItemMap[addr])
+		}
+	}
+	return list, nil
+}
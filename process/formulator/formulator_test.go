@@ -0,0 +1,176 @@
+package formulator
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+	"github.com/fletaio/fleta_testnet/process/processtest"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+var (
+	testHyper1    = common.NewAddress(0, 1, 0)
+	testHyper2    = common.NewAddress(0, 2, 0)
+	testHyper3    = common.NewAddress(0, 3, 0)
+	testStaker    = common.NewAddress(0, 4, 0)
+	testStakerKey = common.PublicHash{4}
+)
+
+const testPayRewardEveryBlocks = 10
+
+// newTestFormulator returns the formulator and the context of the test at the height 1
+// the staker stakes 100 coins to the first hyper formulator of three hyper formulators
+func newTestFormulator(t *testing.T) (*Formulator, *types.Context) {
+	ap := admin.NewAdmin(1)
+	vp := vault.NewVault(2)
+	fp := NewFormulator(3)
+	pm := processtest.NewProcessManager(t, ap, vp, fp)
+	ctx := pm.NewContext(t, &processtest.Fixture{
+		Accounts: []types.Account{
+			&vault.SingleAccount{Address_: testStaker, Name_: "staker", KeyHash: testStakerKey},
+		},
+		Balances: map[common.Address]*amount.Amount{testStaker: amount.NewCoinAmount(10, 0)},
+	})
+	ctw := types.NewContextWrapper(fp.ID(), ctx)
+	if err := vp.InitPolicy(ctw, &vault.Policy{AccountCreationAmount: amount.NewCoinAmount(10, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fp.InitPolicy(ctw,
+		&RewardPolicy{RewardPerBlock: amount.NewCoinAmount(1, 0), PayRewardEveryBlocks: testPayRewardEveryBlocks},
+		&AlphaPolicy{AlphaCreationAmount: amount.NewCoinAmount(1000, 0)},
+		&SigmaPolicy{},
+		&OmegaPolicy{},
+		&HyperPolicy{HyperCreationAmount: amount.NewCoinAmount(1000, 0)},
+	); err != nil {
+		t.Fatal(err)
+	}
+	for i, addr := range []common.Address{testHyper1, testHyper2, testHyper3} {
+		acc := &FormulatorAccount{
+			Address_:       addr,
+			Name_:          "hyper" + string('1'+rune(i)),
+			FormulatorType: HyperFormulatorType,
+			Amount:         amount.NewCoinAmount(1000, 0),
+			StakingAmount:  amount.NewCoinAmount(0, 0),
+			Policy:         &ValidatorPolicy{MinimumStaking: amount.NewCoinAmount(0, 0)},
+		}
+		if addr == testHyper1 {
+			acc.StakingAmount = amount.NewCoinAmount(100, 0)
+		}
+		if err := ctw.CreateAccount(acc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fp.AddStakingAmount(ctw, testHyper1, testStaker, amount.NewCoinAmount(100, 0))
+	return fp, ctx.NextContext(hash.Hash256{}, 0)
+}
+
+func TestChangeStakingCooldown(t *testing.T) {
+	tests := []struct {
+		name           string
+		cooldownHeight uint32
+		blocks         uint32
+		err            error
+	}{
+		{"not activated", 0, 0, nil},
+		{"before the activation", 10, 0, nil},
+		{"in the cooldown", 1, testPayRewardEveryBlocks - 1, ErrChangeStakingCooldown},
+		{"after the cooldown", 1, testPayRewardEveryBlocks, nil},
+	}
+	for _, tt := range tests {
+		fp, ctx := newTestFormulator(t)
+		fp.SetChangeStakingCooldownHeight(tt.cooldownHeight)
+		ctw := types.NewContextWrapper(fp.ID(), ctx)
+
+		var tx types.Transaction = &ChangeStaking{From_: testStaker, HyperUnstaking: testHyper1, HyperStaking: testHyper2, Amount: amount.NewCoinAmount(10, 0)}
+		if err := tx.Validate(fp, ctw, []common.PublicHash{testStakerKey}); err != nil {
+			t.Fatalf("%s: first change: %v", tt.name, err)
+		}
+		if err := tx.Execute(fp, ctw, 0); err != nil {
+			t.Fatalf("%s: first change: %v", tt.name, err)
+		}
+		for i := uint32(0); i < tt.blocks; i++ {
+			ctx = ctx.NextContext(hash.Hash256{}, 0)
+		}
+		ctw = types.NewContextWrapper(fp.ID(), ctx)
+		tx = &ChangeStaking{From_: testStaker, HyperUnstaking: testHyper2, HyperStaking: testHyper3, Amount: amount.NewCoinAmount(5, 0)}
+		if err := tx.Validate(fp, ctw, []common.PublicHash{testStakerKey}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+// testEventProvider returns events of heights and the other functions of the provider are not used
+type testEventProvider struct {
+	types.Provider
+	EventMap map[uint32][]types.Event
+}
+
+func (cn *testEventProvider) Events(From uint32, To uint32) ([]types.Event, error) {
+	evs := []types.Event{}
+	for h := From; h <= To; h++ {
+		evs = append(evs, cn.EventMap[h]...)
+	}
+	return evs, nil
+}
+
+func TestRecentRewardEvents(t *testing.T) {
+	fp, ctx := newTestFormulator(t)
+	ctw := types.NewContextWrapper(fp.ID(), ctx)
+
+	// reward periods are 5, 15 and 10 blocks that are different from the current policy
+	cn := &testEventProvider{EventMap: map[uint32][]types.Event{}}
+	for _, v := range [][2]uint32{{5, 5}, {20, 15}, {30, 10}} {
+		ev := &RewardEvent{Height_: v[0], Index_: 65535, GenBlockMap: types.NewAddressUint32Map()}
+		ev.GenBlockMap.Put(testHyper1, v[1]-1)
+		ev.GenBlockMap.Put(testHyper2, 1)
+		cn.EventMap[v[0]] = []types.Event{ev}
+	}
+	fp.cn = cn
+	fp.setLastPaidHeight(ctw, 30)
+
+	tests := []struct {
+		name    string
+		periods int
+		heights []uint32
+	}{
+		{"all periods", 10, []uint32{30, 20, 5}},
+		{"recent periods", 2, []uint32{30, 20}},
+	}
+	for _, tt := range tests {
+		evs, err := fp.RecentRewardEvents(ctw, tt.periods)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(evs) != len(tt.heights) {
+			t.Fatalf("%s: got %d events, want %d", tt.name, len(evs), len(tt.heights))
+		}
+		for i, ev := range evs {
+			if ev.Height() != tt.heights[i] {
+				t.Errorf("%s: got height %d, want %d", tt.name, ev.Height(), tt.heights[i])
+			}
+		}
+	}
+}
+
+func TestRevokeAdminValidateParams(t *testing.T) {
+	tests := []struct {
+		name string
+		from common.Address
+		err  error
+	}{
+		{"fee payable", testStaker, nil},
+		{"fee not payable", testHyper2, vault.ErrInsufficientFee},
+	}
+	for _, tt := range tests {
+		fp, ctx := newTestFormulator(t)
+		ctw := types.NewContextWrapper(fp.ID(), ctx)
+		tx := &RevokeAdmin{From_: tt.from, Formulator: testHyper3, Heritor: testStaker}
+		if err := tx.ValidateParams(fp, ctw); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -9,7 +9,8 @@ import (
 	"github.com/fletaio/fleta_testnet/core/types"
 )
 
-// ChangeStaking is used to stake coin to the hyper formulator
+// ChangeStaking is used to move the staking amount from the hyper formulator to the other hyper formulator
+// a staker can change staking once per reward period after the cooldown height to prevent hopping between hyper formulators
 type ChangeStaking struct {
 	Timestamp_     uint64
 	From_          common.Address
@@ -69,6 +70,18 @@ func (tx *ChangeStaking) Validate(p types.Process, loader types.LoaderWrapper, s
 		return ErrInvalidStakingAmount
 	}
 
+	if sp.isChangeStakingCooldownActivated(loader.TargetHeight()) {
+		policy, err := sp.GetRewardPolicy(loader)
+		if err != nil {
+			return err
+		}
+		if LastHeight := sp.GetLastChangeStakingHeight(loader, tx.From()); LastHeight > 0 {
+			if loader.TargetHeight() < LastHeight+policy.PayRewardEveryBlocks {
+				return ErrChangeStakingCooldown
+			}
+		}
+	}
+
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
 		return err
@@ -96,6 +109,9 @@ func (tx *ChangeStaking) Execute(p types.Process, ctw *types.ContextWrapper, ind
 	if err != nil {
 		return err
 	}
+	if sp.isChangeStakingCooldownActivated(ctw.TargetHeight()) {
+		sp.setLastChangeStakingHeight(ctw, tx.From(), ctw.TargetHeight())
+	}
 
 	if err := sp.vault.CheckFeePayable(p, ctw, tx); err != nil {
 		Fee := tx.Fee(p, ctw)
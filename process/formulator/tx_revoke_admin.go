@@ -38,17 +38,11 @@ func (tx *RevokeAdmin) Fee(p types.Process, loader types.LoaderWrapper) *amount.
 func (tx *RevokeAdmin) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Formulator)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if !sp.admin.IsPolicyAdmin(loader, p.Name(), tx.From()) {
 		return admin.ErrUnauthorizedTransaction
 	}
-	if tx.Formulator == tx.Heritor {
-		return ErrInvalidHeritor
-	}
-
-	if has, err := loader.HasAccount(tx.Heritor); err != nil {
+	if err := tx.ValidateParams(p, loader); err != nil {
 		return err
-	} else if !has {
-		return types.ErrNotExistAccount
 	}
 
 	fromAcc, err := loader.Account(tx.From())
@@ -58,6 +52,23 @@ func (tx *RevokeAdmin) Validate(p types.Process, loader types.LoaderWrapper, sig
 	if err := fromAcc.Validate(loader, signers); err != nil {
 		return err
 	}
+	return nil
+}
+
+// ValidateParams validates parameters of the transaction apart from its signers
+// the fee is checked too because the proposal of it is executed by the fee of the admin
+func (tx *RevokeAdmin) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	sp := p.(*Formulator)
+
+	if tx.Formulator == tx.Heritor {
+		return ErrInvalidHeritor
+	}
+
+	if has, err := loader.HasAccount(tx.Heritor); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
 
 	acc, err := loader.Account(tx.Formulator)
 	if err != nil {
@@ -31,11 +31,11 @@ func (tx *UpdateHyperPolicy) From() common.Address {
 func (tx *UpdateHyperPolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Formulator)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if !sp.admin.IsPolicyAdmin(loader, p.Name(), tx.From()) {
 		return admin.ErrUnauthorizedTransaction
 	}
-	if tx.Policy == nil {
-		return ErrInvalidHyperPolicy
+	if err := tx.ValidateParams(p, loader); err != nil {
+		return err
 	}
 
 	fromAcc, err := loader.Account(tx.From())
@@ -48,6 +48,14 @@ func (tx *UpdateHyperPolicy) Validate(p types.Process, loader types.LoaderWrappe
 	return nil
 }
 
+// ValidateParams validates parameters of the transaction apart from its signers
+func (tx *UpdateHyperPolicy) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	if tx.Policy == nil {
+		return ErrInvalidHyperPolicy
+	}
+	return nil
+}
+
 // Execute updates the context by the transaction
 func (tx *UpdateHyperPolicy) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	if bs, err := encoding.Marshal(tx.Policy); err != nil {
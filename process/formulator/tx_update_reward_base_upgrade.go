@@ -30,9 +30,12 @@ func (tx *UpdateRewardBaseUpgrade) From() common.Address {
 func (tx *UpdateRewardBaseUpgrade) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Formulator)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if !sp.admin.IsPolicyAdmin(loader, p.Name(), tx.From()) {
 		return admin.ErrUnauthorizedTransaction
 	}
+	if err := tx.ValidateParams(p, loader); err != nil {
+		return err
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
@@ -44,6 +47,11 @@ func (tx *UpdateRewardBaseUpgrade) Validate(p types.Process, loader types.Loader
 	return nil
 }
 
+// ValidateParams validates parameters of the transaction apart from its signers
+func (tx *UpdateRewardBaseUpgrade) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	return nil
+}
+
 // Execute updates the context by the transaction
 func (tx *UpdateRewardBaseUpgrade) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	sp := p.(*Formulator)
@@ -31,11 +31,11 @@ func (tx *UpdateRewardPolicy) From() common.Address {
 func (tx *UpdateRewardPolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Formulator)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if !sp.admin.IsPolicyAdmin(loader, p.Name(), tx.From()) {
 		return admin.ErrUnauthorizedTransaction
 	}
-	if tx.Policy == nil {
-		return ErrInvalidRewardPolicy
+	if err := tx.ValidateParams(p, loader); err != nil {
+		return err
 	}
 
 	fromAcc, err := loader.Account(tx.From())
@@ -48,6 +48,14 @@ func (tx *UpdateRewardPolicy) Validate(p types.Process, loader types.LoaderWrapp
 	return nil
 }
 
+// ValidateParams validates parameters of the transaction apart from its signers
+func (tx *UpdateRewardPolicy) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	if tx.Policy == nil {
+		return ErrInvalidRewardPolicy
+	}
+	return nil
+}
+
 // Execute updates the context by the transaction
 func (tx *UpdateRewardPolicy) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	if bs, err := encoding.Marshal(tx.Policy); err != nil {
@@ -31,9 +31,12 @@ func (tx *UpdateTransmutePolicy) From() common.Address {
 func (tx *UpdateTransmutePolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Formulator)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if !sp.admin.IsPolicyAdmin(loader, p.Name(), tx.From()) {
 		return admin.ErrUnauthorizedTransaction
 	}
+	if err := tx.ValidateParams(p, loader); err != nil {
+		return err
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
@@ -45,6 +48,11 @@ func (tx *UpdateTransmutePolicy) Validate(p types.Process, loader types.LoaderWr
 	return nil
 }
 
+// ValidateParams validates parameters of the transaction apart from its signers
+func (tx *UpdateTransmutePolicy) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	return nil
+}
+
 // Execute updates the context by the transaction
 func (tx *UpdateTransmutePolicy) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	if tx.Policy == nil {
@@ -37,6 +37,7 @@ var (
 	tagUnstakingAmountReverse   = []byte{6, 2}
 	tagUnstakingAmountCount     = []byte{6, 3}
 	tagRewardBaseUpgrade        = []byte{7, 0}
+	tagLastChangeStakingHeight  = []byte{8, 0}
 )
 
 func toStakingAmountKey(StakingAddrss common.Address) []byte {
@@ -14,4 +14,15 @@ var (
 	ErrProcessedERC20TXID               = errors.New("processed erc20 txid")
 	ErrProcessedOutTXID                 = errors.New("processed out txid")
 	ErrPolicyShouldBeSetupInApplication = errors.New("policy should be setup in application")
+	ErrInvalidRelayerSet                = errors.New("invalid relayer set")
+	ErrNotExistRelayerSet               = errors.New("not exist relayer set")
+	ErrInsufficientAttestation          = errors.New("insufficient attestation")
+	ErrInvalidAttestation               = errors.New("invalid attestation")
+	ErrDuplicatedAttestation            = errors.New("duplicated attestation")
+	ErrNotExistBatch                    = errors.New("not exist batch")
+	ErrNotSealedBatch                   = errors.New("not sealed batch")
+	ErrNotExistWithdrawal               = errors.New("not exist withdrawal")
+	ErrWithdrawalPaused                 = errors.New("withdrawal paused")
+	ErrExceedAddressDailyLimit          = errors.New("exceed address daily limit")
+	ErrExceedGlobalDailyLimit           = errors.New("exceed global daily limit")
 )
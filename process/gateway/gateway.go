@@ -1,10 +1,13 @@
 package gateway
 
 import (
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/encoding"
 	"github.com/fletaio/fleta_testnet/process/admin"
 	"github.com/fletaio/fleta_testnet/process/vault"
+	"github.com/fletaio/fleta_testnet/service/apiserver"
 )
 
 // Gateway manages balance of accounts of the chain
@@ -64,13 +67,127 @@ func (p *Gateway) Init(reg *types.Register, pm types.ProcessManager, cn types.Pr
 	reg.RegisterTransaction(2, &TokenOut{})
 	reg.RegisterTransaction(3, &TokenLeave{})
 	reg.RegisterTransaction(4, &UpdatePolicy{})
+	reg.RegisterTransaction(5, &UpdateRelayerSet{})
+	reg.RegisterTransaction(6, &CompleteBatch{})
+	reg.RegisterTransaction(7, &SetPause{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("gateway")
+		if err != nil {
+			return err
+		}
+		s.Set("policy", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Policy(loader)
+		})
+		s.Set("paused", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.IsPaused(loader), nil
+		})
+		s.Set("withdrawFee", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			am, err := amount.ParseAmount(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			policy, err := p.Policy(loader)
+			if err != nil {
+				return nil, err
+			}
+			return policy.WithdrawFeeOf(am), nil
+		})
+		s.Set("dailyWithdrawal", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return map[string]*amount.Amount{
+				"address": p.DailyWithdrawal(loader, addr),
+				"global":  p.GlobalDailyWithdrawal(loader),
+			}, nil
+		})
+		s.Set("relayerSet", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			rs, err := p.RelayerSet(loader)
+			if err == ErrNotExistRelayerSet {
+				return nil, nil
+			}
+			return rs, err
+		})
+		s.Set("currentBatch", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			wb, err := p.Batch(loader, p.CurrentBatchNumber(loader))
+			if err == ErrNotExistBatch {
+				return nil, nil
+			}
+			return wb, err
+		})
+		s.Set("batch", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			num, err := arg.Uint64(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Batch(loader, num)
+		})
+		s.Set("outBatch", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TXID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			num, err := p.OutBatchNumber(loader, TXID)
+			if err != nil {
+				return nil, err
+			}
+			return p.Batch(loader, num)
+		})
+	}
 	return nil
 }
 
+// InitRelayerSet called at OnInitGenesis of an application
+func (p *Gateway) InitRelayerSet(ctw *types.ContextWrapper, rs *RelayerSet) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	if err := rs.Validate(); err != nil {
+		return err
+	}
+	return p.setRelayerSet(ctw, rs)
+}
+
 // InitPolicy called at OnInitGenesis of an application
 func (p *Gateway) InitPolicy(ctw *types.ContextWrapper, policy *Policy) error {
 	ctw = types.SwitchContextWrapper(p.pid, ctw)
 
+	if err := policy.Validate(); err != nil {
+		return err
+	}
 	if bs, err := encoding.Marshal(policy); err != nil {
 		return err
 	} else {
@@ -95,6 +212,22 @@ func (p *Gateway) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
 
 // AfterExecuteTransactions called after processes transactions of the block
 func (p *Gateway) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	wb, err := p.Batch(ctw, p.CurrentBatchNumber(ctw))
+	if err != nil {
+		if err == ErrNotExistBatch {
+			return nil
+		}
+		return err
+	}
+	policy, err := p.Policy(ctw)
+	if err != nil {
+		return err
+	}
+	if ctw.TargetHeight() >= wb.OpenedHeight+policy.BatchInterval {
+		if err := p.sealBatch(ctw, wb); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1,8 +1,12 @@
 package gateway
 
 import (
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/binutil"
 	"github.com/fletaio/fleta_testnet/common/hash"
 	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
 )
 
 // HasERC20TXID returns the erc20 txid has processed or not
@@ -34,3 +38,237 @@ func (p *Gateway) HasOutTXID(loader types.Loader, CoinTXID string) bool {
 func (p *Gateway) setOutTXID(ctw *types.ContextWrapper, CoinTXID string) {
 	ctw.SetProcessData(toOutTXIDKey(CoinTXID), []byte{1})
 }
+
+// Policy returns the policy of the gateway
+func (p *Gateway) Policy(loader types.Loader) (*Policy, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	policy := &Policy{}
+	if err := encoding.Unmarshal(lw.ProcessData(tagPolicy), &policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// RelayerSet returns the relayer set of the gateway
+// Attestations can't be accepted until the relayer set is set up
+func (p *Gateway) RelayerSet(loader types.Loader) (*RelayerSet, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(tagRelayerSet); len(bs) > 0 {
+		rs := &RelayerSet{}
+		if err := encoding.Unmarshal(bs, &rs); err != nil {
+			return nil, err
+		}
+		return rs, nil
+	} else {
+		return nil, ErrNotExistRelayerSet
+	}
+}
+
+func (p *Gateway) setRelayerSet(ctw *types.ContextWrapper, rs *RelayerSet) error {
+	body, err := encoding.Marshal(rs)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(tagRelayerSet, body)
+	return nil
+}
+
+// CurrentBatchNumber returns the number of the batch that collects TokenOut requests
+func (p *Gateway) CurrentBatchNumber(loader types.Loader) uint64 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(tagBatchNumber); len(bs) > 0 {
+		return binutil.LittleEndian.Uint64(bs)
+	} else {
+		return 1
+	}
+}
+
+// Batch returns the withdrawal batch of the number
+func (p *Gateway) Batch(loader types.Loader, num uint64) (*WithdrawalBatch, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(toBatchKey(num)); len(bs) > 0 {
+		wb := &WithdrawalBatch{}
+		if err := encoding.Unmarshal(bs, &wb); err != nil {
+			return nil, err
+		}
+		return wb, nil
+	} else {
+		return nil, ErrNotExistBatch
+	}
+}
+
+func (p *Gateway) setBatch(ctw *types.ContextWrapper, wb *WithdrawalBatch) error {
+	body, err := encoding.Marshal(wb)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toBatchKey(wb.Number), body)
+	return nil
+}
+
+// OutBatchNumber returns the number of the batch that includes the TokenOut request
+func (p *Gateway) OutBatchNumber(loader types.Loader, CoinTXID string) (uint64, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(toOutBatchNumberKey(CoinTXID)); len(bs) > 0 {
+		return binutil.LittleEndian.Uint64(bs), nil
+	} else {
+		return 0, ErrNotExistBatch
+	}
+}
+
+// unpaidWithdrawal returns the sealed batch and the withdrawal item of the TokenOut request that is not paid out yet
+func (p *Gateway) unpaidWithdrawal(loader types.Loader, CoinTXID string) (*WithdrawalBatch, *WithdrawalItem, error) {
+	num, err := p.OutBatchNumber(loader, CoinTXID)
+	if err != nil {
+		return nil, nil, ErrNotExistWithdrawal
+	}
+	wb, err := p.Batch(loader, num)
+	if err != nil {
+		return nil, nil, err
+	}
+	if wb.Status != BatchSealed {
+		return nil, nil, ErrNotSealedBatch
+	}
+	for _, item := range wb.Items {
+		if item.CoinTXID == CoinTXID {
+			return wb, item, nil
+		}
+	}
+	return nil, nil, ErrNotExistWithdrawal
+}
+
+// addWithdrawal appends the TokenOut request to the current batch and seals it when the batch is full
+func (p *Gateway) addWithdrawal(ctw *types.ContextWrapper, item *WithdrawalItem) error {
+	policy, err := p.Policy(ctw)
+	if err != nil {
+		return err
+	}
+	num := p.CurrentBatchNumber(ctw)
+	wb, err := p.Batch(ctw, num)
+	if err != nil {
+		if err != ErrNotExistBatch {
+			return err
+		}
+		wb = &WithdrawalBatch{
+			Number:       num,
+			Items:        []*WithdrawalItem{},
+			OpenedHeight: ctw.TargetHeight(),
+			Status:       BatchOpened,
+		}
+	}
+	wb.Items = append(wb.Items, item)
+	ctw.SetProcessData(toOutBatchNumberKey(item.CoinTXID), binutil.LittleEndian.Uint64ToBytes(num))
+	if policy.MaxBatchSize > 0 && len(wb.Items) >= int(policy.MaxBatchSize) {
+		return p.sealBatch(ctw, wb)
+	}
+	return p.setBatch(ctw, wb)
+}
+
+// sealBatch fixes the hash of the batch and opens the next batch
+func (p *Gateway) sealBatch(ctw *types.ContextWrapper, wb *WithdrawalBatch) error {
+	wb.Status = BatchSealed
+	wb.SealedHeight = ctw.TargetHeight()
+	wb.Hash = wb.ItemsHash()
+	if err := p.setBatch(ctw, wb); err != nil {
+		return err
+	}
+	ctw.SetProcessData(tagBatchNumber, binutil.LittleEndian.Uint64ToBytes(wb.Number+1))
+	return nil
+}
+
+// IsPaused returns withdrawals of the gateway are paused or not
+func (p *Gateway) IsPaused(loader types.Loader) bool {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(tagPaused); len(bs) > 0 {
+		return true
+	} else {
+		return false
+	}
+}
+
+func (p *Gateway) setPaused(ctw *types.ContextWrapper, Paused bool) {
+	if Paused {
+		ctw.SetProcessData(tagPaused, []byte{1})
+	} else {
+		ctw.SetProcessData(tagPaused, nil)
+	}
+}
+
+// DailyWithdrawal returns the withdrawn amount of the address in the current day
+func (p *Gateway) DailyWithdrawal(loader types.Loader, addr common.Address) *amount.Amount {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return dailyAmount(lw.AccountData(addr, tagDailyWithdrawal), p.withdrawalDay(lw, lw.TargetHeight()))
+}
+
+// GlobalDailyWithdrawal returns the withdrawn amount of all addresses in the current day
+func (p *Gateway) GlobalDailyWithdrawal(loader types.Loader) *amount.Amount {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return dailyAmount(lw.ProcessData(tagDailyWithdrawal), p.withdrawalDay(lw, lw.TargetHeight()))
+}
+
+// checkDailyLimit returns an error when the withdrawal exceeds daily limits of the policy
+func (p *Gateway) checkDailyLimit(loader types.Loader, policy *Policy, addr common.Address, am *amount.Amount) error {
+	if policy.AddressDailyLimit != nil && !policy.AddressDailyLimit.IsZero() {
+		if policy.AddressDailyLimit.Less(p.DailyWithdrawal(loader, addr).Add(am)) {
+			return ErrExceedAddressDailyLimit
+		}
+	}
+	if policy.GlobalDailyLimit != nil && !policy.GlobalDailyLimit.IsZero() {
+		if policy.GlobalDailyLimit.Less(p.GlobalDailyWithdrawal(loader).Add(am)) {
+			return ErrExceedGlobalDailyLimit
+		}
+	}
+	return nil
+}
+
+func (p *Gateway) addDailyWithdrawal(ctw *types.ContextWrapper, addr common.Address, am *amount.Amount) {
+	day := p.withdrawalDay(ctw, ctw.TargetHeight())
+	ctw.SetAccountData(addr, tagDailyWithdrawal, toDailyAmountBytes(day, p.DailyWithdrawal(ctw, addr).Add(am)))
+	ctw.SetProcessData(tagDailyWithdrawal, toDailyAmountBytes(day, p.GlobalDailyWithdrawal(ctw).Add(am)))
+}
+
+// subDailyWithdrawal rolls back the refunded withdrawal of the height from daily withdrawals
+// the withdrawal of the past day is not counted anymore so only the withdrawal of the current day is rolled back
+func (p *Gateway) subDailyWithdrawal(ctw *types.ContextWrapper, addr common.Address, am *amount.Amount, Height uint32) {
+	day := p.withdrawalDay(ctw, ctw.TargetHeight())
+	if p.withdrawalDay(ctw, Height) != day {
+		return
+	}
+	ctw.SetAccountData(addr, tagDailyWithdrawal, toDailyAmountBytes(day, subDailyAmount(p.DailyWithdrawal(ctw, addr), am)))
+	ctw.SetProcessData(tagDailyWithdrawal, toDailyAmountBytes(day, subDailyAmount(p.GlobalDailyWithdrawal(ctw), am)))
+}
+
+// withdrawalDay returns the day of the height by BlocksPerDay of the policy
+func (p *Gateway) withdrawalDay(loader types.Loader, Height uint32) uint32 {
+	policy, err := p.Policy(loader)
+	if err != nil || policy.BlocksPerDay == 0 {
+		return 0
+	}
+	return Height / policy.BlocksPerDay
+}
+
+func subDailyAmount(sum *amount.Amount, am *amount.Amount) *amount.Amount {
+	if sum.Less(am) {
+		return amount.NewCoinAmount(0, 0)
+	}
+	return sum.Sub(am)
+}
+
+func dailyAmount(bs []byte, day uint32) *amount.Amount {
+	if len(bs) < 4 || binutil.LittleEndian.Uint32(bs) != day {
+		return amount.NewCoinAmount(0, 0)
+	}
+	return amount.NewAmountFromBytes(bs[4:])
+}
+
+func toDailyAmountBytes(day uint32, am *amount.Amount) []byte {
+	return append(binutil.LittleEndian.Uint32ToBytes(day), am.Bytes()...)
+}
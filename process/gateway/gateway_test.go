@@ -0,0 +1,205 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/common/key"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+	"github.com/fletaio/fleta_testnet/process/processtest"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+var (
+	testAdmin    = common.NewAddress(0, 1, 0)
+	testUser     = common.NewAddress(0, 2, 0)
+	testAdminKey = common.PublicHash{1}
+	testUserKey  = common.PublicHash{2}
+)
+
+// newTestGateway returns the gateway and the context of the test
+// the admin account has 1000 coins, the user account has 100 coins and a day of the policy is 100 blocks
+func newTestGateway(t *testing.T) (*Gateway, *types.Context) {
+	ap := admin.NewAdmin(1)
+	vp := vault.NewVault(2)
+	gp := NewGateway(3)
+	pm := processtest.NewProcessManager(t, ap, vp, gp)
+	ctx := pm.NewContext(t, &processtest.Fixture{
+		AdminAddressMap: map[string]common.Address{gp.Name(): testAdmin},
+		Accounts: []types.Account{
+			&vault.SingleAccount{Address_: testAdmin, Name_: "admin", KeyHash: testAdminKey},
+			&vault.SingleAccount{Address_: testUser, Name_: "user", KeyHash: testUserKey},
+		},
+		Balances: map[common.Address]*amount.Amount{
+			testAdmin: amount.NewCoinAmount(1000, 0),
+			testUser:  amount.NewCoinAmount(100, 0),
+		},
+	})
+	ctw := types.NewContextWrapper(gp.ID(), ctx)
+	if err := vp.InitPolicy(ctw, &vault.Policy{AccountCreationAmount: amount.NewCoinAmount(10, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := gp.InitPolicy(ctw, &Policy{
+		WithdrawFee:       amount.NewCoinAmount(1, 0),
+		BatchInterval:     10,
+		MaxBatchSize:      10,
+		MinWithdrawFee:    amount.NewCoinAmount(0, 0),
+		MaxWithdrawFee:    amount.NewCoinAmount(0, 0),
+		AddressDailyLimit: amount.NewCoinAmount(0, 0),
+		GlobalDailyLimit:  amount.NewCoinAmount(0, 0),
+		BlocksPerDay:      100,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return gp, ctx.NextContext(hash.Hash256{}, 0)
+}
+
+// nextContext returns the context after the count of blocks
+func nextContext(ctx *types.Context, count int) *types.Context {
+	for i := 0; i < count; i++ {
+		ctx = ctx.NextContext(hash.Hash256{}, 0)
+	}
+	return ctx
+}
+
+func TestTokenInAttestations(t *testing.T) {
+	keys := make([]*key.MemoryKey, 4)
+	for i := range keys {
+		k, err := key.NewMemoryKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = k
+	}
+	rs := &RelayerSet{Threshold: 2}
+	for _, k := range keys[:3] {
+		rs.Relayers = append(rs.Relayers, common.NewPublicHash(k.PublicKey()))
+	}
+
+	tests := []struct {
+		name    string
+		rs      *RelayerSet
+		signers []int
+		ChainID uint8
+		err     error
+	}{
+		{"quorum", rs, []int{0, 1}, 0, nil},
+		{"all relayers", rs, []int{0, 1, 2}, 0, nil},
+		{"not exist relayer set", nil, []int{0, 1}, 0, ErrNotExistRelayerSet},
+		{"no attestation", rs, nil, 0, ErrInsufficientAttestation},
+		{"under quorum", rs, []int{0}, 0, ErrInsufficientAttestation},
+		{"duplicated relayer", rs, []int{0, 0}, 0, ErrDuplicatedAttestation},
+		{"not relayer", rs, []int{0, 3}, 0, ErrInvalidAttestation},
+		{"other chain", rs, []int{0, 1}, 1, ErrInvalidAttestation},
+	}
+	for _, tt := range tests {
+		gp, ctx := newTestGateway(t)
+		ctw := types.NewContextWrapper(gp.ID(), ctx)
+		if tt.rs != nil {
+			if err := gp.InitRelayerSet(ctw, tt.rs); err != nil {
+				t.Fatal(err)
+			}
+		}
+		tx := &TokenIn{
+			From_:       testAdmin,
+			ERC20TXID:   hash.Hash([]byte(tt.name)),
+			ToAddresses: []common.Address{testUser},
+			Amounts:     []*amount.Amount{amount.NewCoinAmount(10, 0)},
+		}
+		for _, i := range tt.signers {
+			sig, err := keys[i].Sign(tx.AttestationHash(tt.ChainID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tx.Attestations = append(tx.Attestations, sig)
+		}
+		if err := tx.Validate(gp, ctw, []common.PublicHash{testAdminKey}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestTokenLeaveRefund(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(gp *Gateway, ctx *types.Context) *types.Context
+		daily *amount.Amount
+		err   error
+	}{
+		{"refund of the day", func(gp *Gateway, ctx *types.Context) *types.Context {
+			return ctx
+		}, amount.NewCoinAmount(0, 0), nil},
+		{"refund of the past day", func(gp *Gateway, ctx *types.Context) *types.Context {
+			ctx = nextContext(ctx, 100)
+			execTokenOut(t, gp, ctx, amount.NewCoinAmount(5, 0))
+			return ctx
+		}, amount.NewCoinAmount(5, 0), nil},
+		{"completed batch", func(gp *Gateway, ctx *types.Context) *types.Context {
+			tx := &CompleteBatch{From_: testAdmin, BatchNumber: 1, ERC20TXID: hash.Hash([]byte("payout"))}
+			if err := tx.Execute(gp, types.NewContextWrapper(gp.ID(), ctx), 0); err != nil {
+				t.Fatal(err)
+			}
+			return ctx
+		}, nil, ErrProcessedOutTXID},
+	}
+	for _, tt := range tests {
+		gp, ctx := newTestGateway(t)
+		CoinTXID := execTokenOut(t, gp, ctx, amount.NewCoinAmount(10, 0))
+		tx := &TokenLeave{
+			From_:    testAdmin,
+			CoinTXID: CoinTXID,
+		}
+		if err := tx.Validate(gp, types.NewContextWrapper(gp.ID(), ctx), []common.PublicHash{testAdminKey}); err != ErrNotSealedBatch {
+			t.Errorf("%s: opened batch: got %v, want %v", tt.name, err, ErrNotSealedBatch)
+		}
+
+		ctx = nextContext(ctx, 10)
+		if err := gp.AfterExecuteTransactions(nil, types.NewContextWrapper(gp.ID(), ctx)); err != nil {
+			t.Fatal(err)
+		}
+		ctx = tt.setup(gp, ctx)
+		ctw := types.NewContextWrapper(gp.ID(), ctx)
+		before := gp.vault.Balance(ctw, testUser)
+		if err := tx.Validate(gp, ctw, []common.PublicHash{testAdminKey}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+		if tt.err != nil {
+			continue
+		}
+		if err := tx.Execute(gp, ctw, 0); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if am := gp.vault.Balance(ctw, testUser).Sub(before); !am.Equal(amount.NewCoinAmount(10, 0)) {
+			t.Errorf("%s: refunded: got %v, want 10", tt.name, am)
+		}
+		if am := gp.DailyWithdrawal(ctw, testUser); !am.Equal(tt.daily) {
+			t.Errorf("%s: daily withdrawal: got %v, want %v", tt.name, am, tt.daily)
+		}
+		if am := gp.GlobalDailyWithdrawal(ctw); !am.Equal(tt.daily) {
+			t.Errorf("%s: global daily withdrawal: got %v, want %v", tt.name, am, tt.daily)
+		}
+		wb, err := gp.Batch(ctw, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(wb.PayoutItems()) != 0 {
+			t.Errorf("%s: got %d payout items, want 0", tt.name, len(wb.PayoutItems()))
+		}
+		if err := tx.Validate(gp, ctw, []common.PublicHash{testAdminKey}); err != ErrProcessedOutTXID {
+			t.Errorf("%s: refund again: got %v, want %v", tt.name, err, ErrProcessedOutTXID)
+		}
+	}
+}
+
+// execTokenOut executes the TokenOut of the user and returns the id of the transaction
+func execTokenOut(t *testing.T, gp *Gateway, ctx *types.Context, am *amount.Amount) string {
+	t.Helper()
+	tx := &TokenOut{From_: testUser, Amount: am}
+	if err := tx.Execute(gp, types.NewContextWrapper(gp.ID(), ctx), 0); err != nil {
+		t.Fatal(err)
+	}
+	return types.TransactionID(ctx.TargetHeight(), 0)
+}
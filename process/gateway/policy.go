@@ -8,8 +8,54 @@ import (
 )
 
 // Policy defines a policy of gateway
+// When WithdrawFeeRatio1000 is zero, WithdrawFee is charged as a flat fee
+// Otherwise the ratio of the amount is charged and capped by MinWithdrawFee and MaxWithdrawFee (zero MaxWithdrawFee means no cap)
+// Zero daily limits mean no limit and daily withdrawals are tracked by the day of BlocksPerDay blocks
 type Policy struct {
-	WithdrawFee *amount.Amount
+	WithdrawFee          *amount.Amount
+	BatchInterval        uint32
+	MaxBatchSize         uint16
+	WithdrawFeeRatio1000 uint32
+	MinWithdrawFee       *amount.Amount
+	MaxWithdrawFee       *amount.Amount
+	AddressDailyLimit    *amount.Amount
+	GlobalDailyLimit     *amount.Amount
+	BlocksPerDay         uint32
+}
+
+// Validate checks fields of the policy
+func (pc *Policy) Validate() error {
+	if pc.WithdrawFee == nil || pc.MinWithdrawFee == nil || pc.MaxWithdrawFee == nil {
+		return ErrInvalidPolicy
+	}
+	if pc.AddressDailyLimit == nil || pc.GlobalDailyLimit == nil {
+		return ErrInvalidPolicy
+	}
+	if pc.WithdrawFeeRatio1000 >= 1000 {
+		return ErrInvalidPolicy
+	}
+	if pc.BlocksPerDay == 0 {
+		return ErrInvalidPolicy
+	}
+	if !pc.MaxWithdrawFee.IsZero() && pc.MaxWithdrawFee.Less(pc.MinWithdrawFee) {
+		return ErrInvalidPolicy
+	}
+	return nil
+}
+
+// WithdrawFeeOf returns the withdraw fee of the amount
+func (pc *Policy) WithdrawFeeOf(am *amount.Amount) *amount.Amount {
+	if pc.WithdrawFeeRatio1000 == 0 {
+		return pc.WithdrawFee
+	}
+	fee := am.MulC(int64(pc.WithdrawFeeRatio1000)).DivC(1000)
+	if pc.MinWithdrawFee != nil && fee.Less(pc.MinWithdrawFee) {
+		fee = pc.MinWithdrawFee.Clone()
+	}
+	if pc.MaxWithdrawFee != nil && !pc.MaxWithdrawFee.IsZero() && pc.MaxWithdrawFee.Less(fee) {
+		fee = pc.MaxWithdrawFee.Clone()
+	}
+	return fee
 }
 
 // MarshalJSON is a marshaler function
@@ -22,6 +68,62 @@ func (pc *Policy) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"batch_interval":`)
+	if bs, err := json.Marshal(pc.BatchInterval); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"max_batch_size":`)
+	if bs, err := json.Marshal(pc.MaxBatchSize); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"withdraw_fee_ratio_1000":`)
+	if bs, err := json.Marshal(pc.WithdrawFeeRatio1000); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"min_withdraw_fee":`)
+	if bs, err := json.Marshal(pc.MinWithdrawFee); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"max_withdraw_fee":`)
+	if bs, err := json.Marshal(pc.MaxWithdrawFee); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"address_daily_limit":`)
+	if bs, err := json.Marshal(pc.AddressDailyLimit); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"global_daily_limit":`)
+	if bs, err := json.Marshal(pc.GlobalDailyLimit); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"blocks_per_day":`)
+	if bs, err := json.Marshal(pc.BlocksPerDay); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/hash"
+)
+
+// domains of messages that relayers attest
+const (
+	tokenInDomain         = "fleta.gateway.TokenIn"
+	batchCompletionDomain = "fleta.gateway.CompleteBatch"
+)
+
+// RelayerSet defines relayers that attest token transfers between the chain and the erc20 side
+// Threshold attestations of relayers are required to accept a TokenIn and to complete a withdrawal batch
+type RelayerSet struct {
+	Relayers  []common.PublicHash
+	Threshold uint8
+}
+
+// Validate checks the relayers and the threshold of the relayer set
+func (rs *RelayerSet) Validate() error {
+	if len(rs.Relayers) == 0 || len(rs.Relayers) > 255 {
+		return ErrInvalidRelayerSet
+	}
+	if rs.Threshold == 0 || int(rs.Threshold) > len(rs.Relayers) {
+		return ErrInvalidRelayerSet
+	}
+	relayerMap := map[common.PublicHash]bool{}
+	for _, v := range rs.Relayers {
+		if relayerMap[v] {
+			return ErrInvalidRelayerSet
+		}
+		relayerMap[v] = true
+	}
+	return nil
+}
+
+// ValidateAttestations checks that signatures of the threshold number of distinct relayers are on the hash
+func (rs *RelayerSet) ValidateAttestations(h hash.Hash256, sigs []common.Signature) error {
+	if len(sigs) < int(rs.Threshold) {
+		return ErrInsufficientAttestation
+	}
+	relayerMap := map[common.PublicHash]bool{}
+	for _, v := range rs.Relayers {
+		relayerMap[v] = true
+	}
+	sigMap := map[common.PublicHash]bool{}
+	for _, sig := range sigs {
+		pubkey, err := common.RecoverPubkey(h, sig)
+		if err != nil {
+			return err
+		}
+		pubhash := common.NewPublicHash(pubkey)
+		if !relayerMap[pubhash] {
+			return ErrInvalidAttestation
+		}
+		if sigMap[pubhash] {
+			return ErrDuplicatedAttestation
+		}
+		sigMap[pubhash] = true
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (rs *RelayerSet) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"relayers":[`)
+	for i, pubhash := range rs.Relayers {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := pubhash.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"threshold":`)
+	if bs, err := json.Marshal(rs.Threshold); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
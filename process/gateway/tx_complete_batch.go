@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+)
+
+// CompleteBatch is used to mark the sealed withdrawal batch as paid out on the erc20 side
+type CompleteBatch struct {
+	Timestamp_   uint64
+	From_        common.Address
+	BatchNumber  uint64
+	ERC20TXID    hash.Hash256
+	Attestations []common.Signature
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *CompleteBatch) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *CompleteBatch) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *CompleteBatch) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Gateway)
+
+	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+		return admin.ErrUnauthorizedTransaction
+	}
+
+	wb, err := sp.Batch(loader, tx.BatchNumber)
+	if err != nil {
+		return err
+	}
+	if wb.Status != BatchSealed {
+		return ErrNotSealedBatch
+	}
+	rs, err := sp.RelayerSet(loader)
+	if err != nil {
+		return err
+	}
+	if err := rs.ValidateAttestations(wb.CompletionHash(loader.ChainID(), tx.ERC20TXID), tx.Attestations); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *CompleteBatch) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Gateway)
+
+	wb, err := sp.Batch(ctw, tx.BatchNumber)
+	if err != nil {
+		return err
+	}
+	if wb.Status != BatchSealed {
+		return ErrNotSealedBatch
+	}
+	for _, item := range wb.Items {
+		sp.setOutTXID(ctw, item.CoinTXID)
+	}
+	wb.Status = BatchCompleted
+	wb.ERC20TXID = tx.ERC20TXID
+	return sp.setBatch(ctw, wb)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *CompleteBatch) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"batch_number":`)
+	if bs, err := json.Marshal(tx.BatchNumber); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"erc20_txid":`)
+	if bs, err := tx.ERC20TXID.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"attestations":`)
+	buffer.WriteString(`[`)
+	for i, sig := range tx.Attestations {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := sig.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
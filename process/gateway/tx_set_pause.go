@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+)
+
+// SetPause is used to pause or resume withdrawals of the gateway in an emergency
+// It is not timelocked by the council because it only blocks TokenOut and deposits keep working
+type SetPause struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Paused     bool
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *SetPause) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *SetPause) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *SetPause) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Gateway)
+
+	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+		return admin.ErrUnauthorizedTransaction
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *SetPause) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Gateway)
+	sp.setPaused(ctw, tx.Paused)
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (tx *SetPause) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"paused":`)
+	if bs, err := json.Marshal(tx.Paused); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -8,17 +8,19 @@ import (
 	"github.com/fletaio/fleta_testnet/common/amount"
 	"github.com/fletaio/fleta_testnet/common/hash"
 	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
 	"github.com/fletaio/fleta_testnet/process/admin"
 )
 
 // TokenIn is a TokenIn
 type TokenIn struct {
-	Timestamp_  uint64
-	From_       common.Address
-	ERC20TXID   hash.Hash256
-	ERC20From   ERC20Address
-	ToAddresses []common.Address
-	Amounts     []*amount.Amount
+	Timestamp_   uint64
+	From_        common.Address
+	ERC20TXID    hash.Hash256
+	ERC20From    ERC20Address
+	ToAddresses  []common.Address
+	Amounts      []*amount.Amount
+	Attestations []common.Signature
 }
 
 // Timestamp returns the timestamp of the transaction
@@ -50,6 +52,13 @@ func (tx *TokenIn) Validate(p types.Process, loader types.LoaderWrapper, signers
 	if sp.HasERC20TXID(loader, tx.ERC20TXID) {
 		return ErrProcessedERC20TXID
 	}
+	rs, err := sp.RelayerSet(loader)
+	if err != nil {
+		return err
+	}
+	if err := rs.ValidateAttestations(tx.AttestationHash(loader.ChainID()), tx.Attestations); err != nil {
+		return err
+	}
 
 	for _, To := range tx.ToAddresses {
 		if has, err := loader.HasAccount(To); err != nil {
@@ -87,6 +96,26 @@ func (tx *TokenIn) Execute(p types.Process, ctw *types.ContextWrapper, index uin
 	return nil
 }
 
+// AttestationHash returns the hash of the deposit that relayers sign
+// it is prefixed by the domain and the chain id so the attestation cannot be replayed on other messages or chains
+func (tx *TokenIn) AttestationHash(ChainID uint8) hash.Hash256 {
+	return encoding.Hash(struct {
+		Domain      string
+		ChainID     uint8
+		ERC20TXID   hash.Hash256
+		ERC20From   ERC20Address
+		ToAddresses []common.Address
+		Amounts     []*amount.Amount
+	}{
+		Domain:      tokenInDomain,
+		ChainID:     ChainID,
+		ERC20TXID:   tx.ERC20TXID,
+		ERC20From:   tx.ERC20From,
+		ToAddresses: tx.ToAddresses,
+		Amounts:     tx.Amounts,
+	})
+}
+
 // MarshalJSON is a marshaler function
 func (tx *TokenIn) MarshalJSON() ([]byte, error) {
 	var buffer bytes.Buffer
@@ -146,6 +175,20 @@ func (tx *TokenIn) MarshalJSON() ([]byte, error) {
 		}
 	}
 	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"attestations":`)
+	buffer.WriteString(`[`)
+	for i, sig := range tx.Attestations {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := sig.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
@@ -5,21 +5,17 @@ import (
 	"encoding/json"
 
 	"github.com/fletaio/fleta_testnet/common"
-	"github.com/fletaio/fleta_testnet/common/amount"
-	"github.com/fletaio/fleta_testnet/common/hash"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/process/admin"
 )
 
 // TokenLeave is a TokenLeave
+// It refunds the TokenOut request of the sealed withdrawal batch that is not paid out on the erc20 side
+// The amount and the sender of the refund are taken from the withdrawal item of the batch
 type TokenLeave struct {
 	Timestamp_ uint64
 	From_      common.Address
 	CoinTXID   string
-	CoinFrom   common.Address
-	ERC20TXID  hash.Hash256
-	ERC20To    ERC20Address
-	Amount     *amount.Amount
 }
 
 // Timestamp returns the timestamp of the transaction
@@ -39,9 +35,6 @@ func (tx *TokenLeave) Validate(p types.Process, loader types.LoaderWrapper, sign
 	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
 		return admin.ErrUnauthorizedTransaction
 	}
-	if tx.Amount.Less(amount.COIN.DivC(10)) {
-		return types.ErrDustAmount
-	}
 	if _, _, err := types.ParseTransactionID(tx.CoinTXID); err != nil {
 		return err
 	}
@@ -49,6 +42,9 @@ func (tx *TokenLeave) Validate(p types.Process, loader types.LoaderWrapper, sign
 	if sp.HasOutTXID(loader, tx.CoinTXID) {
 		return ErrProcessedOutTXID
 	}
+	if _, _, err := sp.unpaidWithdrawal(loader, tx.CoinTXID); err != nil {
+		return err
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
@@ -63,8 +59,29 @@ func (tx *TokenLeave) Validate(p types.Process, loader types.LoaderWrapper, sign
 // Execute updates the context by the transaction
 func (tx *TokenLeave) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	sp := p.(*Gateway)
+
+	Height, _, err := types.ParseTransactionID(tx.CoinTXID)
+	if err != nil {
+		return err
+	}
+	if sp.HasOutTXID(ctw, tx.CoinTXID) {
+		return ErrProcessedOutTXID
+	}
+	wb, item, err := sp.unpaidWithdrawal(ctw, tx.CoinTXID)
+	if err != nil {
+		return err
+	}
+	if err := sp.vault.SubBalance(ctw, tx.From(), item.Amount); err != nil {
+		return err
+	}
+	if err := sp.vault.AddBalance(ctw, item.CoinFrom, item.Amount); err != nil {
+		return err
+	}
+	sp.subDailyWithdrawal(ctw, item.CoinFrom, item.Amount, Height)
 	sp.setOutTXID(ctw, tx.CoinTXID)
-	return nil
+
+	wb.Refunded = append(wb.Refunded, tx.CoinTXID)
+	return sp.setBatch(ctw, wb)
 }
 
 // MarshalJSON is a marshaler function
@@ -91,34 +108,6 @@ func (tx *TokenLeave) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
-	buffer.WriteString(`,`)
-	buffer.WriteString(`"coin_from":`)
-	if bs, err := tx.CoinFrom.MarshalJSON(); err != nil {
-		return nil, err
-	} else {
-		buffer.Write(bs)
-	}
-	buffer.WriteString(`,`)
-	buffer.WriteString(`"erc20_txid":`)
-	if bs, err := tx.ERC20TXID.MarshalJSON(); err != nil {
-		return nil, err
-	} else {
-		buffer.Write(bs)
-	}
-	buffer.WriteString(`,`)
-	buffer.WriteString(`"erc20_to":`)
-	if bs, err := tx.ERC20To.MarshalJSON(); err != nil {
-		return nil, err
-	} else {
-		buffer.Write(bs)
-	}
-	buffer.WriteString(`,`)
-	buffer.WriteString(`"amount":`)
-	if bs, err := tx.Amount.MarshalJSON(); err != nil {
-		return nil, err
-	} else {
-		buffer.Write(bs)
-	}
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
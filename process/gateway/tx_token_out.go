@@ -7,7 +7,6 @@ import (
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/amount"
 	"github.com/fletaio/fleta_testnet/core/types"
-	"github.com/fletaio/fleta_testnet/encoding"
 )
 
 // TokenOut is a TokenOut
@@ -41,6 +40,9 @@ func (tx *TokenOut) Validate(p types.Process, loader types.LoaderWrapper, signer
 	if tx.Amount.Less(amount.COIN.DivC(10)) {
 		return types.ErrDustAmount
 	}
+	if sp.IsPaused(loader) {
+		return ErrWithdrawalPaused
+	}
 
 	AdminAddress := sp.admin.AdminAddress(loader, p.Name())
 	if has, err := loader.HasAccount(AdminAddress); err != nil {
@@ -57,12 +59,15 @@ func (tx *TokenOut) Validate(p types.Process, loader types.LoaderWrapper, signer
 		return err
 	}
 
-	policy := &Policy{}
-	if err := encoding.Unmarshal(loader.ProcessData(tagPolicy), &policy); err != nil {
+	policy, err := sp.Policy(loader)
+	if err != nil {
+		return err
+	}
+	if err := sp.checkDailyLimit(loader, policy, tx.From(), tx.Amount); err != nil {
 		return err
 	}
 
-	if err := sp.vault.CheckFeePayableWith(p, loader, tx, tx.Amount.Add(policy.WithdrawFee)); err != nil {
+	if err := sp.vault.CheckFeePayableWith(p, loader, tx, tx.Amount.Add(policy.WithdrawFeeOf(tx.Amount))); err != nil {
 		return err
 	}
 	return nil
@@ -73,24 +78,34 @@ func (tx *TokenOut) Execute(p types.Process, ctw *types.ContextWrapper, index ui
 	sp := p.(*Gateway)
 
 	return sp.vault.WithFee(p, ctw, tx, func() error {
-		policy := &Policy{}
-		if err := encoding.Unmarshal(ctw.ProcessData(tagPolicy), &policy); err != nil {
+		policy, err := sp.Policy(ctw)
+		if err != nil {
+			return err
+		}
+		if err := sp.checkDailyLimit(ctw, policy, tx.From(), tx.Amount); err != nil {
 			return err
 		}
-		if err := sp.vault.SubBalance(ctw, tx.From(), policy.WithdrawFee); err != nil {
+		WithdrawFee := policy.WithdrawFeeOf(tx.Amount)
+		if err := sp.vault.SubBalance(ctw, tx.From(), WithdrawFee); err != nil {
 			return err
 		}
 		if err := sp.vault.SubBalance(ctw, tx.From(), tx.Amount); err != nil {
 			return err
 		}
 		AdminAddress := sp.admin.AdminAddress(ctw, p.Name())
-		if err := sp.vault.AddBalance(ctw, AdminAddress, policy.WithdrawFee); err != nil {
+		if err := sp.vault.AddBalance(ctw, AdminAddress, WithdrawFee); err != nil {
 			return err
 		}
 		if err := sp.vault.AddBalance(ctw, AdminAddress, tx.Amount); err != nil {
 			return err
 		}
-		return nil
+		sp.addDailyWithdrawal(ctw, tx.From(), tx.Amount)
+		return sp.addWithdrawal(ctw, &WithdrawalItem{
+			CoinTXID: types.TransactionID(ctw.TargetHeight(), index),
+			CoinFrom: tx.From(),
+			ERC20To:  tx.ERC20To,
+			Amount:   tx.Amount,
+		})
 	})
 }
 
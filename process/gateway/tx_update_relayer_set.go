@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+)
+
+// UpdateRelayerSet is used to update relayers of the gateway
+type UpdateRelayerSet struct {
+	Timestamp_ uint64
+	From_      common.Address
+	RelayerSet *RelayerSet
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *UpdateRelayerSet) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *UpdateRelayerSet) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *UpdateRelayerSet) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Gateway)
+
+	if !sp.admin.IsPolicyAdmin(loader, p.Name(), tx.From()) {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if err := tx.ValidateParams(p, loader); err != nil {
+		return err
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateParams validates parameters of the transaction apart from its signers
+func (tx *UpdateRelayerSet) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	if tx.RelayerSet == nil {
+		return ErrInvalidRelayerSet
+	}
+	if err := tx.RelayerSet.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *UpdateRelayerSet) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Gateway)
+
+	if tx.RelayerSet == nil {
+		return ErrInvalidRelayerSet
+	}
+	return sp.setRelayerSet(ctw, tx.RelayerSet)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *UpdateRelayerSet) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"relayer_set":`)
+	if bs, err := tx.RelayerSet.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
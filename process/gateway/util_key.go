@@ -1,14 +1,21 @@
 package gateway
 
 import (
+	"github.com/fletaio/fleta_testnet/common/binutil"
 	"github.com/fletaio/fleta_testnet/common/hash"
 )
 
 // tags
 var (
-	tagERC20TXID = []byte{1, 0}
-	tagOutTXID   = []byte{1, 1}
-	tagPolicy    = []byte{2, 0}
+	tagERC20TXID       = []byte{1, 0}
+	tagOutTXID         = []byte{1, 1}
+	tagPolicy          = []byte{2, 0}
+	tagRelayerSet      = []byte{3, 0}
+	tagBatchNumber     = []byte{4, 0}
+	tagBatch           = []byte{4, 1}
+	tagOutBatchNumber  = []byte{4, 2}
+	tagDailyWithdrawal = []byte{5, 0}
+	tagPaused          = []byte{6, 0}
 )
 
 func toERC20TXIDKey(h hash.Hash256) []byte {
@@ -24,3 +31,17 @@ func toOutTXIDKey(TXID string) []byte {
 	copy(bs[2:], []byte(TXID))
 	return bs
 }
+
+func toBatchKey(num uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagBatch)
+	binutil.BigEndian.PutUint64(bs[2:], num)
+	return bs
+}
+
+func toOutBatchNumberKey(TXID string) []byte {
+	bs := make([]byte, 2+len(TXID))
+	copy(bs, tagOutBatchNumber)
+	copy(bs[2:], []byte(TXID))
+	return bs
+}
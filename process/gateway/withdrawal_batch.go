@@ -0,0 +1,189 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/encoding"
+)
+
+// BatchStatus is a status of the withdrawal batch
+type BatchStatus uint8
+
+// batch statuses
+const (
+	BatchOpened    = BatchStatus(1)
+	BatchSealed    = BatchStatus(2)
+	BatchCompleted = BatchStatus(3)
+)
+
+// WithdrawalItem is a TokenOut request included in the withdrawal batch
+type WithdrawalItem struct {
+	CoinTXID string
+	CoinFrom common.Address
+	ERC20To  ERC20Address
+	Amount   *amount.Amount
+}
+
+// WithdrawalBatch is a numbered group of TokenOut requests that relayers pay out together
+type WithdrawalBatch struct {
+	Number       uint64
+	Items        []*WithdrawalItem
+	OpenedHeight uint32
+	SealedHeight uint32
+	Hash         hash.Hash256
+	Status       BatchStatus
+	ERC20TXID    hash.Hash256
+	Refunded     []string
+}
+
+// ItemsHash returns the deterministic hash of the number and the items of the batch
+func (wb *WithdrawalBatch) ItemsHash() hash.Hash256 {
+	return encoding.Hash(struct {
+		Number uint64
+		Items  []*WithdrawalItem
+	}{
+		Number: wb.Number,
+		Items:  wb.Items,
+	})
+}
+
+// PayoutItems returns items of the batch that are not refunded
+func (wb *WithdrawalBatch) PayoutItems() []*WithdrawalItem {
+	refundMap := map[string]bool{}
+	for _, TXID := range wb.Refunded {
+		refundMap[TXID] = true
+	}
+	items := make([]*WithdrawalItem, 0, len(wb.Items))
+	for _, item := range wb.Items {
+		if !refundMap[item.CoinTXID] {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// CompletionHash returns the hash that relayers sign to attest the payout of the batch
+// it is prefixed by the domain and the chain id and refunded items are included so the attestation covers only items that are paid out
+func (wb *WithdrawalBatch) CompletionHash(ChainID uint8, ERC20TXID hash.Hash256) hash.Hash256 {
+	return encoding.Hash(struct {
+		Domain    string
+		ChainID   uint8
+		Hash      hash.Hash256
+		Refunded  []string
+		ERC20TXID hash.Hash256
+	}{
+		Domain:    batchCompletionDomain,
+		ChainID:   ChainID,
+		Hash:      wb.Hash,
+		Refunded:  wb.Refunded,
+		ERC20TXID: ERC20TXID,
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (wb *WithdrawalBatch) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"number":`)
+	if bs, err := json.Marshal(wb.Number); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"items":[`)
+	for i, item := range wb.Items {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := item.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"opened_height":`)
+	if bs, err := json.Marshal(wb.OpenedHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"sealed_height":`)
+	if bs, err := json.Marshal(wb.SealedHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"hash":`)
+	if bs, err := wb.Hash.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"status":`)
+	if bs, err := json.Marshal(wb.Status); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"erc20_txid":`)
+	if bs, err := wb.ERC20TXID.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"refunded":`)
+	if bs, err := json.Marshal(wb.Refunded); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// MarshalJSON is a marshaler function
+func (item *WithdrawalItem) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"coin_txid":`)
+	if bs, err := json.Marshal(item.CoinTXID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"coin_from":`)
+	if bs, err := item.CoinFrom.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"erc20_to":`)
+	if bs, err := item.ERC20To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := item.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
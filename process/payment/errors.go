@@ -14,4 +14,16 @@ var (
 	ErrExistSubscribe         = errors.New("exist subscribe")
 	ErrNotExistSubscribe      = errors.New("not exist subscribe")
 	ErrInvalidBillingAmount   = errors.New("invalid billing amount")
+	ErrInvalidBillingPeriod   = errors.New("invalid billing period")
+	ErrNotDueBilling          = errors.New("not due billing")
+	ErrInvalidExpiryHeight    = errors.New("invalid expiry height")
+	ErrExpiredRequestPayment  = errors.New("expired request payment")
+	ErrNotExistEscrow         = errors.New("not exist escrow")
+	ErrEscrowRefunded         = errors.New("escrow refunded")
+	ErrInvalidEscrowBlocks    = errors.New("invalid escrow blocks")
+	ErrInvalidTopicOwner      = errors.New("invalid topic owner")
+	ErrSameTopicOwner         = errors.New("same topic owner")
+	ErrIndexedTopic           = errors.New("indexed topic")
+	ErrIndexedSubscribe       = errors.New("indexed subscribe")
+	ErrInvalidSubscriber      = errors.New("invalid subscriber")
 )
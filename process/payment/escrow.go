@@ -0,0 +1,61 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+)
+
+// Escrow is an accepted payment that is locked in the payer's locked balance until the delivery is confirmed
+// If the delivery is not confirmed until RefundHeight, the locked balance is unlocked to the payer
+type Escrow struct {
+	Topic        uint64
+	Payer        common.Address
+	Payee        common.Address
+	Amount       *amount.Amount
+	RefundHeight uint32
+}
+
+// MarshalJSON is a marshaler function
+func (es *Escrow) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"topic":`)
+	if bs, err := json.Marshal(es.Topic); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payer":`)
+	if bs, err := es.Payer.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payee":`)
+	if bs, err := es.Payee.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := es.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"refund_height":`)
+	if bs, err := json.Marshal(es.RefundHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
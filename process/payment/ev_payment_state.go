@@ -0,0 +1,109 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+)
+
+// PaymentState is a state of the payment request
+type PaymentState uint8
+
+// payment states
+const (
+	PaymentPaid     = PaymentState(1)
+	PaymentRejected = PaymentState(2)
+	PaymentExpired  = PaymentState(3)
+	PaymentEscrowed = PaymentState(4)
+	PaymentReleased = PaymentState(5)
+	PaymentRefunded = PaymentState(6)
+)
+
+// PaymentStateEvent is emitted when the payment request moves to the state
+type PaymentStateEvent struct {
+	Height_ uint32
+	Index_  uint16
+	N_      uint16
+	TXID    string
+	State   PaymentState
+	Payer   common.Address
+	Amount  *amount.Amount
+}
+
+// Height returns the height of the event
+func (ev *PaymentStateEvent) Height() uint32 {
+	return ev.Height_
+}
+
+// Index returns the index of the event
+func (ev *PaymentStateEvent) Index() uint16 {
+	return ev.Index_
+}
+
+// N returns the n of the event
+func (ev *PaymentStateEvent) N() uint16 {
+	return ev.N_
+}
+
+// SetN updates the n of the event
+func (ev *PaymentStateEvent) SetN(n uint16) {
+	ev.N_ = n
+}
+
+// MarshalJSON is a marshaler function
+func (ev *PaymentStateEvent) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"height":`)
+	if bs, err := json.Marshal(ev.Height_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"index":`)
+	if bs, err := json.Marshal(ev.Index_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"n":`)
+	if bs, err := json.Marshal(ev.N_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"txid":`)
+	if bs, err := json.Marshal(ev.TXID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"state":`)
+	if bs, err := json.Marshal(ev.State); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payer":`)
+	if bs, err := ev.Payer.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := ev.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -1,9 +1,11 @@
 package payment
 
 import (
+	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/process/admin"
 	"github.com/fletaio/fleta_testnet/process/vault"
+	"github.com/fletaio/fleta_testnet/service/apiserver"
 )
 
 // Payment manages balance of accounts of the chain
@@ -66,6 +68,112 @@ func (p *Payment) Init(reg *types.Register, pm types.ProcessManager, cn types.Pr
 	reg.RegisterTransaction(5, &Subscribe{})
 	reg.RegisterTransaction(6, &Unsubscribe{})
 	reg.RegisterTransaction(7, &Billing{})
+	reg.RegisterTransaction(8, &ConfirmDelivery{})
+	reg.RegisterTransaction(9, &TransferTopic{})
+	reg.RegisterTransaction(10, &UpdateTopic{})
+	reg.RegisterTransaction(11, &IndexTopic{})
+	reg.RegisterTransaction(12, &IndexSubscribe{})
+
+	reg.RegisterEvent(1, &ChargedEvent{})
+	reg.RegisterEvent(2, &ChargeFailedEvent{})
+	reg.RegisterEvent(3, &PaymentStateEvent{})
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		s, err := v.JRPC("payment")
+		if err != nil {
+			return err
+		}
+		s.Set("topics", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Topics(loader)
+		})
+		s.Set("topic", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			name, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			ti, err := p.TopicInfo(loader, Topic(name))
+			if err != nil {
+				return nil, err
+			}
+			return &TopicItem{
+				Topic:           Topic(name),
+				Info:            ti,
+				SubscriberCount: p.SubscriberCount(loader, Topic(name)),
+			}, nil
+		})
+		s.Set("subscription", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 2 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			name, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			arg1, err := arg.String(1)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg1)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Subscription(loader, Topic(name), addr)
+		})
+		s.Set("subscribers", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			name, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Subscribers(loader, Topic(name)), nil
+		})
+		s.Set("dueSubscribers", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			name, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.DueSubscribers(loader, Topic(name))
+		})
+		s.Set("requestPayment", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TXID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.RequestPayment(loader, TXID)
+		})
+		s.Set("escrow", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			TXID, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.Escrow(loader, TXID)
+		})
+	}
 	return nil
 }
 
@@ -94,6 +202,56 @@ func (p *Payment) BeforeExecuteTransactions(ctw *types.ContextWrapper) error {
 
 // AfterExecuteTransactions called after processes transactions of the block
 func (p *Payment) AfterExecuteTransactions(b *types.Block, ctw *types.ContextWrapper) error {
+	TXIDs, err := p.flushTXIDs(ctw, toRequestExpiryKey(b.Header.Height))
+	if err != nil {
+		return err
+	}
+	for _, TXID := range TXIDs {
+		req, err := p.getRequestPayment(ctw, TXID)
+		if err != nil {
+			if err == ErrNotExistRequestPayment {
+				continue
+			}
+			return err
+		}
+		p.removeRequestPayment(ctw, TXID)
+		if err := ctw.EmitEvent(&PaymentStateEvent{
+			Height_: b.Header.Height,
+			Index_:  65535,
+			TXID:    TXID,
+			State:   PaymentExpired,
+			Payer:   req.To,
+			Amount:  req.Amount,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// the vault unlocks the escrowed amount to the payer at the refund height
+	TXIDs, err = p.flushTXIDs(ctw, toEscrowRefundKey(b.Header.Height))
+	if err != nil {
+		return err
+	}
+	for _, TXID := range TXIDs {
+		es, err := p.Escrow(ctw, TXID)
+		if err != nil {
+			if err == ErrNotExistEscrow {
+				continue
+			}
+			return err
+		}
+		p.removeEscrow(ctw, TXID)
+		if err := ctw.EmitEvent(&PaymentStateEvent{
+			Height_: b.Header.Height,
+			Index_:  65535,
+			TXID:    TXID,
+			State:   PaymentRefunded,
+			Payer:   es.Payer,
+			Amount:  es.Amount,
+		}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package payment
 import (
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/binutil"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/encoding"
 )
@@ -25,6 +26,11 @@ func (p *Payment) addRequestPayment(ctw *types.ContextWrapper, TXID string, tx *
 		return err
 	}
 	ctw.SetProcessData(toRequestPaymentKey(TXID), body)
+	if tx.ExpiryHeight > 0 {
+		if err := p.appendTXID(ctw, toRequestExpiryKey(tx.ExpiryHeight), TXID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -32,6 +38,69 @@ func (p *Payment) removeRequestPayment(ctw *types.ContextWrapper, TXID string) {
 	ctw.SetProcessData(toRequestPaymentKey(TXID), nil)
 }
 
+// RequestPayment returns the pending payment request of the TXID
+func (p *Payment) RequestPayment(loader types.Loader, TXID string) (*RequestPayment, error) {
+	return p.getRequestPayment(types.NewLoaderWrapper(p.pid, loader), TXID)
+}
+
+// Escrow returns the escrow of the accepted payment request
+func (p *Payment) Escrow(loader types.Loader, TXID string) (*Escrow, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(toEscrowKey(TXID)); len(bs) > 0 {
+		es := &Escrow{}
+		if err := encoding.Unmarshal(bs, &es); err != nil {
+			return nil, err
+		}
+		return es, nil
+	} else {
+		return nil, ErrNotExistEscrow
+	}
+}
+
+func (p *Payment) addEscrow(ctw *types.ContextWrapper, TXID string, es *Escrow) error {
+	body, err := encoding.Marshal(es)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toEscrowKey(TXID), body)
+	if err := p.appendTXID(ctw, toEscrowRefundKey(es.RefundHeight), TXID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *Payment) removeEscrow(ctw *types.ContextWrapper, TXID string) {
+	ctw.SetProcessData(toEscrowKey(TXID), nil)
+}
+
+// appendTXID adds the TXID to the height index of the key
+// removed entries are not taken out of the index, they are skipped when the index is flushed
+func (p *Payment) appendTXID(ctw *types.ContextWrapper, key []byte, TXID string) error {
+	TXIDs, err := p.flushTXIDs(ctw, key)
+	if err != nil {
+		return err
+	}
+	TXIDs = append(TXIDs, TXID)
+	body, err := encoding.Marshal(TXIDs)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(key, body)
+	return nil
+}
+
+func (p *Payment) flushTXIDs(ctw *types.ContextWrapper, key []byte) ([]string, error) {
+	TXIDs := []string{}
+	if bs := ctw.ProcessData(key); len(bs) > 0 {
+		if err := encoding.Unmarshal(bs, &TXIDs); err != nil {
+			return nil, err
+		}
+		ctw.SetProcessData(key, nil)
+	}
+	return TXIDs, nil
+}
+
 // GetTopicName returns the topic name of the topic
 func (p *Payment) GetTopicName(loader types.Loader, topic uint64) (string, error) {
 	lw := types.NewLoaderWrapper(p.pid, loader)
@@ -43,35 +112,251 @@ func (p *Payment) GetTopicName(loader types.Loader, topic uint64) (string, error
 	}
 }
 
+// TopicInfo returns the metadata of the topic
+// topics that are added without the metadata are owned by the admin of the process
+func (p *Payment) TopicInfo(loader types.Loader, topic uint64) (*TopicInfo, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Name, err := p.GetTopicName(lw, topic)
+	if err != nil {
+		return nil, err
+	}
+	if bs := lw.ProcessData(toTopicInfoKey(topic)); len(bs) > 0 {
+		ti := &TopicInfo{}
+		if err := encoding.Unmarshal(bs, &ti); err != nil {
+			return nil, err
+		}
+		return ti, nil
+	}
+	adminAddr := p.admin.AdminAddress(lw, p.Name())
+	return &TopicInfo{
+		Name:   Name,
+		Owner:  adminAddr,
+		Payee:  adminAddr,
+		Price:  amount.NewCoinAmount(0, 0),
+		Period: 0,
+	}, nil
+}
+
+func (p *Payment) setTopicInfo(ctw *types.ContextWrapper, topic uint64, ti *TopicInfo) error {
+	body, err := encoding.Marshal(ti)
+	if err != nil {
+		return err
+	}
+	ctw.SetProcessData(toTopicInfoKey(topic), body)
+	return nil
+}
+
 func (p *Payment) addTopic(ctw *types.ContextWrapper, topic uint64, Name string) error {
 	if bs := ctw.ProcessData(toTopicKey(topic)); len(bs) > 0 {
 		return ErrExistTopic
 	}
 	ctw.SetProcessData(toTopicKey(topic), []byte(Name))
+	p.indexTopic(ctw, topic)
 	return nil
 }
 
+// isIndexedTopic returns the topic is listed by Topics or not
+// topics that are added before the topic list are not listed until they are indexed by IndexTopic
+func (p *Payment) isIndexedTopic(loader types.Loader, topic uint64) bool {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return len(lw.ProcessData(toTopicReverseKey(topic))) > 0
+}
+
+func (p *Payment) indexTopic(ctw *types.ContextWrapper, topic uint64) {
+	Count := p.TopicCount(ctw)
+	ctw.SetProcessData(toTopicNumberKey(Count), binutil.LittleEndian.Uint64ToBytes(topic))
+	ctw.SetProcessData(toTopicReverseKey(topic), binutil.LittleEndian.Uint32ToBytes(Count))
+	ctw.SetProcessData(tagTopicCount, binutil.LittleEndian.Uint32ToBytes(Count+1))
+}
+
 func (p *Payment) removeTopic(ctw *types.ContextWrapper, topic uint64) {
 	ctw.SetProcessData(toTopicKey(topic), nil)
+	ctw.SetProcessData(toTopicInfoKey(topic), nil)
+
+	bs := ctw.ProcessData(toTopicReverseKey(topic))
+	if len(bs) == 0 {
+		return
+	}
+	num := binutil.LittleEndian.Uint32(bs)
+	Count := p.TopicCount(ctw)
+	if num != Count-1 {
+		last := binutil.LittleEndian.Uint64(ctw.ProcessData(toTopicNumberKey(Count - 1)))
+		ctw.SetProcessData(toTopicNumberKey(num), binutil.LittleEndian.Uint64ToBytes(last))
+		ctw.SetProcessData(toTopicReverseKey(last), binutil.LittleEndian.Uint32ToBytes(num))
+	}
+	ctw.SetProcessData(toTopicNumberKey(Count-1), nil)
+	ctw.SetProcessData(toTopicReverseKey(topic), nil)
+	if Count > 1 {
+		ctw.SetProcessData(tagTopicCount, binutil.LittleEndian.Uint32ToBytes(Count-1))
+	} else {
+		ctw.SetProcessData(tagTopicCount, nil)
+	}
 }
 
-func (p *Payment) getSubscribe(lw types.LoaderWrapper, topic uint64, addr common.Address) (*amount.Amount, error) {
+// TopicCount returns the number of topics
+func (p *Payment) TopicCount(loader types.Loader) uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(tagTopicCount); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	} else {
+		return 0
+	}
+}
+
+// Topics returns topics with their metadata and the number of subscribers
+func (p *Payment) Topics(loader types.Loader) ([]*TopicItem, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Count := p.TopicCount(lw)
+	list := make([]*TopicItem, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		topic := binutil.LittleEndian.Uint64(lw.ProcessData(toTopicNumberKey(i)))
+		ti, err := p.TopicInfo(lw, topic)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &TopicItem{
+			Topic:           topic,
+			Info:            ti,
+			SubscriberCount: p.SubscriberCount(lw, topic),
+		})
+	}
+	return list, nil
+}
+
+// Subscription returns the subscription of the address to the topic
+// subscriptions that are added before the subscriber list are stored as the amount so they are returned without the period
+func (p *Payment) Subscription(loader types.Loader, topic uint64, addr common.Address) (*Subscription, error) {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
 	if bs := lw.AccountData(addr, toTopicKey(topic)); len(bs) > 0 {
-		am := amount.NewAmountFromBytes(bs)
-		return am, nil
+		if !p.isIndexedSubscribe(lw, topic, addr) {
+			return &Subscription{
+				Amount: amount.NewAmountFromBytes(bs),
+			}, nil
+		}
+		sub := &Subscription{}
+		if err := encoding.Unmarshal(bs, &sub); err != nil {
+			return nil, err
+		}
+		return sub, nil
 	} else {
 		return nil, ErrNotExistSubscribe
 	}
 }
 
-func (p *Payment) addSubscribe(ctw *types.ContextWrapper, topic uint64, addr common.Address, am *amount.Amount) error {
+func (p *Payment) setSubscription(ctw *types.ContextWrapper, topic uint64, addr common.Address, sub *Subscription) error {
+	body, err := encoding.Marshal(sub)
+	if err != nil {
+		return err
+	}
+	ctw.SetAccountData(addr, toTopicKey(topic), body)
+	return nil
+}
+
+func (p *Payment) addSubscribe(ctw *types.ContextWrapper, topic uint64, addr common.Address, sub *Subscription) error {
 	if bs := ctw.AccountData(addr, toTopicKey(topic)); len(bs) > 0 {
 		return ErrExistSubscribe
 	}
-	ctw.SetAccountData(addr, toTopicKey(topic), am.Bytes())
+	if err := p.setSubscription(ctw, topic, addr, sub); err != nil {
+		return err
+	}
+	p.indexSubscribe(ctw, topic, addr)
+	return nil
+}
+
+// isIndexedSubscribe returns the subscription is listed by Subscribers or not
+// subscriptions that are added before the subscriber list are not listed until they are indexed by IndexSubscribe
+func (p *Payment) isIndexedSubscribe(loader types.Loader, topic uint64, addr common.Address) bool {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	return len(lw.ProcessData(toSubscriberReverseKey(topic, addr))) > 0
+}
+
+func (p *Payment) indexSubscribe(ctw *types.ContextWrapper, topic uint64, addr common.Address) {
+	Count := p.SubscriberCount(ctw, topic)
+	ctw.SetProcessData(toSubscriberNumberKey(topic, Count), addr[:])
+	ctw.SetProcessData(toSubscriberReverseKey(topic, addr), binutil.LittleEndian.Uint32ToBytes(Count))
+	ctw.SetProcessData(toSubscriberCountKey(topic), binutil.LittleEndian.Uint32ToBytes(Count+1))
+}
+
+// migrateSubscribe stores the subscription that is added before the subscriber list by the current format and indexes it
+func (p *Payment) migrateSubscribe(ctw *types.ContextWrapper, topic uint64, addr common.Address) error {
+	sub, err := p.Subscription(ctw, topic, addr)
+	if err != nil {
+		return err
+	}
+	if err := p.setSubscription(ctw, topic, addr, sub); err != nil {
+		return err
+	}
+	p.indexSubscribe(ctw, topic, addr)
 	return nil
 }
 
 func (p *Payment) removeSubscribe(ctw *types.ContextWrapper, topic uint64, addr common.Address) {
 	ctw.SetAccountData(addr, toTopicKey(topic), nil)
+
+	bs := ctw.ProcessData(toSubscriberReverseKey(topic, addr))
+	if len(bs) == 0 {
+		return
+	}
+	num := binutil.LittleEndian.Uint32(bs)
+	Count := p.SubscriberCount(ctw, topic)
+	if num != Count-1 {
+		lastBs := ctw.ProcessData(toSubscriberNumberKey(topic, Count-1))
+		var lastAddr common.Address
+		copy(lastAddr[:], lastBs)
+		ctw.SetProcessData(toSubscriberNumberKey(topic, num), lastAddr[:])
+		ctw.SetProcessData(toSubscriberReverseKey(topic, lastAddr), binutil.LittleEndian.Uint32ToBytes(num))
+	}
+	ctw.SetProcessData(toSubscriberNumberKey(topic, Count-1), nil)
+	ctw.SetProcessData(toSubscriberReverseKey(topic, addr), nil)
+	if Count > 1 {
+		ctw.SetProcessData(toSubscriberCountKey(topic), binutil.LittleEndian.Uint32ToBytes(Count-1))
+	} else {
+		ctw.SetProcessData(toSubscriberCountKey(topic), nil)
+	}
+}
+
+// SubscriberCount returns the number of subscribers of the topic
+func (p *Payment) SubscriberCount(loader types.Loader, topic uint64) uint32 {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	if bs := lw.ProcessData(toSubscriberCountKey(topic)); len(bs) > 0 {
+		return binutil.LittleEndian.Uint32(bs)
+	} else {
+		return 0
+	}
+}
+
+// Subscribers returns subscribers of the topic
+func (p *Payment) Subscribers(loader types.Loader, topic uint64) []common.Address {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	Count := p.SubscriberCount(lw, topic)
+	list := make([]common.Address, 0, Count)
+	for i := uint32(0); i < Count; i++ {
+		var addr common.Address
+		copy(addr[:], lw.ProcessData(toSubscriberNumberKey(topic, i)))
+		list = append(list, addr)
+	}
+	return list
+}
+
+// DueSubscribers returns subscribers of the topic that can be billed at the target height
+func (p *Payment) DueSubscribers(loader types.Loader, topic uint64) ([]common.Address, error) {
+	list := []common.Address{}
+	for _, addr := range p.Subscribers(loader, topic) {
+		sub, err := p.Subscription(loader, topic, addr)
+		if err != nil {
+			return nil, err
+		}
+		if sub.IsDue(loader.TargetHeight()) {
+			list = append(list, addr)
+		}
+	}
+	return list, nil
 }
@@ -0,0 +1,337 @@
+package payment
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+	"github.com/fletaio/fleta_testnet/process/processtest"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+var (
+	testAdmin    = common.NewAddress(0, 1, 0)
+	testUser     = common.NewAddress(0, 2, 0)
+	testAdminKey = common.PublicHash{1}
+	testUserKey  = common.PublicHash{2}
+	testTopic    = Topic("test.topic")
+)
+
+// newTestPayment returns the payment and the context of the test at the height 1 that has the topic and the user account of 100 coins
+func newTestPayment(t *testing.T) (*Payment, *types.ContextWrapper) {
+	ap := admin.NewAdmin(1)
+	vp := vault.NewVault(2)
+	pp := NewPayment(3)
+	pm := processtest.NewProcessManager(t, ap, vp, pp)
+	ctx := pm.NewContext(t, &processtest.Fixture{
+		AdminAddressMap: map[string]common.Address{pp.Name(): testAdmin},
+		Accounts: []types.Account{
+			&vault.SingleAccount{Address_: testAdmin, Name_: "admin", KeyHash: testAdminKey},
+			&vault.SingleAccount{Address_: testUser, Name_: "user", KeyHash: testUserKey},
+		},
+		Balances: map[common.Address]*amount.Amount{testUser: amount.NewCoinAmount(100, 0)},
+	})
+	ctw := types.NewContextWrapper(pp.ID(), ctx.NextContext(hash.Hash256{}, 0))
+	if err := vp.InitPolicy(ctw, &vault.Policy{AccountCreationAmount: amount.NewCoinAmount(10, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pp.InitTopics(ctw, []string{"test.topic"}); err != nil {
+		t.Fatal(err)
+	}
+	return pp, ctw
+}
+
+func TestRequestPaymentValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		amount       *amount.Amount
+		expiryHeight uint32
+		escrowBlocks uint32
+		err          error
+	}{
+		{"ok", amount.NewCoinAmount(10, 0), 10, 0, nil},
+		{"maximum escrow", amount.NewCoinAmount(10, 0), 10, MaxEscrowBlocks, nil},
+		{"escrow over the maximum", amount.NewCoinAmount(10, 0), 10, MaxEscrowBlocks + 1, ErrInvalidEscrowBlocks},
+		{"escrow overflow", amount.NewCoinAmount(10, 0), 10, math.MaxUint32, ErrInvalidEscrowBlocks},
+		{"no expiry", amount.NewCoinAmount(10, 0), 0, 0, nil},
+		{"expired", amount.NewCoinAmount(10, 0), 1, 0, ErrInvalidExpiryHeight},
+		{"dust amount", amount.COIN.DivC(100), 10, 0, types.ErrDustAmount},
+	}
+	for _, tt := range tests {
+		pp, ctw := newTestPayment(t)
+		tx := &RequestPayment{
+			From_:        testAdmin,
+			Topic:        testTopic,
+			To:           testUser,
+			Amount:       tt.amount,
+			ExpiryHeight: tt.expiryHeight,
+			EscrowBlocks: tt.escrowBlocks,
+		}
+		if err := tx.Validate(pp, ctw, []common.PublicHash{testAdminKey}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestEscrowRelease(t *testing.T) {
+	pp, ctw := newTestPayment(t)
+	req := &RequestPayment{
+		From_:        testAdmin,
+		Topic:        testTopic,
+		To:           testUser,
+		Amount:       amount.NewCoinAmount(10, 0),
+		ExpiryHeight: 10,
+		EscrowBlocks: 5,
+	}
+	if err := req.Execute(pp, ctw, 0); err != nil {
+		t.Fatal(err)
+	}
+	TXID := types.TransactionID(ctw.TargetHeight(), 0)
+
+	res := &ResponsePayment{From_: testUser, TXID: TXID, Amount: req.Amount, IsAccept: true}
+	if err := res.Validate(pp, ctw, []common.PublicHash{testUserKey}); err != nil {
+		t.Fatal(err)
+	}
+	if err := res.Execute(pp, ctw, 1); err != nil {
+		t.Fatal(err)
+	}
+	if am := pp.vault.TotalLockedBalanceByAddress(ctw, testUser); !am.Equal(req.Amount) {
+		t.Errorf("escrowed amount: got %v, want %v", am, req.Amount)
+	}
+
+	tx := &ConfirmDelivery{From_: testAdmin, TXID: TXID}
+	if err := tx.Validate(pp, ctw, []common.PublicHash{testAdminKey}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Execute(pp, ctw, 2); err != nil {
+		t.Fatal(err)
+	}
+	if !pp.vault.TotalLockedBalanceByAddress(ctw, testUser).IsZero() {
+		t.Errorf("locked balance remains after the release")
+	}
+	if list := pp.vault.LockedBalanceSchedule(ctw, testUser); len(list) != 0 {
+		t.Errorf("schedule remains after the release %v", list)
+	}
+	if am := pp.vault.Balance(ctw, testAdmin); !am.Equal(req.Amount) {
+		t.Errorf("balance of the payee: got %v, want %v", am, req.Amount)
+	}
+	if _, err := pp.Escrow(ctw, TXID); err != ErrNotExistEscrow {
+		t.Errorf("escrow: got %v, want %v", err, ErrNotExistEscrow)
+	}
+}
+
+func TestResponsePaymentPayee(t *testing.T) {
+	testPayee := common.NewAddress(1, 3, 0)
+	tests := []struct {
+		name         string
+		expiryHeight uint32
+		escrowBlocks uint32
+	}{
+		{"paid", 10, 0},
+		{"escrowed", 10, 5},
+		{"no expiry", 0, 0},
+	}
+	for _, tt := range tests {
+		pp, ctw := newTestPayment(t)
+		if err := ctw.CreateAccount(&vault.SingleAccount{Address_: testPayee, Name_: "payee"}); err != nil {
+			t.Fatal(err)
+		}
+		at := &AddTopic{
+			From_:     testAdmin,
+			Topic:     Topic("paid.topic"),
+			TopicName: "paid.topic",
+			Owner:     testAdmin,
+			Payee:     testPayee,
+			Price:     amount.NewCoinAmount(0, 0),
+		}
+		if err := at.Execute(pp, ctw, 0); err != nil {
+			t.Fatal(err)
+		}
+		req := &RequestPayment{
+			From_:        testAdmin,
+			Topic:        at.Topic,
+			To:           testUser,
+			Amount:       amount.NewCoinAmount(10, 0),
+			ExpiryHeight: tt.expiryHeight,
+			EscrowBlocks: tt.escrowBlocks,
+		}
+		if err := req.Execute(pp, ctw, 1); err != nil {
+			t.Fatal(err)
+		}
+		TXID := types.TransactionID(ctw.TargetHeight(), 1)
+
+		res := &ResponsePayment{From_: testUser, TXID: TXID, Amount: req.Amount, IsAccept: true}
+		if err := res.Validate(pp, ctw, []common.PublicHash{testUserKey}); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if err := res.Execute(pp, ctw, 2); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if tt.escrowBlocks > 0 {
+			tx := &ConfirmDelivery{From_: testAdmin, TXID: TXID}
+			if err := tx.Validate(pp, ctw, []common.PublicHash{testAdminKey}); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if err := tx.Execute(pp, ctw, 3); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+		}
+		if am := pp.vault.Balance(ctw, testPayee); !am.Equal(req.Amount) {
+			t.Errorf("%s: balance of the payee: got %v, want %v", tt.name, am, req.Amount)
+		}
+		if am := pp.vault.Balance(ctw, testAdmin); !am.IsZero() {
+			t.Errorf("%s: balance of the admin: got %v, want 0", tt.name, am)
+		}
+	}
+}
+
+func TestBillingFee(t *testing.T) {
+	tests := []struct {
+		name    string
+		balance *amount.Amount
+		amount  *amount.Amount
+		charged bool
+		err     error
+	}{
+		{"charged", amount.NewCoinAmount(1, 0), amount.NewCoinAmount(10, 0), true, nil},
+		{"charge failed", amount.NewCoinAmount(1, 0), amount.NewCoinAmount(1000, 0), false, nil},
+		{"insufficient fee", amount.NewCoinAmount(0, 0), amount.NewCoinAmount(10, 0), false, vault.ErrInsufficientFee},
+	}
+	for _, tt := range tests {
+		pp, ctw := newTestPayment(t)
+		if err := pp.vault.AddBalance(ctw, testAdmin, tt.balance); err != nil {
+			t.Fatal(err)
+		}
+		sub := &Subscribe{From_: testUser, Topic: testTopic, Amount: amount.NewCoinAmount(0, 0), Period: 10}
+		if err := sub.Execute(pp, ctw, 0); err != nil {
+			t.Fatal(err)
+		}
+		tx := &Billing{From_: testAdmin, Topic: testTopic, To: testUser, Amount: tt.amount}
+		if err := tx.Validate(pp, ctw, []common.PublicHash{testAdminKey}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+		if tt.err != nil {
+			continue
+		}
+		if err := tx.Execute(pp, ctw, 1); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		want := tt.balance.Sub(pp.vault.GetDefaultFee(ctw))
+		if tt.charged {
+			want = want.Add(tt.amount)
+		}
+		if am := pp.vault.Balance(ctw, testAdmin); !am.Equal(want) {
+			t.Errorf("%s: balance of the owner: got %v, want %v", tt.name, am, want)
+		}
+	}
+}
+
+func TestIndexSubscribe(t *testing.T) {
+	pp, ctw := newTestPayment(t)
+	// the subscription is stored as it was added before the subscriber list
+	ctw.SetAccountData(testUser, toTopicKey(testTopic), amount.NewCoinAmount(5, 0).Bytes())
+	if sub, err := pp.Subscription(ctw, testTopic, testUser); err != nil {
+		t.Fatal(err)
+	} else if !sub.Amount.Equal(amount.NewCoinAmount(5, 0)) || sub.Period != 0 || !sub.IsDue(ctw.TargetHeight()) {
+		t.Errorf("unexpected legacy subscription %v", sub)
+	}
+	if Count := pp.SubscriberCount(ctw, testTopic); Count != 0 {
+		t.Fatalf("got %d subscribers, want 0", Count)
+	}
+
+	tests := []struct {
+		name  string
+		from  common.Address
+		topic uint64
+		addrs []common.Address
+		err   error
+	}{
+		{"not admin", testUser, testTopic, []common.Address{testUser}, admin.ErrUnauthorizedTransaction},
+		{"not exist topic", testAdmin, Topic("unknown.topic"), []common.Address{testUser}, ErrNotExistTopic},
+		{"not exist subscribe", testAdmin, testTopic, []common.Address{testAdmin}, ErrNotExistSubscribe},
+		{"duplicated subscriber", testAdmin, testTopic, []common.Address{testUser, testUser}, ErrInvalidSubscriber},
+		{"no subscriber", testAdmin, testTopic, nil, ErrInvalidSubscriber},
+		{"legacy subscribe", testAdmin, testTopic, []common.Address{testUser}, nil},
+	}
+	for _, tt := range tests {
+		tx := &IndexSubscribe{From_: tt.from, Topic: tt.topic, Subscribers: tt.addrs}
+		key := testAdminKey
+		if tt.from == testUser {
+			key = testUserKey
+		}
+		if err := tx.Validate(pp, ctw, []common.PublicHash{key}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	tx := &IndexSubscribe{From_: testAdmin, Topic: testTopic, Subscribers: []common.Address{testUser}}
+	if err := tx.Execute(pp, ctw, 0); err != nil {
+		t.Fatal(err)
+	}
+	if list := pp.Subscribers(ctw, testTopic); len(list) != 1 || list[0] != testUser {
+		t.Errorf("unexpected subscribers %v", list)
+	}
+	if sub, err := pp.Subscription(ctw, testTopic, testUser); err != nil {
+		t.Fatal(err)
+	} else if !sub.Amount.Equal(amount.NewCoinAmount(5, 0)) || sub.Period != 0 {
+		t.Errorf("unexpected indexed subscription %v", sub)
+	}
+	if err := tx.Validate(pp, ctw, []common.PublicHash{testAdminKey}); err != ErrIndexedSubscribe {
+		t.Errorf("indexed again: got %v, want %v", err, ErrIndexedSubscribe)
+	}
+}
+
+func TestIndexTopic(t *testing.T) {
+	pp, ctw := newTestPayment(t)
+	// the topic is stored as it was added before the topic list
+	legacy := Topic("legacy.topic")
+	ctw.SetProcessData(toTopicKey(legacy), []byte("legacy.topic"))
+	if list, err := pp.Topics(ctw); err != nil {
+		t.Fatal(err)
+	} else if len(list) != 1 {
+		t.Fatalf("got %d topics, want 1", len(list))
+	}
+
+	tests := []struct {
+		name   string
+		from   common.Address
+		topics []string
+		err    error
+	}{
+		{"not admin", testUser, []string{"legacy.topic"}, admin.ErrUnauthorizedTransaction},
+		{"not exist topic", testAdmin, []string{"unknown.topic"}, ErrNotExistTopic},
+		{"indexed topic", testAdmin, []string{"test.topic"}, ErrIndexedTopic},
+		{"duplicated topic", testAdmin, []string{"legacy.topic", "legacy.topic"}, ErrInvalidTopicName},
+		{"no topic", testAdmin, nil, ErrInvalidTopicName},
+		{"legacy topic", testAdmin, []string{"legacy.topic"}, nil},
+	}
+	for _, tt := range tests {
+		tx := &IndexTopic{From_: tt.from, TopicNames: tt.topics}
+		key := testAdminKey
+		if tt.from == testUser {
+			key = testUserKey
+		}
+		if err := tx.Validate(pp, ctw, []common.PublicHash{key}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	tx := &IndexTopic{From_: testAdmin, TopicNames: []string{"legacy.topic"}}
+	if err := tx.Execute(pp, ctw, 0); err != nil {
+		t.Fatal(err)
+	}
+	list, err := pp.Topics(ctw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[1].Topic != legacy || list[1].Info.Name != "legacy.topic" {
+		t.Errorf("unexpected topics %v", list)
+	}
+	if err := tx.Validate(pp, ctw, []common.PublicHash{testAdminKey}); err != ErrIndexedTopic {
+		t.Errorf("indexed again: got %v, want %v", err, ErrIndexedTopic)
+	}
+}
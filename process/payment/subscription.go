@@ -0,0 +1,66 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common/amount"
+)
+
+// Subscription is a subscription of the topic that allows one billing per period
+// Zero Amount means the billing amount of a period is not limited
+// Zero Period means the subscription is added before billing periods and it can be billed at any height
+type Subscription struct {
+	Amount            *amount.Amount
+	Period            uint32
+	StartHeight       uint32
+	NextBillingHeight uint32
+}
+
+// IsDue returns the subscription can be billed at the height or not
+func (sub *Subscription) IsDue(height uint32) bool {
+	return height >= sub.NextBillingHeight
+}
+
+// nextBillingHeight returns the start height of the period after the period that includes the height
+func (sub *Subscription) nextBillingHeight(height uint32) uint32 {
+	if sub.Period == 0 {
+		return height
+	}
+	return sub.StartHeight + ((height-sub.StartHeight)/sub.Period+1)*sub.Period
+}
+
+// MarshalJSON is a marshaler function
+func (sub *Subscription) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := sub.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"period":`)
+	if bs, err := json.Marshal(sub.Period); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"start_height":`)
+	if bs, err := json.Marshal(sub.StartHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"next_billing_height":`)
+	if bs, err := json.Marshal(sub.NextBillingHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
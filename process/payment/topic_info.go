@@ -0,0 +1,115 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+)
+
+// TopicInfo is the metadata of the topic
+// Owner manages the topic and Payee receives the billing proceeds of the topic
+type TopicInfo struct {
+	Name        string
+	Owner       common.Address
+	Payee       common.Address
+	Description string
+	Price       *amount.Amount
+	Period      uint32
+}
+
+// Validate checks the metadata of the topic
+func (ti *TopicInfo) Validate() error {
+	if len(ti.Description) > 255 {
+		return ErrExceedContentSize
+	}
+	if ti.Price == nil || ti.Price.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidPaymentAmount
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (ti *TopicInfo) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"name":`)
+	if bs, err := json.Marshal(ti.Name); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"owner":`)
+	if bs, err := ti.Owner.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payee":`)
+	if bs, err := ti.Payee.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"description":`)
+	if bs, err := json.Marshal(ti.Description); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"price":`)
+	if bs, err := ti.Price.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"period":`)
+	if bs, err := json.Marshal(ti.Period); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
+
+// TopicItem is the topic with the number of subscribers
+type TopicItem struct {
+	Topic           uint64
+	Info            *TopicInfo
+	SubscriberCount uint32
+}
+
+// MarshalJSON is a marshaler function
+func (ti *TopicItem) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"topic":`)
+	if bs, err := json.Marshal(ti.Topic); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"info":`)
+	if bs, err := ti.Info.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"subscriber_count":`)
+	if bs, err := json.Marshal(ti.SubscriberCount); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
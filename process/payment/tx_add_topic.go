@@ -5,16 +5,22 @@ import (
 	"encoding/json"
 
 	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/process/admin"
 )
 
 // AddTopic is a AddTopic
 type AddTopic struct {
-	Timestamp_ uint64
-	From_      common.Address
-	Topic      uint64
-	TopicName  string
+	Timestamp_  uint64
+	From_       common.Address
+	Topic       uint64
+	TopicName   string
+	Owner       common.Address
+	Payee       common.Address
+	Description string
+	Price       *amount.Amount
+	Period      uint32
 }
 
 // Timestamp returns the timestamp of the transaction
@@ -37,6 +43,20 @@ func (tx *AddTopic) Validate(p types.Process, loader types.LoaderWrapper, signer
 	if tx.Topic != Topic(tx.TopicName) {
 		return ErrInvalidTopicName
 	}
+	if err := tx.topicInfo().Validate(); err != nil {
+		return err
+	}
+
+	if has, err := loader.HasAccount(tx.Owner); err != nil {
+		return err
+	} else if !has {
+		return ErrInvalidTopicOwner
+	}
+	if has, err := loader.HasAccount(tx.Payee); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
@@ -52,10 +72,26 @@ func (tx *AddTopic) Validate(p types.Process, loader types.LoaderWrapper, signer
 func (tx *AddTopic) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	sp := p.(*Payment)
 
-	sp.addTopic(ctw, tx.Topic, tx.TopicName)
+	if err := sp.addTopic(ctw, tx.Topic, tx.TopicName); err != nil {
+		return err
+	}
+	if err := sp.setTopicInfo(ctw, tx.Topic, tx.topicInfo()); err != nil {
+		return err
+	}
 	return nil
 }
 
+func (tx *AddTopic) topicInfo() *TopicInfo {
+	return &TopicInfo{
+		Name:        tx.TopicName,
+		Owner:       tx.Owner,
+		Payee:       tx.Payee,
+		Description: tx.Description,
+		Price:       tx.Price,
+		Period:      tx.Period,
+	}
+}
+
 // MarshalJSON is a marshaler function
 func (tx *AddTopic) MarshalJSON() ([]byte, error) {
 	var buffer bytes.Buffer
@@ -87,6 +123,41 @@ func (tx *AddTopic) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"owner":`)
+	if bs, err := tx.Owner.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payee":`)
+	if bs, err := tx.Payee.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"description":`)
+	if bs, err := json.Marshal(tx.Description); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"price":`)
+	if bs, err := tx.Price.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"period":`)
+	if bs, err := json.Marshal(tx.Period); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/fletaio/fleta_testnet/common/amount"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/process/admin"
+	"github.com/fletaio/fleta_testnet/process/vault"
 )
 
 // Billing is a Billing
@@ -30,20 +31,27 @@ func (tx *Billing) From() common.Address {
 	return tx.From_
 }
 
+// Fee returns the fee of the transaction
+func (tx *Billing) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Payment)
+	return sp.vault.GetDefaultFee(loader)
+}
+
 // Validate validates signatures of the transaction
 func (tx *Billing) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Payment)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
-	}
 	if len(tx.Content) > 255 {
 		return ErrExceedContentSize
 	}
 
-	if _, err := sp.GetTopicName(loader, tx.Topic); err != nil {
+	ti, err := sp.TopicInfo(loader, tx.Topic)
+	if err != nil {
 		return err
 	}
+	if tx.From() != ti.Owner {
+		return admin.ErrUnauthorizedTransaction
+	}
 
 	if has, err := loader.HasAccount(tx.To); err != nil {
 		return err
@@ -51,13 +59,16 @@ func (tx *Billing) Validate(p types.Process, loader types.LoaderWrapper, signers
 		return types.ErrNotExistAccount
 	}
 
-	am, err := sp.getSubscribe(loader, tx.Topic, tx.To)
+	sub, err := sp.Subscription(loader, tx.Topic, tx.To)
 	if err != nil {
 		return err
 	}
-	if !am.IsZero() && am.Less(tx.Amount) {
+	if !sub.Amount.IsZero() && sub.Amount.Less(tx.Amount) {
 		return ErrInvalidBillingAmount
 	}
+	if !sub.IsDue(loader.TargetHeight()) {
+		return ErrNotDueBilling
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
@@ -66,20 +77,64 @@ func (tx *Billing) Validate(p types.Process, loader types.LoaderWrapper, signers
 	if err := fromAcc.Validate(loader, signers); err != nil {
 		return err
 	}
+
+	if err := sp.vault.CheckFeePayable(p, loader, tx); err != nil {
+		return err
+	}
 	return nil
 }
 
 // Execute updates the context by the transaction
+// the fee is charged even if the charge fails so the owner cannot emit failed charges for free
 func (tx *Billing) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	sp := p.(*Payment)
 
-	if err := sp.vault.SubBalance(ctw, tx.To, tx.Amount); err != nil {
-		return err
-	}
-	if err := sp.vault.AddBalance(ctw, sp.admin.AdminAddress(ctw, p.Name()), tx.Amount); err != nil {
-		return err
-	}
-	return nil
+	return sp.vault.WithFee(p, ctw, tx, func() error {
+		sub, err := sp.Subscription(ctw, tx.Topic, tx.To)
+		if err != nil {
+			return err
+		}
+		if !sub.IsDue(ctw.TargetHeight()) {
+			return ErrNotDueBilling
+		}
+
+		if sp.vault.Balance(ctw, tx.To).Less(tx.Amount) {
+			ev := &ChargeFailedEvent{
+				Height_:    ctw.TargetHeight(),
+				Index_:     index,
+				Topic:      tx.Topic,
+				Subscriber: tx.To,
+				Amount:     tx.Amount,
+				Reason:     vault.ErrInsufficientBalance.Error(),
+			}
+			return ctw.EmitEvent(ev)
+		}
+		if err := sp.vault.SubBalance(ctw, tx.To, tx.Amount); err != nil {
+			return err
+		}
+		ti, err := sp.TopicInfo(ctw, tx.Topic)
+		if err != nil {
+			return err
+		}
+		if err := sp.vault.AddBalance(ctw, ti.Payee, tx.Amount); err != nil {
+			return err
+		}
+		sub.NextBillingHeight = sub.nextBillingHeight(ctw.TargetHeight())
+		if err := sp.setSubscription(ctw, tx.Topic, tx.To, sub); err != nil {
+			return err
+		}
+		if !sp.isIndexedSubscribe(ctw, tx.Topic, tx.To) {
+			sp.indexSubscribe(ctw, tx.Topic, tx.To)
+		}
+		ev := &ChargedEvent{
+			Height_:    ctw.TargetHeight(),
+			Index_:     index,
+			Topic:      tx.Topic,
+			Subscriber: tx.To,
+			Amount:     tx.Amount,
+		}
+		return ctw.EmitEvent(ev)
+	})
 }
 
 // MarshalJSON is a marshaler function
@@ -0,0 +1,108 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+)
+
+// ConfirmDelivery releases the escrowed payment to the payee of the topic
+// It is sent by the admin that requested the payment
+type ConfirmDelivery struct {
+	Timestamp_ uint64
+	From_      common.Address
+	TXID       string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *ConfirmDelivery) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *ConfirmDelivery) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *ConfirmDelivery) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Payment)
+
+	es, err := sp.Escrow(loader, tx.TXID)
+	if err != nil {
+		return err
+	}
+	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if loader.TargetHeight() > es.RefundHeight {
+		return ErrEscrowRefunded
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *ConfirmDelivery) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Payment)
+
+	es, err := sp.Escrow(ctw, tx.TXID)
+	if err != nil {
+		return err
+	}
+	if err := sp.vault.SubLockedBalance(ctw, es.Payer, es.RefundHeight, es.Amount); err != nil {
+		return err
+	}
+	if err := sp.vault.AddBalance(ctw, es.Payee, es.Amount); err != nil {
+		return err
+	}
+	sp.removeEscrow(ctw, tx.TXID)
+
+	ev := &PaymentStateEvent{
+		Height_: ctw.TargetHeight(),
+		Index_:  index,
+		TXID:    tx.TXID,
+		State:   PaymentReleased,
+		Payer:   es.Payer,
+		Amount:  es.Amount,
+	}
+	return ctw.EmitEvent(ev)
+}
+
+// MarshalJSON is a marshaler function
+func (tx *ConfirmDelivery) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"txid":`)
+	if bs, err := json.Marshal(tx.TXID); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,124 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+)
+
+// IndexSubscribe is used to add subscriptions that are added before the subscriber list to the subscriber list
+// The subscriptions are stored by the current format without the billing period
+type IndexSubscribe struct {
+	Timestamp_  uint64
+	From_       common.Address
+	Topic       uint64
+	Subscribers []common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *IndexSubscribe) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *IndexSubscribe) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *IndexSubscribe) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Payment)
+
+	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if len(tx.Subscribers) == 0 || len(tx.Subscribers) > 255 {
+		return ErrInvalidSubscriber
+	}
+	if _, err := sp.GetTopicName(loader, tx.Topic); err != nil {
+		return err
+	}
+	addrMap := map[common.Address]bool{}
+	for _, addr := range tx.Subscribers {
+		if addrMap[addr] {
+			return ErrInvalidSubscriber
+		}
+		addrMap[addr] = true
+
+		if _, err := sp.Subscription(loader, tx.Topic, addr); err != nil {
+			return err
+		}
+		if sp.isIndexedSubscribe(loader, tx.Topic, addr) {
+			return ErrIndexedSubscribe
+		}
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *IndexSubscribe) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Payment)
+
+	for _, addr := range tx.Subscribers {
+		if sp.isIndexedSubscribe(ctw, tx.Topic, addr) {
+			return ErrIndexedSubscribe
+		}
+		if err := sp.migrateSubscribe(ctw, tx.Topic, addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (tx *IndexSubscribe) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"topic":`)
+	if bs, err := json.Marshal(tx.Topic); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"subscribers":`)
+	buffer.WriteString(`[`)
+	for i, addr := range tx.Subscribers {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := addr.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
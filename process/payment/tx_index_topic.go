@@ -0,0 +1,107 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+)
+
+// IndexTopic is used to add topics that are added before the topic list to the topic list
+type IndexTopic struct {
+	Timestamp_ uint64
+	From_      common.Address
+	TopicNames []string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *IndexTopic) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *IndexTopic) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *IndexTopic) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Payment)
+
+	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if len(tx.TopicNames) == 0 || len(tx.TopicNames) > 255 {
+		return ErrInvalidTopicName
+	}
+	topicMap := map[uint64]bool{}
+	for _, Name := range tx.TopicNames {
+		topic := Topic(Name)
+		if topicMap[topic] {
+			return ErrInvalidTopicName
+		}
+		topicMap[topic] = true
+
+		if name, err := sp.GetTopicName(loader, topic); err != nil {
+			return err
+		} else if name != Name {
+			return ErrInvalidTopicName
+		}
+		if sp.isIndexedTopic(loader, topic) {
+			return ErrIndexedTopic
+		}
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *IndexTopic) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Payment)
+
+	for _, Name := range tx.TopicNames {
+		topic := Topic(Name)
+		if sp.isIndexedTopic(ctw, topic) {
+			return ErrIndexedTopic
+		}
+		sp.indexTopic(ctw, topic)
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (tx *IndexTopic) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"topic_names":`)
+	if bs, err := json.Marshal(tx.TopicNames); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -31,12 +31,16 @@ func (tx *RemoveTopic) From() common.Address {
 func (tx *RemoveTopic) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Payment)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
-		return admin.ErrUnauthorizedTransaction
-	}
 	if tx.Topic != Topic(tx.TopicName) {
 		return ErrInvalidTopicName
 	}
+	ti, err := sp.TopicInfo(loader, tx.Topic)
+	if err != nil {
+		return err
+	}
+	if tx.From() != ti.Owner && tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+		return admin.ErrUnauthorizedTransaction
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
@@ -10,14 +10,20 @@ import (
 	"github.com/fletaio/fleta_testnet/process/admin"
 )
 
+// MaxEscrowBlocks is the maximum number of blocks that the accepted payment is escrowed for
+const MaxEscrowBlocks = 5184000
+
 // RequestPayment is a RequestPayment
+// The request does not expire when ExpiryHeight is zero as requests before the expiry was added
 type RequestPayment struct {
-	Timestamp_ uint64
-	From_      common.Address
-	Topic      uint64
-	To         common.Address
-	Amount     *amount.Amount
-	Content    string
+	Timestamp_   uint64
+	From_        common.Address
+	Topic        uint64
+	To           common.Address
+	Amount       *amount.Amount
+	Content      string
+	ExpiryHeight uint32
+	EscrowBlocks uint32
 }
 
 // Timestamp returns the timestamp of the transaction
@@ -43,6 +49,12 @@ func (tx *RequestPayment) Validate(p types.Process, loader types.LoaderWrapper,
 	if len(tx.Content) > 255 {
 		return ErrExceedContentSize
 	}
+	if tx.ExpiryHeight > 0 && tx.ExpiryHeight <= loader.TargetHeight() {
+		return ErrInvalidExpiryHeight
+	}
+	if tx.EscrowBlocks > MaxEscrowBlocks {
+		return ErrInvalidEscrowBlocks
+	}
 
 	if _, err := sp.GetTopicName(loader, tx.Topic); err != nil {
 		return err
@@ -119,6 +131,20 @@ func (tx *RequestPayment) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"expiry_height":`)
+	if bs, err := json.Marshal(tx.ExpiryHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"escrow_blocks":`)
+	if bs, err := json.Marshal(tx.EscrowBlocks); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
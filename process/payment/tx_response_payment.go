@@ -38,25 +38,31 @@ func (tx *ResponsePayment) Validate(p types.Process, loader types.LoaderWrapper,
 		return types.ErrDustAmount
 	}
 
-	adminAddr := sp.admin.AdminAddress(loader, p.Name())
 	req, err := sp.getRequestPayment(loader, tx.TXID)
 	if err != nil {
 		return err
 	}
-	if req.From() != adminAddr {
+	if req.From() != sp.admin.AdminAddress(loader, p.Name()) {
 		return admin.ErrUnauthorizedTransaction
 	}
 	if req.To != tx.From() {
 		return ErrInvalidRequestPayment
 	}
+	if req.ExpiryHeight > 0 && loader.TargetHeight() >= req.ExpiryHeight {
+		return ErrExpiredRequestPayment
+	}
 	if !req.Amount.Equal(tx.Amount) {
 		return ErrInvalidPaymentAmount
 	}
-	if _, err := sp.GetTopicName(loader, req.Topic); err != nil {
+	if req.EscrowBlocks > MaxEscrowBlocks {
+		return ErrInvalidEscrowBlocks
+	}
+	ti, err := sp.TopicInfo(loader, req.Topic)
+	if err != nil {
 		return err
 	}
 
-	if has, err := loader.HasAccount(adminAddr); err != nil {
+	if has, err := loader.HasAccount(ti.Payee); err != nil {
 		return err
 	} else if !has {
 		return types.ErrNotExistAccount
@@ -83,16 +89,51 @@ func (tx *ResponsePayment) Validate(p types.Process, loader types.LoaderWrapper,
 func (tx *ResponsePayment) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	sp := p.(*Payment)
 
-	if tx.IsAccept {
-		if err := sp.vault.SubBalance(ctw, tx.From(), tx.Amount); err != nil {
+	req, err := sp.getRequestPayment(ctw, tx.TXID)
+	if err != nil {
+		return err
+	}
+	ev := &PaymentStateEvent{
+		Height_: ctw.TargetHeight(),
+		Index_:  index,
+		TXID:    tx.TXID,
+		Payer:   tx.From(),
+		Amount:  tx.Amount,
+	}
+	if !tx.IsAccept {
+		ev.State = PaymentRejected
+	} else {
+		ti, err := sp.TopicInfo(ctw, req.Topic)
+		if err != nil {
 			return err
 		}
-		if err := sp.vault.AddBalance(ctw, sp.admin.AdminAddress(ctw, p.Name()), tx.Amount); err != nil {
+		if err := sp.vault.SubBalance(ctw, tx.From(), tx.Amount); err != nil {
 			return err
 		}
+		if req.EscrowBlocks == 0 {
+			if err := sp.vault.AddBalance(ctw, ti.Payee, tx.Amount); err != nil {
+				return err
+			}
+			ev.State = PaymentPaid
+		} else {
+			es := &Escrow{
+				Topic:        req.Topic,
+				Payer:        tx.From(),
+				Payee:        ti.Payee,
+				Amount:       tx.Amount,
+				RefundHeight: ctw.TargetHeight() + req.EscrowBlocks,
+			}
+			if err := sp.vault.AddLockedBalance(ctw, es.Payer, es.RefundHeight, es.Amount); err != nil {
+				return err
+			}
+			if err := sp.addEscrow(ctw, tx.TXID, es); err != nil {
+				return err
+			}
+			ev.State = PaymentEscrowed
+		}
 	}
 	sp.removeRequestPayment(ctw, tx.TXID)
-	return nil
+	return ctw.EmitEvent(ev)
 }
 
 // MarshalJSON is a marshaler function
@@ -16,6 +16,7 @@ type Subscribe struct {
 	From_      common.Address
 	Topic      uint64
 	Amount     *amount.Amount
+	Period     uint32
 }
 
 // Timestamp returns the timestamp of the transaction
@@ -36,6 +37,10 @@ func (tx *Subscribe) Validate(p types.Process, loader types.LoaderWrapper, signe
 		return types.ErrDustAmount
 	}
 
+	if tx.Period == 0 {
+		return ErrInvalidBillingPeriod
+	}
+
 	if _, err := sp.GetTopicName(loader, tx.Topic); err != nil {
 		return err
 	}
@@ -67,7 +72,13 @@ func (tx *Subscribe) Validate(p types.Process, loader types.LoaderWrapper, signe
 func (tx *Subscribe) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	sp := p.(*Payment)
 
-	if err := sp.addSubscribe(ctw, tx.Topic, tx.From(), tx.Amount); err != nil {
+	sub := &Subscription{
+		Amount:            tx.Amount,
+		Period:            tx.Period,
+		StartHeight:       ctw.TargetHeight(),
+		NextBillingHeight: ctw.TargetHeight(),
+	}
+	if err := sp.addSubscribe(ctw, tx.Topic, tx.From(), sub); err != nil {
 		return err
 	}
 	return nil
@@ -104,6 +115,13 @@ func (tx *Subscribe) MarshalJSON() ([]byte, error) {
 	} else {
 		buffer.Write(bs)
 	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"period":`)
+	if bs, err := json.Marshal(tx.Period); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
 	buffer.WriteString(`}`)
 	return buffer.Bytes(), nil
 }
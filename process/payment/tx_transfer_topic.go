@@ -0,0 +1,109 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+)
+
+// TransferTopic moves the ownership of the topic to the new owner
+type TransferTopic struct {
+	Timestamp_ uint64
+	From_      common.Address
+	Topic      uint64
+	Owner      common.Address
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *TransferTopic) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *TransferTopic) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *TransferTopic) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Payment)
+
+	ti, err := sp.TopicInfo(loader, tx.Topic)
+	if err != nil {
+		return err
+	}
+	if tx.From() != ti.Owner {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if tx.Owner == ti.Owner {
+		return ErrSameTopicOwner
+	}
+
+	if has, err := loader.HasAccount(tx.Owner); err != nil {
+		return err
+	} else if !has {
+		return ErrInvalidTopicOwner
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *TransferTopic) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Payment)
+
+	ti, err := sp.TopicInfo(ctw, tx.Topic)
+	if err != nil {
+		return err
+	}
+	ti.Owner = tx.Owner
+	if err := sp.setTopicInfo(ctw, tx.Topic, ti); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarshalJSON is a marshaler function
+func (tx *TransferTopic) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"topic":`)
+	if bs, err := json.Marshal(tx.Topic); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"owner":`)
+	if bs, err := tx.Owner.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,141 @@
+package payment
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+)
+
+// UpdateTopic updates the payee and the pricing metadata of the topic
+type UpdateTopic struct {
+	Timestamp_  uint64
+	From_       common.Address
+	Topic       uint64
+	Payee       common.Address
+	Description string
+	Price       *amount.Amount
+	Period      uint32
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *UpdateTopic) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *UpdateTopic) From() common.Address {
+	return tx.From_
+}
+
+// Validate validates signatures of the transaction
+func (tx *UpdateTopic) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Payment)
+
+	ti, err := sp.TopicInfo(loader, tx.Topic)
+	if err != nil {
+		return err
+	}
+	if tx.From() != ti.Owner {
+		return admin.ErrUnauthorizedTransaction
+	}
+	if err := tx.update(ti).Validate(); err != nil {
+		return err
+	}
+
+	if has, err := loader.HasAccount(tx.Payee); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *UpdateTopic) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Payment)
+
+	ti, err := sp.TopicInfo(ctw, tx.Topic)
+	if err != nil {
+		return err
+	}
+	if err := sp.setTopicInfo(ctw, tx.Topic, tx.update(ti)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (tx *UpdateTopic) update(ti *TopicInfo) *TopicInfo {
+	ti.Payee = tx.Payee
+	ti.Description = tx.Description
+	ti.Price = tx.Price
+	ti.Period = tx.Period
+	return ti
+}
+
+// MarshalJSON is a marshaler function
+func (tx *UpdateTopic) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"topic":`)
+	if bs, err := json.Marshal(tx.Topic); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"payee":`)
+	if bs, err := tx.Payee.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"description":`)
+	if bs, err := json.Marshal(tx.Description); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"price":`)
+	if bs, err := tx.Price.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"period":`)
+	if bs, err := json.Marshal(tx.Period); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
@@ -1,13 +1,24 @@
 package payment
 
 import (
+	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/binutil"
 )
 
 // tags
 var (
-	tagRequestPayment = []byte{1, 0}
-	tagTopic          = []byte{2, 0}
+	tagRequestPayment    = []byte{1, 0}
+	tagRequestExpiry     = []byte{1, 1}
+	tagTopic             = []byte{2, 0}
+	tagTopicInfo         = []byte{2, 1}
+	tagTopicNumber       = []byte{2, 2}
+	tagTopicReverse      = []byte{2, 3}
+	tagTopicCount        = []byte{2, 4}
+	tagSubscriberNumber  = []byte{3, 0}
+	tagSubscriberReverse = []byte{3, 1}
+	tagSubscriberCount   = []byte{3, 2}
+	tagEscrow            = []byte{4, 0}
+	tagEscrowRefund      = []byte{4, 1}
 )
 
 func toRequestPaymentKey(TXID string) []byte {
@@ -23,3 +34,68 @@ func toTopicKey(topic uint64) []byte {
 	binutil.BigEndian.PutUint64(bs[2:], topic)
 	return bs
 }
+
+func toTopicInfoKey(topic uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagTopicInfo)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	return bs
+}
+
+func toTopicNumberKey(num uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagTopicNumber)
+	binutil.BigEndian.PutUint32(bs[2:], num)
+	return bs
+}
+
+func toTopicReverseKey(topic uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagTopicReverse)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	return bs
+}
+
+func toSubscriberNumberKey(topic uint64, num uint32) []byte {
+	bs := make([]byte, 14)
+	copy(bs, tagSubscriberNumber)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	binutil.BigEndian.PutUint32(bs[10:], num)
+	return bs
+}
+
+func toSubscriberReverseKey(topic uint64, addr common.Address) []byte {
+	bs := make([]byte, 10+common.AddressSize)
+	copy(bs, tagSubscriberReverse)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	copy(bs[10:], addr[:])
+	return bs
+}
+
+func toSubscriberCountKey(topic uint64) []byte {
+	bs := make([]byte, 10)
+	copy(bs, tagSubscriberCount)
+	binutil.BigEndian.PutUint64(bs[2:], topic)
+	return bs
+}
+
+func toRequestExpiryKey(height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagRequestExpiry)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	return bs
+}
+
+func toEscrowKey(TXID string) []byte {
+	bs := make([]byte, 2+len(TXID))
+	copy(bs, tagEscrow)
+	copy(bs[2:], []byte(TXID))
+	return bs
+}
+
+func toEscrowRefundKey(height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagEscrowRefund)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	return bs
+}
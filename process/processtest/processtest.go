@@ -0,0 +1,111 @@
+// Package processtest provides the process manager and the context of processes for tests of processes
+package processtest
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// ProcessManager is the process manager of processes of the test without services
+type ProcessManager struct {
+	processes []types.Process
+}
+
+// NewProcessManager initializes processes by registers of their ids and returns the process manager of them
+func NewProcessManager(t *testing.T, processes ...types.Process) *ProcessManager {
+	t.Helper()
+	pm := &ProcessManager{processes: processes}
+	for _, p := range pm.processes {
+		if err := p.Init(types.NewRegister(p.ID()), pm, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return pm
+}
+
+// Processes returns processes
+func (pm *ProcessManager) Processes() []types.Process {
+	return pm.processes
+}
+
+// Process returns the process by the id
+func (pm *ProcessManager) Process(id uint8) (types.Process, error) {
+	for _, p := range pm.processes {
+		if p.ID() == id {
+			return p, nil
+		}
+	}
+	return nil, types.ErrNotExistProcess
+}
+
+// ProcessByName returns the process by the name
+func (pm *ProcessManager) ProcessByName(name string) (types.Process, error) {
+	for _, p := range pm.processes {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, types.ErrNotExistProcess
+}
+
+// Services returns services
+func (pm *ProcessManager) Services() []types.Service {
+	return nil
+}
+
+// ServiceByName returns the service by the name
+func (pm *ProcessManager) ServiceByName(name string) (types.Service, error) {
+	return nil, types.ErrNotExistProcess
+}
+
+// Fixture is the initial data of the context of the test
+type Fixture struct {
+	AdminAddressMap map[string]common.Address
+	Accounts        []types.Account
+	Balances        map[common.Address]*amount.Amount
+}
+
+type adminInitializer interface {
+	InitAdmin(ctw *types.ContextWrapper, addrMap map[string]common.Address) error
+}
+
+type balanceAdder interface {
+	AddBalance(ctw *types.ContextWrapper, addr common.Address, am *amount.Amount) error
+}
+
+// NewContext returns the empty context that has the data of the fixture
+// admin addresses are initialized by the fleta.admin process and balances are added by the fleta.vault process
+func (pm *ProcessManager) NewContext(t *testing.T, fx *Fixture) *types.Context {
+	t.Helper()
+	ctx := types.NewEmptyContext()
+	ctw := types.NewContextWrapper(0, ctx)
+	if len(fx.AdminAddressMap) > 0 {
+		p, err := pm.ProcessByName("fleta.admin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.(adminInitializer).InitAdmin(ctw, fx.AdminAddressMap); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, acc := range fx.Accounts {
+		if err := ctw.CreateAccount(acc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(fx.Balances) > 0 {
+		p, err := pm.ProcessByName("fleta.vault")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for addr, am := range fx.Balances {
+			if err := p.(balanceAdder).AddBalance(ctw, addr, am); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return ctx
+}
@@ -17,4 +17,7 @@ var (
 	ErrPolicyShouldBeSetupInApplication = errors.New("policy should be setup in application")
 	ErrInvalidTagSize                   = errors.New("invalid tag size")
 	ErrInvalidDefaultFee                = errors.New("invalid default fee")
+	ErrInvalidUnlockHeight              = errors.New("invalid unlock height")
+	ErrInvalidInstallmentCount          = errors.New("invalid installment count")
+	ErrInvalidRecipientCount            = errors.New("invalid recipient count")
 )
@@ -0,0 +1,11 @@
+package vault
+
+import (
+	"github.com/fletaio/fleta_testnet/common/amount"
+)
+
+// LockedBalanceItem is an entry of the unlock schedule of the account
+type LockedBalanceItem struct {
+	UnlockedHeight uint32         `json:"unlocked_height"`
+	Amount         *amount.Amount `json:"amount"`
+}
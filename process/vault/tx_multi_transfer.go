@@ -0,0 +1,161 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// MaxMultiTransferCount is the maximum number of recipients of a multi transfer
+const MaxMultiTransferCount = 500
+
+// MultiTransfer is used to transfer coins to multiple recipients in one transaction
+type MultiTransfer struct {
+	Timestamp_ uint64
+	From_      common.Address
+	To         []common.Address
+	Amount     []*amount.Amount
+	Tag        []string
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *MultiTransfer) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *MultiTransfer) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *MultiTransfer) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader).MulC(int64(len(tx.To)))
+}
+
+// TotalAmount returns the sum of the amounts of all recipients
+func (tx *MultiTransfer) TotalAmount() *amount.Amount {
+	sum := amount.NewCoinAmount(0, 0)
+	for _, am := range tx.Amount {
+		sum = sum.Add(am)
+	}
+	return sum
+}
+
+// Validate validates signatures of the transaction
+func (tx *MultiTransfer) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if len(tx.To) == 0 || len(tx.To) > MaxMultiTransferCount {
+		return ErrInvalidRecipientCount
+	}
+	if len(tx.Amount) != len(tx.To) {
+		return ErrInvalidRecipientCount
+	}
+	if len(tx.Tag) != 0 && len(tx.Tag) != len(tx.To) {
+		return ErrInvalidRecipientCount
+	}
+	for _, tag := range tx.Tag {
+		if len(tag) > 32 {
+			return ErrInvalidTagSize
+		}
+	}
+	for i, To := range tx.To {
+		if tx.Amount[i].Less(amount.COIN.DivC(10)) {
+			return types.ErrDustAmount
+		}
+		if has, err := loader.HasAccount(To); err != nil {
+			return err
+		} else if !has {
+			return types.ErrNotExistAccount
+		}
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayableWith(p, loader, tx, tx.TotalAmount()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Execute updates the context by the transaction
+func (tx *MultiTransfer) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		if err := sp.SubBalance(ctw, tx.From(), tx.TotalAmount()); err != nil {
+			return err
+		}
+		for i, To := range tx.To {
+			if err := sp.AddBalance(ctw, To, tx.Amount[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *MultiTransfer) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":[`)
+	for i, addr := range tx.To {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := addr.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":[`)
+	for i, am := range tx.Amount {
+		if i > 0 {
+			buffer.WriteString(`,`)
+		}
+		if bs, err := am.MarshalJSON(); err != nil {
+			return nil, err
+		} else {
+			buffer.Write(bs)
+		}
+	}
+	buffer.WriteString(`]`)
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"tag":`)
+	if bs, err := json.Marshal(tx.Tag); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
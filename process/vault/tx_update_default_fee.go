@@ -31,15 +31,12 @@ func (tx *UpdateDefaultFee) From() common.Address {
 func (tx *UpdateDefaultFee) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Vault)
 
-	if tx.DefaultFee == nil {
-		return ErrInvalidDefaultFee
-	}
-	if tx.DefaultFee.Less(amount.COIN.DivC(100000000)) {
-		return ErrInvalidDefaultFee
-	}
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if !sp.admin.IsPolicyAdmin(loader, p.Name(), tx.From()) {
 		return admin.ErrUnauthorizedTransaction
 	}
+	if err := tx.ValidateParams(p, loader); err != nil {
+		return err
+	}
 
 	fromAcc, err := loader.Account(tx.From())
 	if err != nil {
@@ -51,6 +48,17 @@ func (tx *UpdateDefaultFee) Validate(p types.Process, loader types.LoaderWrapper
 	return nil
 }
 
+// ValidateParams validates parameters of the transaction apart from its signers
+func (tx *UpdateDefaultFee) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	if tx.DefaultFee == nil {
+		return ErrInvalidDefaultFee
+	}
+	if tx.DefaultFee.Less(amount.COIN.DivC(100000000)) {
+		return ErrInvalidDefaultFee
+	}
+	return nil
+}
+
 // Execute updates the context by the transaction
 func (tx *UpdateDefaultFee) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	ctw.SetProcessData(tagDefaultFee, tx.DefaultFee.Bytes())
@@ -31,11 +31,11 @@ func (tx *UpdatePolicy) From() common.Address {
 func (tx *UpdatePolicy) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
 	sp := p.(*Vault)
 
-	if tx.From() != sp.admin.AdminAddress(loader, p.Name()) {
+	if !sp.admin.IsPolicyAdmin(loader, p.Name(), tx.From()) {
 		return admin.ErrUnauthorizedTransaction
 	}
-	if tx.Policy == nil {
-		return ErrInvalidPolicy
+	if err := tx.ValidateParams(p, loader); err != nil {
+		return err
 	}
 
 	fromAcc, err := loader.Account(tx.From())
@@ -48,6 +48,14 @@ func (tx *UpdatePolicy) Validate(p types.Process, loader types.LoaderWrapper, si
 	return nil
 }
 
+// ValidateParams validates parameters of the transaction apart from its signers
+func (tx *UpdatePolicy) ValidateParams(p types.Process, loader types.LoaderWrapper) error {
+	if tx.Policy == nil {
+		return ErrInvalidPolicy
+	}
+	return nil
+}
+
 // Execute updates the context by the transaction
 func (tx *UpdatePolicy) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
 	if bs, err := encoding.Marshal(tx.Policy); err != nil {
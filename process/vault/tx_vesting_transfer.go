@@ -0,0 +1,177 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
+// MaxVestingInstallments is the maximum number of installments of a vesting transfer
+const MaxVestingInstallments = 120
+
+// VestingTransfer is used to transfer coins that are unlocked linearly in installments from the start height to the end height
+type VestingTransfer struct {
+	Timestamp_   uint64
+	From_        common.Address
+	To           common.Address
+	Amount       *amount.Amount
+	StartHeight  uint32
+	EndHeight    uint32
+	Installments uint16
+}
+
+// Timestamp returns the timestamp of the transaction
+func (tx *VestingTransfer) Timestamp() uint64 {
+	return tx.Timestamp_
+}
+
+// From returns the from address of the transaction
+func (tx *VestingTransfer) From() common.Address {
+	return tx.From_
+}
+
+// Fee returns the fee of the transaction
+func (tx *VestingTransfer) Fee(p types.Process, loader types.LoaderWrapper) *amount.Amount {
+	sp := p.(*Vault)
+	return sp.GetDefaultFee(loader).MulC(int64(tx.Installments))
+}
+
+// Validate validates signatures of the transaction
+func (tx *VestingTransfer) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	sp := p.(*Vault)
+
+	if tx.Installments == 0 || tx.Installments > MaxVestingInstallments {
+		return ErrInvalidInstallmentCount
+	}
+	if tx.StartHeight <= loader.TargetHeight() {
+		return ErrInvalidUnlockHeight
+	}
+	if tx.EndHeight < tx.StartHeight {
+		return ErrInvalidUnlockHeight
+	}
+	if tx.EndHeight-tx.StartHeight < uint32(tx.Installments)-1 {
+		return ErrInvalidInstallmentCount
+	}
+	if tx.Amount.DivC(int64(tx.Installments)).Less(amount.COIN.DivC(10)) {
+		return types.ErrDustAmount
+	}
+
+	if has, err := loader.HasAccount(tx.To); err != nil {
+		return err
+	} else if !has {
+		return types.ErrNotExistAccount
+	}
+
+	fromAcc, err := loader.Account(tx.From())
+	if err != nil {
+		return err
+	}
+	if err := fromAcc.Validate(loader, signers); err != nil {
+		return err
+	}
+
+	if err := sp.CheckFeePayableWith(p, loader, tx, tx.Amount); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnlockSchedule returns the unlock heights and the amounts of the installments
+func (tx *VestingTransfer) UnlockSchedule() ([]uint32, []*amount.Amount) {
+	N := uint32(tx.Installments)
+	Heights := make([]uint32, 0, N)
+	Amounts := make([]*amount.Amount, 0, N)
+	Installment := tx.Amount.DivC(int64(N))
+	Remained := tx.Amount.Clone()
+	for i := uint32(0); i < N; i++ {
+		if N == 1 {
+			Heights = append(Heights, tx.EndHeight)
+		} else {
+			Heights = append(Heights, tx.StartHeight+uint32(uint64(tx.EndHeight-tx.StartHeight)*uint64(i)/uint64(N-1)))
+		}
+		if i == N-1 {
+			Amounts = append(Amounts, Remained)
+		} else {
+			Amounts = append(Amounts, Installment)
+			Remained = Remained.Sub(Installment)
+		}
+	}
+	return Heights, Amounts
+}
+
+// Execute updates the context by the transaction
+func (tx *VestingTransfer) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	sp := p.(*Vault)
+
+	return sp.WithFee(p, ctw, tx, func() error {
+		if err := sp.SubBalance(ctw, tx.From(), tx.Amount); err != nil {
+			return err
+		}
+		Heights, Amounts := tx.UnlockSchedule()
+		for i, UnlockedHeight := range Heights {
+			if err := sp.AddLockedBalance(ctw, tx.To, UnlockedHeight, Amounts[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MarshalJSON is a marshaler function
+func (tx *VestingTransfer) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{`)
+	buffer.WriteString(`"timestamp":`)
+	if bs, err := json.Marshal(tx.Timestamp_); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"from":`)
+	if bs, err := tx.From_.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"to":`)
+	if bs, err := tx.To.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"amount":`)
+	if bs, err := tx.Amount.MarshalJSON(); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"start_height":`)
+	if bs, err := json.Marshal(tx.StartHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"end_height":`)
+	if bs, err := json.Marshal(tx.EndHeight); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`,`)
+	buffer.WriteString(`"installments":`)
+	if bs, err := json.Marshal(tx.Installments); err != nil {
+		return nil, err
+	} else {
+		buffer.Write(bs)
+	}
+	buffer.WriteString(`}`)
+	return buffer.Bytes(), nil
+}
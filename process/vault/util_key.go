@@ -13,6 +13,9 @@ var (
 	tagLockedBalanceReverse = []byte{2, 3}
 	tagLockedBalanceCount   = []byte{2, 4}
 	tagLockedBalanceSum     = []byte{2, 5}
+	tagLockedHeightNumber   = []byte{2, 6}
+	tagLockedHeightReverse  = []byte{2, 7}
+	tagLockedHeightCount    = []byte{2, 8}
 	tagCollectedFee         = []byte{3, 1}
 	tagPolicy               = []byte{4, 0}
 	tagDefaultFee           = []byte{4, 1}
@@ -48,3 +51,17 @@ func toLockedBalanceCountKey(height uint32) []byte {
 	binutil.BigEndian.PutUint32(bs[2:], height)
 	return bs
 }
+
+func toLockedHeightNumberKey(height uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagLockedHeightNumber)
+	binutil.BigEndian.PutUint32(bs[2:], height)
+	return bs
+}
+
+func toLockedHeightReverseKey(num uint32) []byte {
+	bs := make([]byte, 6)
+	copy(bs, tagLockedHeightReverse)
+	binutil.BigEndian.PutUint32(bs[2:], num)
+	return bs
+}
@@ -52,6 +52,9 @@ func (p *Vault) Init(reg *types.Register, pm types.ProcessManager, cn types.Prov
 	reg.RegisterTransaction(3, &TransferWithTag{})
 	reg.RegisterTransaction(4, &CreateAccount{})
 	reg.RegisterTransaction(5, &CreateMultiAccount{})
+	reg.RegisterTransaction(6, &LockedTransfer{})
+	reg.RegisterTransaction(7, &VestingTransfer{})
+	reg.RegisterTransaction(8, &MultiTransfer{})
 	reg.RegisterTransaction(9, &IssueAccount{})
 	reg.RegisterTransaction(10, &UpdatePolicy{})
 
@@ -86,6 +89,36 @@ func (p *Vault) Init(reg *types.Register, pm types.ProcessManager, cn types.Prov
 			loader := cn.NewLoaderWrapper(p.ID())
 			return p.Balance(loader, addr), nil
 		})
+		s.Set("lockedBalance", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.TotalLockedBalanceByAddress(loader, addr), nil
+		})
+		s.Set("lockedBalanceSchedule", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 1 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			loader := cn.NewLoaderWrapper(p.ID())
+			return p.LockedBalanceSchedule(loader, addr), nil
+		})
 		s.Set("collectedFee", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
 			loader := cn.NewLoaderWrapper(p.ID())
 			return p.CollectedFee(loader), nil
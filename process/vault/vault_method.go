@@ -1,6 +1,10 @@
 package vault
 
 import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/amount"
 	"github.com/fletaio/fleta_testnet/common/binutil"
@@ -20,6 +24,59 @@ func (p *Vault) Balance(loader types.Loader, addr common.Address) *amount.Amount
 	return total
 }
 
+// BalanceChange is the balance of the account before and after the change
+type BalanceChange struct {
+	Address common.Address `json:"address"`
+	Before  *amount.Amount `json:"before"`
+	After   *amount.Amount `json:"after"`
+}
+
+// BalanceChanges returns balance changes of accounts in the diff of the context data
+func (p *Vault) BalanceChanges(diff *types.ContextDiff) []*BalanceChange {
+	changes := []*BalanceChange{}
+	if diff == nil {
+		return changes
+	}
+	for _, d := range diff.AccountData {
+		if d.Address == nil || d.ProcessID != p.pid {
+			continue
+		}
+		if name, err := hex.DecodeString(d.Name); err != nil || !bytes.Equal(name, tagBalance) {
+			continue
+		}
+		changes = append(changes, &BalanceChange{
+			Address: *d.Address,
+			Before:  hexAmount(d.Before),
+			After:   hexAmount(d.After),
+		})
+	}
+	return changes
+}
+
+// CollectedFeeChange returns the amount of the fee that is collected in the diff of the context data
+func (p *Vault) CollectedFeeChange(diff *types.ContextDiff) *amount.Amount {
+	if diff == nil {
+		return amount.NewCoinAmount(0, 0)
+	}
+	for _, d := range diff.ProcessData {
+		if d.ProcessID != p.pid {
+			continue
+		}
+		if name, err := hex.DecodeString(d.Name); err != nil || !bytes.Equal(name, tagCollectedFee) {
+			continue
+		}
+		return hexAmount(d.After).Sub(hexAmount(d.Before))
+	}
+	return amount.NewCoinAmount(0, 0)
+}
+
+func hexAmount(str string) *amount.Amount {
+	if bs, err := hex.DecodeString(str); err == nil && len(bs) > 0 {
+		return amount.NewAmountFromBytes(bs)
+	}
+	return amount.NewCoinAmount(0, 0)
+}
+
 // AddBalance adds balance to the account of the address
 func (p *Vault) AddBalance(ctw *types.ContextWrapper, addr common.Address, am *amount.Amount) error {
 	ctw = types.SwitchContextWrapper(p.pid, ctw)
@@ -100,11 +157,39 @@ func (p *Vault) AddLockedBalance(ctw *types.ContextWrapper, addr common.Address,
 		Count++
 		ctw.SetProcessData(toLockedBalanceCountKey(UnlockedHeight), binutil.LittleEndian.Uint32ToBytes(Count))
 	}
+	// the height is removed when the locked balance is taken back to zero so it is added again even if the address is numbered
+	p.addLockedHeight(ctw, addr, UnlockedHeight)
 	ctw.SetProcessData(toLockedBalanceKey(UnlockedHeight, addr), p.LockedBalance(ctw, addr, UnlockedHeight).Add(am).Bytes())
 	ctw.SetAccountData(addr, tagLockedBalanceSum, p.TotalLockedBalanceByAddress(ctw, addr).Add(am).Bytes())
 	return nil
 }
 
+// SubLockedBalance takes the amount back from the locked balance of the address before it is unlocked
+func (p *Vault) SubLockedBalance(ctw *types.ContextWrapper, addr common.Address, UnlockedHeight uint32, am *amount.Amount) error {
+	ctw = types.SwitchContextWrapper(p.pid, ctw)
+
+	zero := amount.NewCoinAmount(0, 0)
+	if am.Less(zero) {
+		return ErrMinusInput
+	}
+	locked := p.LockedBalance(ctw, addr, UnlockedHeight)
+	if locked.Less(am) {
+		return ErrInsufficientBalance
+	}
+	if remain := locked.Sub(am); !remain.IsZero() {
+		ctw.SetProcessData(toLockedBalanceKey(UnlockedHeight, addr), remain.Bytes())
+	} else {
+		ctw.SetProcessData(toLockedBalanceKey(UnlockedHeight, addr), nil)
+		p.removeLockedHeight(ctw, addr, UnlockedHeight)
+	}
+	if sum := p.TotalLockedBalanceByAddress(ctw, addr).Sub(am); !sum.IsZero() {
+		ctw.SetAccountData(addr, tagLockedBalanceSum, sum.Bytes())
+	} else {
+		ctw.SetAccountData(addr, tagLockedBalanceSum, nil)
+	}
+	return nil
+}
+
 func (p *Vault) flushLockedBalanceMap(ctw *types.ContextWrapper, UnlockedHeight uint32) (map[common.Address]*amount.Amount, error) {
 	LockedBalanceMap := map[common.Address]*amount.Amount{}
 	if bs := ctw.ProcessData(toLockedBalanceCountKey(UnlockedHeight)); len(bs) > 0 {
@@ -113,6 +198,7 @@ func (p *Vault) flushLockedBalanceMap(ctw *types.ContextWrapper, UnlockedHeight
 			var addr common.Address
 			copy(addr[:], ctw.ProcessData(toLockedBalanceReverseKey(UnlockedHeight, i)))
 			LockedBalanceMap[addr] = p.LockedBalance(ctw, addr, UnlockedHeight)
+			p.removeLockedHeight(ctw, addr, UnlockedHeight)
 
 			ctw.SetProcessData(toLockedBalanceKey(UnlockedHeight, addr), nil)
 			ctw.SetProcessData(toLockedBalanceNumberKey(UnlockedHeight, addr), nil)
@@ -123,6 +209,70 @@ func (p *Vault) flushLockedBalanceMap(ctw *types.ContextWrapper, UnlockedHeight
 	return LockedBalanceMap, nil
 }
 
+func (p *Vault) addLockedHeight(ctw *types.ContextWrapper, addr common.Address, UnlockedHeight uint32) {
+	if ns := ctw.AccountData(addr, toLockedHeightNumberKey(UnlockedHeight)); len(ns) > 0 {
+		return
+	}
+	var Count uint32
+	if bs := ctw.AccountData(addr, tagLockedHeightCount); len(bs) > 0 {
+		Count = binutil.LittleEndian.Uint32(bs)
+	}
+	ctw.SetAccountData(addr, toLockedHeightNumberKey(UnlockedHeight), binutil.LittleEndian.Uint32ToBytes(Count))
+	ctw.SetAccountData(addr, toLockedHeightReverseKey(Count), binutil.LittleEndian.Uint32ToBytes(UnlockedHeight))
+	Count++
+	ctw.SetAccountData(addr, tagLockedHeightCount, binutil.LittleEndian.Uint32ToBytes(Count))
+}
+
+func (p *Vault) removeLockedHeight(ctw *types.ContextWrapper, addr common.Address, UnlockedHeight uint32) {
+	ns := ctw.AccountData(addr, toLockedHeightNumberKey(UnlockedHeight))
+	if len(ns) == 0 {
+		return
+	}
+	var Count uint32
+	if bs := ctw.AccountData(addr, tagLockedHeightCount); len(bs) > 0 {
+		Count = binutil.LittleEndian.Uint32(bs)
+	}
+	Number := binutil.LittleEndian.Uint32(ns)
+	if Number != Count-1 {
+		swapHeight := ctw.AccountData(addr, toLockedHeightReverseKey(Count-1))
+		ctw.SetAccountData(addr, toLockedHeightReverseKey(Number), swapHeight)
+		ctw.SetAccountData(addr, toLockedHeightNumberKey(binutil.LittleEndian.Uint32(swapHeight)), binutil.LittleEndian.Uint32ToBytes(Number))
+	}
+	ctw.SetAccountData(addr, toLockedHeightNumberKey(UnlockedHeight), nil)
+	ctw.SetAccountData(addr, toLockedHeightReverseKey(Count-1), nil)
+	Count--
+	if Count == 0 {
+		ctw.SetAccountData(addr, tagLockedHeightCount, nil)
+	} else {
+		ctw.SetAccountData(addr, tagLockedHeightCount, binutil.LittleEndian.Uint32ToBytes(Count))
+	}
+}
+
+// LockedBalanceSchedule returns the locked balances of the address ordered by the unlock height
+func (p *Vault) LockedBalanceSchedule(loader types.Loader, addr common.Address) []*LockedBalanceItem {
+	lw := types.NewLoaderWrapper(p.pid, loader)
+
+	list := []*LockedBalanceItem{}
+	if bs := lw.AccountData(addr, tagLockedHeightCount); len(bs) > 0 {
+		Count := binutil.LittleEndian.Uint32(bs)
+		for i := uint32(0); i < Count; i++ {
+			UnlockedHeight := binutil.LittleEndian.Uint32(lw.AccountData(addr, toLockedHeightReverseKey(i)))
+			am := p.LockedBalance(lw, addr, UnlockedHeight)
+			if am.IsZero() {
+				continue
+			}
+			list = append(list, &LockedBalanceItem{
+				UnlockedHeight: UnlockedHeight,
+				Amount:         am,
+			})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].UnlockedHeight < list[j].UnlockedHeight
+	})
+	return list
+}
+
 // CheckFeePayable returns tx fee can be paid or not
 func (p *Vault) CheckFeePayable(tp types.Process, loader types.Loader, tx FeeTransaction) error {
 	return p.CheckFeePayableWith(tp, loader, tx, nil)
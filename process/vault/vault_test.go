@@ -0,0 +1,205 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
+	"github.com/fletaio/fleta_testnet/process/admin"
+	"github.com/fletaio/fleta_testnet/process/processtest"
+)
+
+var (
+	testFrom    = common.NewAddress(0, 1, 0)
+	testTo      = common.NewAddress(0, 2, 0)
+	testUnknown = common.NewAddress(0, 3, 0)
+	testFromKey = common.PublicHash{1}
+	testToKey   = common.PublicHash{2}
+)
+
+// newTestVault returns the vault and the context of the test that has two accounts and the balance of 100 coins of the from account
+func newTestVault(t *testing.T) (*Vault, *types.ContextWrapper) {
+	ap := admin.NewAdmin(1)
+	vp := NewVault(2)
+	pm := processtest.NewProcessManager(t, ap, vp)
+	ctw := types.NewContextWrapper(vp.ID(), pm.NewContext(t, &processtest.Fixture{
+		Accounts: []types.Account{
+			&SingleAccount{Address_: testFrom, Name_: "from", KeyHash: testFromKey},
+			&SingleAccount{Address_: testTo, Name_: "to", KeyHash: testToKey},
+		},
+		Balances: map[common.Address]*amount.Amount{testFrom: amount.NewCoinAmount(100, 0)},
+	}))
+	if err := vp.InitPolicy(ctw, &Policy{AccountCreationAmount: amount.NewCoinAmount(10, 0)}); err != nil {
+		t.Fatal(err)
+	}
+	return vp, ctw
+}
+
+func TestLockedTransferValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tx      *LockedTransfer
+		signers []common.PublicHash
+		err     error
+	}{
+		{"ok", &LockedTransfer{From_: testFrom, To: testTo, Amount: amount.NewCoinAmount(10, 0), UnlockedHeight: 10}, []common.PublicHash{testFromKey}, nil},
+		{"unlocked at the current height", &LockedTransfer{From_: testFrom, To: testTo, Amount: amount.NewCoinAmount(10, 0), UnlockedHeight: 0}, []common.PublicHash{testFromKey}, ErrInvalidUnlockHeight},
+		{"dust amount", &LockedTransfer{From_: testFrom, To: testTo, Amount: amount.COIN.DivC(100), UnlockedHeight: 10}, []common.PublicHash{testFromKey}, types.ErrDustAmount},
+		{"not exist recipient", &LockedTransfer{From_: testFrom, To: testUnknown, Amount: amount.NewCoinAmount(10, 0), UnlockedHeight: 10}, []common.PublicHash{testFromKey}, types.ErrNotExistAccount},
+		{"invalid signer", &LockedTransfer{From_: testFrom, To: testTo, Amount: amount.NewCoinAmount(10, 0), UnlockedHeight: 10}, []common.PublicHash{testToKey}, types.ErrInvalidAccountSigner},
+		{"insufficient balance", &LockedTransfer{From_: testFrom, To: testTo, Amount: amount.NewCoinAmount(100, 0), UnlockedHeight: 10}, []common.PublicHash{testFromKey}, ErrInsufficientFee},
+	}
+	for _, tt := range tests {
+		vp, ctw := newTestVault(t)
+		if err := tt.tx.Validate(vp, ctw, tt.signers); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestVestingTransferValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		tx   *VestingTransfer
+		err  error
+	}{
+		{"ok", &VestingTransfer{Amount: amount.NewCoinAmount(10, 0), StartHeight: 10, EndHeight: 20, Installments: 3}, nil},
+		{"single installment", &VestingTransfer{Amount: amount.NewCoinAmount(10, 0), StartHeight: 10, EndHeight: 10, Installments: 1}, nil},
+		{"zero installments", &VestingTransfer{Amount: amount.NewCoinAmount(10, 0), StartHeight: 10, EndHeight: 20, Installments: 0}, ErrInvalidInstallmentCount},
+		{"too many installments", &VestingTransfer{Amount: amount.NewCoinAmount(10, 0), StartHeight: 10, EndHeight: 1000, Installments: MaxVestingInstallments + 1}, ErrInvalidInstallmentCount},
+		{"started at the current height", &VestingTransfer{Amount: amount.NewCoinAmount(10, 0), StartHeight: 0, EndHeight: 20, Installments: 3}, ErrInvalidUnlockHeight},
+		{"ended before the start", &VestingTransfer{Amount: amount.NewCoinAmount(10, 0), StartHeight: 20, EndHeight: 10, Installments: 3}, ErrInvalidUnlockHeight},
+		{"too short range", &VestingTransfer{Amount: amount.NewCoinAmount(10, 0), StartHeight: 10, EndHeight: 11, Installments: 3}, ErrInvalidInstallmentCount},
+		{"dust installment", &VestingTransfer{Amount: amount.COIN.DivC(10), StartHeight: 10, EndHeight: 20, Installments: 3}, types.ErrDustAmount},
+	}
+	for _, tt := range tests {
+		vp, ctw := newTestVault(t)
+		tt.tx.From_ = testFrom
+		tt.tx.To = testTo
+		if err := tt.tx.Validate(vp, ctw, []common.PublicHash{testFromKey}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestVestingTransferUnlockSchedule(t *testing.T) {
+	tx := &VestingTransfer{Amount: amount.NewCoinAmount(10, 0), StartHeight: 10, EndHeight: 20, Installments: 3}
+	Heights, Amounts := tx.UnlockSchedule()
+	want := []uint32{10, 15, 20}
+	if len(Heights) != len(want) {
+		t.Fatalf("got %v heights, want %v", Heights, want)
+	}
+	sum := amount.NewCoinAmount(0, 0)
+	for i, h := range Heights {
+		if h != want[i] {
+			t.Errorf("height %d: got %v, want %v", i, h, want[i])
+		}
+		sum = sum.Add(Amounts[i])
+	}
+	if !sum.Equal(tx.Amount) {
+		t.Errorf("sum of installments: got %v, want %v", sum, tx.Amount)
+	}
+}
+
+func TestLockedTransferUnlock(t *testing.T) {
+	vp, ctw := newTestVault(t)
+	tx := &LockedTransfer{From_: testFrom, To: testTo, Amount: amount.NewCoinAmount(10, 0), UnlockedHeight: 5}
+	if err := tx.Execute(vp, ctw, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !vp.Balance(ctw, testTo).IsZero() {
+		t.Errorf("locked amount is spendable before the unlock height")
+	}
+	if !vp.TotalLockedBalanceByAddress(ctw, testTo).Equal(tx.Amount) {
+		t.Errorf("locked balance: got %v, want %v", vp.TotalLockedBalanceByAddress(ctw, testTo), tx.Amount)
+	}
+	if list := vp.LockedBalanceSchedule(ctw, testTo); len(list) != 1 || list[0].UnlockedHeight != tx.UnlockedHeight {
+		t.Errorf("unexpected schedule %v", list)
+	}
+
+	b := &types.Block{Header: types.Header{Height: tx.UnlockedHeight}}
+	if err := vp.AfterExecuteTransactions(b, ctw); err != nil {
+		t.Fatal(err)
+	}
+	if !vp.Balance(ctw, testTo).Equal(tx.Amount) {
+		t.Errorf("balance after unlock: got %v, want %v", vp.Balance(ctw, testTo), tx.Amount)
+	}
+	if !vp.TotalLockedBalanceByAddress(ctw, testTo).IsZero() {
+		t.Errorf("locked balance remains after unlock")
+	}
+	if list := vp.LockedBalanceSchedule(ctw, testTo); len(list) != 0 {
+		t.Errorf("schedule remains after unlock %v", list)
+	}
+}
+
+func TestProposeUpdatePolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *Policy
+		err    error
+	}{
+		{"ok", &Policy{AccountCreationAmount: amount.NewCoinAmount(1, 0)}, nil},
+		{"nil policy", nil, ErrInvalidPolicy},
+	}
+	for _, tt := range tests {
+		vp, ctw := newTestVault(t)
+		ap := vp.admin
+		if err := ap.InitAdmin(ctw, map[string]common.Address{vp.Name(): testTo}); err != nil {
+			t.Fatal(err)
+		}
+		if err := ap.InitCouncil(ctw, map[string]*admin.Council{vp.Name(): {Members: []common.Address{testFrom}, Threshold: 1}}); err != nil {
+			t.Fatal(err)
+		}
+		utx := &UpdatePolicy{From_: testTo, Policy: tt.policy}
+		TxType, err := encoding.Factory("transaction").TypeOf(utx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		TxData, err := encoding.Marshal(utx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := &admin.Propose{From_: testFrom, Name: vp.Name(), TxType: TxType, TxData: TxData}
+		if err := tx.Validate(ap, types.SwitchContextWrapper(ap.ID(), ctw), []common.PublicHash{testFromKey}); err != tt.err {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestSubLockedBalance(t *testing.T) {
+	vp, ctw := newTestVault(t)
+	if err := vp.AddLockedBalance(ctw, testTo, 10, amount.NewCoinAmount(10, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := vp.SubLockedBalance(ctw, testTo, 10, amount.NewCoinAmount(4, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if am := vp.LockedBalance(ctw, testTo, 10); !am.Equal(amount.NewCoinAmount(6, 0)) {
+		t.Errorf("locked balance: got %v, want %v", am, amount.NewCoinAmount(6, 0))
+	}
+	if err := vp.SubLockedBalance(ctw, testTo, 10, amount.NewCoinAmount(7, 0)); err != ErrInsufficientBalance {
+		t.Errorf("got %v, want %v", err, ErrInsufficientBalance)
+	}
+	if err := vp.SubLockedBalance(ctw, testTo, 10, amount.NewCoinAmount(6, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if bs := ctw.ProcessData(toLockedBalanceKey(10, testTo)); len(bs) != 0 {
+		t.Errorf("locked balance key remains at zero")
+	}
+	if bs := ctw.AccountData(testTo, toLockedHeightNumberKey(10)); len(bs) != 0 {
+		t.Errorf("locked height remains at zero")
+	}
+	if bs := ctw.AccountData(testTo, tagLockedBalanceSum); len(bs) != 0 {
+		t.Errorf("locked balance sum remains at zero")
+	}
+
+	// the locked balance of the height is scheduled again after it is taken back to zero
+	if err := vp.AddLockedBalance(ctw, testTo, 10, amount.NewCoinAmount(3, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if list := vp.LockedBalanceSchedule(ctw, testTo); len(list) != 1 || list[0].UnlockedHeight != 10 {
+		t.Errorf("got schedule %v, want the height 10", list)
+	}
+}
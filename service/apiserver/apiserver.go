@@ -1,7 +1,9 @@
 package apiserver
 
 import (
+	"context"
 	"sync"
+	"time"
 
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/labstack/echo"
@@ -11,8 +13,9 @@ import (
 type APIServer struct {
 	types.ServiceBase
 	sync.Mutex
-	e      *echo.Echo
-	subMap map[string]*JRPCSub
+	e        *echo.Echo
+	subMap   map[string]*JRPCSub
+	isClosed bool
 }
 
 // NewAPIServer returns a APIServer
@@ -34,6 +37,22 @@ func (s *APIServer) Init(pm types.ProcessManager, cn types.Provider) error {
 	return nil
 }
 
+// Close drains requests in progress and stops the web service
+// requests that are not finished in 10 seconds are dropped
+func (s *APIServer) Close() {
+	s.Lock()
+	if s.isClosed {
+		s.Unlock()
+		return
+	}
+	s.isClosed = true
+	s.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.e.Shutdown(ctx)
+}
+
 // OnLoadChain called when the chain loaded
 func (s *APIServer) OnLoadChain(loader types.Loader) error {
 	return nil
@@ -118,6 +118,20 @@ func (s *APIServer) JRPC(SubName string) (*JRPCSub, error) {
 	return js, nil //TEMP
 }
 
+// SharedJRPC provides the json rpc feature as a SubName.FunctionName methods that is shared by multiple modules
+// it returns the existing sub of the SubName so that function names should not be overlapped between modules
+func (s *APIServer) SharedJRPC(SubName string) *JRPCSub {
+	s.Lock()
+	defer s.Unlock()
+
+	if js, has := s.subMap[SubName]; has {
+		return js
+	}
+	js := NewJRPCSub()
+	s.subMap[SubName] = js
+	return js
+}
+
 func (s *APIServer) handleJRPC(req *jRPCRequest) *JRPCResponse {
 	ls := strings.SplitN(req.Method, ".", 2)
 	if len(ls) != 2 {
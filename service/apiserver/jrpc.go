@@ -24,6 +24,9 @@ func NewJRPCSub() *JRPCSub {
 
 // Set sets a handler of the method
 func (s *JRPCSub) Set(Method string, h Handler) {
+	s.Lock()
+	defer s.Unlock()
+
 	s.funcMap[Method] = h
 }
 
@@ -37,10 +40,28 @@ type JRPCRequest struct {
 
 // jRPCRequest is a jrpc request
 type jRPCRequest struct {
-	JSONRPC string         `json:"jsonrpc"`
-	ID      interface{}    `json:"id"`
-	Method  string         `json:"method"`
-	Params  []*json.Number `json:"params"`
+	JSONRPC string       `json:"jsonrpc"`
+	ID      interface{}  `json:"id"`
+	Method  string       `json:"method"`
+	Params  []*jRPCParam `json:"params"`
+}
+
+// jRPCParam is a parameter of the jrpc request that is kept as a string
+// strings are unquoted and other values like numbers and objects are kept as json texts
+type jRPCParam string
+
+// UnmarshalJSON is a unmarshaler function
+func (p *jRPCParam) UnmarshalJSON(bs []byte) error {
+	if len(bs) > 0 && bs[0] == '"' {
+		var str string
+		if err := json.Unmarshal(bs, &str); err != nil {
+			return err
+		}
+		*p = jRPCParam(str)
+		return nil
+	}
+	*p = jRPCParam(bs)
+	return nil
 }
 
 // JRPCResponse is a jrpc response
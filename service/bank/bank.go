@@ -272,7 +272,7 @@ func (s *Bank) Init(pm types.ProcessManager, cn types.Provider) error {
 				To:         to,
 				Amount:     am,
 			}
-			TxHash := chain.HashTransaction(s.cn.ChainID(), tx)
+			TxHash := types.HashTransaction(s.cn.ChainID(), tx)
 			sig, err := s.Sign(name, Password, TxHash)
 			if err != nil {
 				return nil, err
@@ -306,7 +306,7 @@ func (s *Bank) Init(pm types.ProcessManager, cn types.Provider) error {
 			}
 			t := b.TransactionTypes[index]
 			tx := b.Transactions[index]
-			result := b.TransactionResults[index]
+			result := uint8(txResultSuccess)
 
 			fc := encoding.Factory("transaction")
 			bs, err := tx.MarshalJSON()
@@ -531,8 +531,8 @@ func (s *Bank) OnBlockConnected(b *types.Block, events []types.Event, loader typ
 	s.keyStore.View(func(txn backend.StoreReader) error {
 		for i, t := range b.Transactions {
 			TXID := types.TransactionID(b.Header.Height, uint16(i))
-			res := b.TransactionResults[i]
-			if at, is := t.(chain.AccountTransaction); is {
+			res := uint8(txResultSuccess)
+			if at, is := t.(accountTransaction); is {
 				s.removePending(at)
 
 				if tx, is := t.(*vault.Transfer); is {
@@ -543,6 +543,20 @@ func (s *Bank) OnBlockConnected(b *types.Block, events []types.Event, loader typ
 							continue
 						}
 					}
+				} else if tx, is := t.(*vault.MultiTransfer); is {
+					_, err := txn.Get(toAddressNameKey(tx.From()))
+					if err != nil {
+						isLocal := false
+						for _, To := range tx.To {
+							if _, err := txn.Get(toAddressNameKey(To)); err == nil {
+								isLocal = true
+								break
+							}
+						}
+						if !isLocal {
+							continue
+						}
+					}
 				} else {
 					_, err := txn.Get(toAddressNameKey(at.From()))
 					if err != nil {
@@ -585,6 +599,8 @@ func (s *Bank) OnBlockConnected(b *types.Block, events []types.Event, loader typ
 						s.removeUnstaking(tx.HyperFormulator, tx.From(), tx.UnstakedHeight, tx.Amount)
 					case *vault.Transfer:
 						s.addTransfer(TXID, tx)
+					case *vault.MultiTransfer:
+						s.addMultiTransfer(TXID, tx)
 					}
 				}
 			}
@@ -6,7 +6,6 @@ import (
 	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/amount"
 	"github.com/fletaio/fleta_testnet/common/hash"
-	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/core/types"
 	"github.com/fletaio/fleta_testnet/encoding"
 	"github.com/fletaio/fleta_testnet/process/formulator"
@@ -88,7 +87,24 @@ func (s *Bank) addTransfer(txid string, tx *vault.Transfer) error {
 	return nil
 }
 
-func (s *Bank) addTransaction(txid string, t uint16, at chain.AccountTransaction, res uint8) error {
+func (s *Bank) addMultiTransfer(txid string, tx *vault.MultiTransfer) error {
+	if _, err := s.db.RPush(toTransferSendListKey(tx.From()), []byte(txid)); err != nil {
+		return err
+	}
+	addrMap := map[common.Address]bool{}
+	for _, To := range tx.To {
+		if tx.From() == To || addrMap[To] {
+			continue
+		}
+		addrMap[To] = true
+		if _, err := s.db.RPush(toTransferRecvListKey(To), []byte(txid)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Bank) addTransaction(txid string, t uint16, at accountTransaction, res uint8) error {
 	if res == 1 {
 		switch tx := at.(type) {
 		case *vault.Transfer:
@@ -97,6 +113,17 @@ func (s *Bank) addTransaction(txid string, t uint16, at chain.AccountTransaction
 					return err
 				}
 			}
+		case *vault.MultiTransfer:
+			addrMap := map[common.Address]bool{}
+			for _, To := range tx.To {
+				if tx.From() == To || addrMap[To] {
+					continue
+				}
+				addrMap[To] = true
+				if _, err := s.db.RPush(toTransactionListKey(To), []byte(txid)); err != nil {
+					return err
+				}
+			}
 		case *formulator.Unstaking:
 			if _, err := s.db.RPush(toTransactionListKey(tx.HyperFormulator), []byte(txid)); err != nil {
 				return err
@@ -339,8 +366,8 @@ func (s *Bank) getTransferRecvs(addr common.Address, from int32, to int32, rever
 	return txids, txs, results, nil
 }
 
-func (s *Bank) addPending(at chain.AccountTransaction) error {
-	TxHash := chain.HashTransaction(s.cn.ChainID(), at.(types.Transaction))
+func (s *Bank) addPending(at accountTransaction) error {
+	TxHash := types.HashTransaction(s.cn.ChainID(), at)
 	if _, err := s.db.HSet(toPendingAddressKey(at.From()), TxHash[:], []byte{1}); err != nil {
 		return err
 	}
@@ -404,8 +431,8 @@ func (s *Bank) getPending(TxHash hash.Hash256) (types.Transaction, error) {
 	return t.(types.Transaction), nil
 }
 
-func (s *Bank) removePending(at chain.AccountTransaction) error {
-	TxHash := chain.HashTransaction(s.cn.ChainID(), at.(types.Transaction))
+func (s *Bank) removePending(at accountTransaction) error {
+	TxHash := types.HashTransaction(s.cn.ChainID(), at)
 	if _, err := s.db.HDel(toPendingAddressKey(at.From()), TxHash[:]); err != nil {
 		return err
 	}
@@ -0,0 +1,59 @@
+package bank
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+func TestAddMultiTransfer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := NewBank(nil, dir)
+
+	From := common.NewAddress(0, 1, 0)
+	To1 := common.NewAddress(0, 2, 0)
+	To2 := common.NewAddress(0, 3, 0)
+	tx := &vault.MultiTransfer{
+		From_:  From,
+		To:     []common.Address{To1, To2, To1, From},
+		Amount: []*amount.Amount{amount.NewCoinAmount(1, 0), amount.NewCoinAmount(1, 0), amount.NewCoinAmount(1, 0), amount.NewCoinAmount(1, 0)},
+		Tag:    []string{"", "", "", ""},
+	}
+	if err := s.addMultiTransfer("txid", tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.addTransaction("txid", 0, tx, txResultSuccess); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		key  []byte
+		want int
+	}{
+		{"sent of the sender", toTransferSendListKey(From), 1},
+		{"received of the sender", toTransferRecvListKey(From), 0},
+		{"received of the duplicated recipient", toTransferRecvListKey(To1), 1},
+		{"received of the recipient", toTransferRecvListKey(To2), 1},
+		{"transactions of the sender", toTransactionListKey(From), 1},
+		{"transactions of the duplicated recipient", toTransactionListKey(To1), 1},
+		{"transactions of the recipient", toTransactionListKey(To2), 1},
+	}
+	for _, tt := range tests {
+		values, err := s.db.LRange(tt.key, 0, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(values) != tt.want {
+			t.Errorf("%s: got %d entries, want %d", tt.name, len(values), tt.want)
+		}
+	}
+}
@@ -1,7 +1,21 @@
 package bank
 
+import (
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+)
+
 type Transaction struct {
 	Type   uint16
 	Data   []byte
 	Result uint8
 }
+
+// accountTransaction is a transaction that is sent from the account
+type accountTransaction interface {
+	types.Transaction
+	From() common.Address
+}
+
+// txResultSuccess is the result of transactions in connected blocks because a block is rejected when one of them is failed
+const txResultSuccess = 1
@@ -0,0 +1,552 @@
+package explorerservice
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/binutil"
+	"github.com/fletaio/fleta_testnet/common/factory"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
+	"github.com/fletaio/fleta_testnet/process/formulator"
+	"github.com/fletaio/fleta_testnet/service/history"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	apiDefaultLimit = 20
+	apiMaxLimit     = 100
+	// apiMaxScanBlocks is the number of blocks that are scanned by a page of transactions
+	apiMaxScanBlocks = 1000
+)
+
+// APIBlock is a block of the REST API
+type APIBlock struct {
+	Height           uint32   `json:"height"`
+	Hash             string   `json:"hash"`
+	PrevHash         string   `json:"prev_hash"`
+	LevelRootHash    string   `json:"level_root_hash"`
+	Timestamp        uint64   `json:"timestamp"`
+	Generator        string   `json:"generator"`
+	TimeoutCount     uint32   `json:"timeout_count"`
+	TransactionCount int      `json:"transaction_count"`
+	Transactions     []string `json:"transactions,omitempty"`
+}
+
+// APITransaction is a transaction of the REST API
+type APITransaction struct {
+	ID        string          `json:"id"`
+	Hash      string          `json:"hash"`
+	Height    uint32          `json:"height"`
+	Index     uint16          `json:"index"`
+	BlockHash string          `json:"block_hash"`
+	Type      string          `json:"type"`
+	Timestamp uint64          `json:"timestamp"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// APIAccount is an account of the REST API
+type APIAccount struct {
+	Address        string          `json:"address"`
+	Name           string          `json:"name"`
+	Type           string          `json:"type"`
+	Balance        string          `json:"balance"`
+	LockedBalance  string          `json:"locked_balance"`
+	FormulatorType string          `json:"formulator_type,omitempty"`
+	Stakings       []*APIStaking   `json:"stakings"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// APIStaking is a staking position of an account
+type APIStaking struct {
+	HyperAddress string `json:"hyper_address"`
+	HyperName    string `json:"hyper_name"`
+	Amount       string `json:"amount"`
+}
+
+// APIFormulator is a formulator of the REST API
+type APIFormulator struct {
+	Address    string `json:"address"`
+	Name       string `json:"name"`
+	BlockCount uint32 `json:"block_count"`
+}
+
+// APIBlockPage is a page of blocks
+// NextCursor is empty when there is no more block
+type APIBlockPage struct {
+	Items      []*APIBlock `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// APITransactionPage is a page of transactions
+// NextCursor is empty when there is no more transaction
+type APITransactionPage struct {
+	Items      []*APITransaction `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// APIRecordPage is a page of the transaction history of an address
+// NextCursor is empty when there is no more record
+type APIRecordPage struct {
+	Total      uint32            `json:"total"`
+	Items      []*history.Record `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// APIError is an error response of the REST API
+type APIError struct {
+	Error string `json:"error"`
+}
+
+func (e *BlockExplorer) initAPI() {
+	g := e.e.Group("/api/v1")
+	for _, r := range e.apiRoutes() {
+		g.GET(r.Path, r.Handler)
+	}
+	g.GET("/openapi.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, openAPIDocument(e.apiRoutes()))
+	})
+}
+
+func (e *BlockExplorer) apiRoutes() []*apiRoute {
+	return []*apiRoute{
+		{
+			Path:     "/blocks",
+			Summary:  "List blocks from the cursor height in descending order",
+			Params:   []*apiParam{cursorParam("height of the first block of the page"), limitParam()},
+			Response: APIBlockPage{},
+			Handler:  e.apiBlocks,
+		},
+		{
+			Path:     "/blocks/:height",
+			Summary:  "Get the block by the height or the block hash",
+			Params:   []*apiParam{{Name: "height", In: "path", Description: "height or hash of the block"}},
+			Response: APIBlock{},
+			Handler:  e.apiBlock,
+		},
+		{
+			Path:     "/txs",
+			Summary:  "List transactions from the cursor transaction id in descending order",
+			Params:   []*apiParam{cursorParam("id of the first transaction of the page"), limitParam()},
+			Response: APITransactionPage{},
+			Handler:  e.apiTransactions,
+		},
+		{
+			Path:     "/txs/:txid",
+			Summary:  "Get the transaction by the transaction id or the transaction hash",
+			Params:   []*apiParam{{Name: "txid", In: "path", Description: "id or hash of the transaction"}},
+			Response: APITransaction{},
+			Handler:  e.apiTransaction,
+		},
+		{
+			Path:     "/accounts/:addr",
+			Summary:  "Get the account and its balance by the address",
+			Params:   []*apiParam{{Name: "addr", In: "path", Description: "address of the account"}},
+			Response: APIAccount{},
+			Handler:  e.apiAccount,
+		},
+		{
+			Path:     "/accounts/:addr/txs",
+			Summary:  "List transactions of the address from the newest one",
+			Params:   []*apiParam{{Name: "addr", In: "path", Description: "address of the account"}, cursorParam("next_cursor of the previous page"), limitParam()},
+			Response: APIRecordPage{},
+			Handler:  e.apiAccountTransactions,
+		},
+		{
+			Path:     "/search",
+			Summary:  "Resolve a height, a block hash, a transaction id or hash, an address or an account name",
+			Params:   []*apiParam{{Name: "q", In: "query", Description: "search query"}},
+			Response: SearchResult{},
+			Handler:  e.apiSearch,
+		},
+		{
+			Path:     "/status",
+			Summary:  "Get the sync status of the explorer store",
+			Response: StoreStatus{},
+			Handler:  e.apiStatus,
+		},
+		{
+			Path:     "/formulators",
+			Summary:  "List formulators of the current candidates",
+			Response: []APIFormulator{},
+			Handler:  e.apiFormulators,
+		},
+	}
+}
+
+func apiErrorResponse(c echo.Context, code int, err error) error {
+	return c.JSON(code, &APIError{Error: err.Error()})
+}
+
+func apiLimit(c echo.Context) (int, error) {
+	str := c.QueryParam("limit")
+	if len(str) == 0 {
+		return apiDefaultLimit, nil
+	}
+	limit, err := strconv.Atoi(str)
+	if err != nil || limit <= 0 {
+		return 0, ErrInvalidLimit
+	}
+	if limit > apiMaxLimit {
+		limit = apiMaxLimit
+	}
+	return limit, nil
+}
+
+func (e *BlockExplorer) apiBlocks(c echo.Context) error {
+	limit, err := apiLimit(c)
+	if err != nil {
+		return apiErrorResponse(c, http.StatusBadRequest, err)
+	}
+	height := e.provider.Height()
+	if str := c.QueryParam("cursor"); len(str) > 0 {
+		h, err := strconv.ParseUint(str, 10, 32)
+		if err != nil {
+			return apiErrorResponse(c, http.StatusBadRequest, ErrInvalidCursor)
+		}
+		if uint32(h) < height {
+			height = uint32(h)
+		}
+	}
+
+	page := &APIBlockPage{
+		Items: []*APIBlock{},
+	}
+	for ; height > 0 && len(page.Items) < limit; height-- {
+		ab, err := e.apiBlockOf(height, false)
+		if err != nil {
+			return apiErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		page.Items = append(page.Items, ab)
+	}
+	if height > 0 {
+		page.NextCursor = strconv.FormatUint(uint64(height), 10)
+	}
+	return c.JSON(http.StatusOK, page)
+}
+
+func (e *BlockExplorer) apiBlock(c echo.Context) error {
+	param := c.Param("height")
+	var height uint32
+	if h, err := strconv.ParseUint(param, 10, 32); err == nil {
+		height = uint32(h)
+	} else if h, err := e.blockHeightByHash(param); err == nil {
+		height = h
+	} else {
+		return apiErrorResponse(c, http.StatusNotFound, ErrNotBlockHash)
+	}
+	if height == 0 || height > e.provider.Height() {
+		return apiErrorResponse(c, http.StatusNotFound, ErrNotExistBlock)
+	}
+	ab, err := e.apiBlockOf(height, true)
+	if err != nil {
+		return apiErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, ab)
+}
+
+func (e *BlockExplorer) apiTransactions(c echo.Context) error {
+	limit, err := apiLimit(c)
+	if err != nil {
+		return apiErrorResponse(c, http.StatusBadRequest, err)
+	}
+	height := e.provider.Height()
+	index := -1
+	if str := c.QueryParam("cursor"); len(str) > 0 {
+		h, i, err := types.ParseTransactionID(str)
+		if err != nil {
+			return apiErrorResponse(c, http.StatusBadRequest, ErrInvalidCursor)
+		}
+		if h <= height {
+			height = h
+			index = int(i)
+		}
+	}
+
+	page := &APITransactionPage{
+		Items: []*APITransaction{},
+	}
+	for scanned := 0; height > 0; height-- {
+		// the page is returned with the cursor of the next block when transactions are sparse
+		if scanned >= apiMaxScanBlocks {
+			page.NextCursor = types.TransactionID(height, math.MaxUint16)
+			return c.JSON(http.StatusOK, page)
+		}
+		scanned++
+		b, err := e.provider.Block(height)
+		if err != nil {
+			return apiErrorResponse(c, http.StatusInternalServerError, err)
+		}
+		if index < 0 || index >= len(b.Transactions) {
+			index = len(b.Transactions) - 1
+		}
+		for ; index >= 0; index-- {
+			if len(page.Items) >= limit {
+				page.NextCursor = types.TransactionID(height, uint16(index))
+				return c.JSON(http.StatusOK, page)
+			}
+			at, err := e.apiTransactionOf(b, uint16(index), false)
+			if err != nil {
+				return apiErrorResponse(c, http.StatusInternalServerError, err)
+			}
+			page.Items = append(page.Items, at)
+		}
+	}
+	return c.JSON(http.StatusOK, page)
+}
+
+func (e *BlockExplorer) apiTransaction(c echo.Context) error {
+	param := c.Param("txid")
+	height, index, err := types.ParseTransactionID(param)
+	if err != nil {
+		h, err := hash.ParseHash(param)
+		if err != nil {
+			return apiErrorResponse(c, http.StatusBadRequest, ErrNotTransactionHash)
+		}
+		if height, index, err = e.transactionIDByHash(h); err != nil {
+			return apiErrorResponse(c, http.StatusNotFound, ErrNotTransactionHash)
+		}
+	}
+	if height == 0 || height > e.provider.Height() {
+		return apiErrorResponse(c, http.StatusNotFound, ErrNotExistTransaction)
+	}
+	b, err := e.provider.Block(height)
+	if err != nil {
+		return apiErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	if int(index) >= len(b.Transactions) {
+		return apiErrorResponse(c, http.StatusNotFound, ErrNotExistTransaction)
+	}
+	at, err := e.apiTransactionOf(b, index, true)
+	if err != nil {
+		return apiErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, at)
+}
+
+func (e *BlockExplorer) apiAccount(c echo.Context) error {
+	addr, err := common.ParseAddress(c.Param("addr"))
+	if err != nil {
+		return apiErrorResponse(c, http.StatusBadRequest, err)
+	}
+	aa, err := e.accountInfo(addr)
+	if err != nil {
+		if err == types.ErrNotExistAccount || err == types.ErrDeletedAccount {
+			return apiErrorResponse(c, http.StatusNotFound, err)
+		}
+		return apiErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, aa)
+}
+
+func (e *BlockExplorer) accountInfo(addr common.Address) (*APIAccount, error) {
+	loader := e.provider.NewLoaderWrapper(0)
+	acc, err := loader.Account(addr)
+	if err != nil {
+		return nil, err
+	}
+	data, err := acc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	aa := &APIAccount{
+		Address:       addr.String(),
+		Name:          acc.Name(),
+		Type:          shortTypeName(encoding.Factory("account"), acc),
+		Balance:       "0",
+		LockedBalance: "0",
+		Stakings:      e.stakings(loader, addr),
+		Data:          data,
+	}
+	if e.vault != nil {
+		aa.Balance = e.vault.Balance(loader, addr).String()
+		aa.LockedBalance = e.vault.TotalLockedBalanceByAddress(loader, addr).String()
+	}
+	if frAcc, is := acc.(*formulator.FormulatorAccount); is {
+		aa.FormulatorType = formulatorTypeName(frAcc.FormulatorType)
+	}
+	return aa, nil
+}
+
+func (e *BlockExplorer) apiAccountTransactions(c echo.Context) error {
+	addr, err := common.ParseAddress(c.Param("addr"))
+	if err != nil {
+		return apiErrorResponse(c, http.StatusBadRequest, err)
+	}
+	limit, err := apiLimit(c)
+	if err != nil {
+		return apiErrorResponse(c, http.StatusBadRequest, err)
+	}
+	if e.history == nil {
+		return apiErrorResponse(c, http.StatusNotFound, ErrNotLoadedHistory)
+	}
+
+	// the cursor is pinned to the height of the first page so that new records do not shift offsets
+	To, err := e.history.Height()
+	if err != nil {
+		return apiErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	var offset uint32
+	if str := c.QueryParam("cursor"); len(str) > 0 {
+		strs := strings.Split(str, "-")
+		if len(strs) != 2 {
+			return apiErrorResponse(c, http.StatusBadRequest, ErrInvalidCursor)
+		}
+		h, err := strconv.ParseUint(strs[0], 10, 32)
+		if err != nil {
+			return apiErrorResponse(c, http.StatusBadRequest, ErrInvalidCursor)
+		}
+		o, err := strconv.ParseUint(strs[1], 10, 32)
+		if err != nil {
+			return apiErrorResponse(c, http.StatusBadRequest, ErrInvalidCursor)
+		}
+		To = uint32(h)
+		offset = uint32(o)
+	}
+	result, err := e.history.Records(addr, history.TransactionKind, 0, 0, To, offset, uint32(limit))
+	if err != nil {
+		return apiErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	page := &APIRecordPage{
+		Total: result.Total,
+		Items: result.Records,
+	}
+	if next := offset + uint32(len(result.Records)); next < result.Total {
+		page.NextCursor = strconv.FormatUint(uint64(To), 10) + "-" + strconv.FormatUint(uint64(next), 10)
+	}
+	return c.JSON(http.StatusOK, page)
+}
+
+func (e *BlockExplorer) apiStatus(c echo.Context) error {
+	st, err := e.Status()
+	if err != nil {
+		return apiErrorResponse(c, http.StatusInternalServerError, err)
+	}
+	return c.JSON(http.StatusOK, st)
+}
+
+func (e *BlockExplorer) apiSearch(c echo.Context) error {
+	sr, err := e.Search(c.QueryParam("q"))
+	if err != nil {
+		return apiErrorResponse(c, http.StatusNotFound, err)
+	}
+	return c.JSON(http.StatusOK, sr)
+}
+
+func (e *BlockExplorer) apiFormulators(c echo.Context) error {
+	list := []*APIFormulator{}
+	for _, v := range e.formulators() {
+		list = append(list, &APIFormulator{
+			Address:    v.Address,
+			Name:       v.Name,
+			BlockCount: v.BlockCount,
+		})
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+func (e *BlockExplorer) apiBlockOf(height uint32, withTxs bool) (*APIBlock, error) {
+	b, err := e.provider.Block(height)
+	if err != nil {
+		return nil, err
+	}
+	TimeoutCount, err := e.cs.DecodeConsensusData(b.Header.ConsensusData)
+	if err != nil {
+		return nil, err
+	}
+	ab := &APIBlock{
+		Height:           b.Header.Height,
+		Hash:             encoding.Hash(b.Header).String(),
+		PrevHash:         b.Header.PrevHash.String(),
+		LevelRootHash:    b.Header.LevelRootHash.String(),
+		Timestamp:        b.Header.Timestamp,
+		Generator:        b.Header.Generator.String(),
+		TimeoutCount:     TimeoutCount,
+		TransactionCount: len(b.Transactions),
+	}
+	if withTxs {
+		ab.Transactions = make([]string, 0, len(b.Transactions))
+		for i, tx := range b.Transactions {
+			ab.Transactions = append(ab.Transactions, types.HashTransactionByType(e.provider.ChainID(), b.TransactionTypes[i], tx).String())
+		}
+	}
+	return ab, nil
+}
+
+func (e *BlockExplorer) apiTransactionOf(b *types.Block, index uint16, withData bool) (*APITransaction, error) {
+	t := b.TransactionTypes[index]
+	tx := b.Transactions[index]
+	at := &APITransaction{
+		ID:        types.TransactionID(b.Header.Height, index),
+		Hash:      types.HashTransactionByType(e.provider.ChainID(), t, tx).String(),
+		Height:    b.Header.Height,
+		Index:     index,
+		BlockHash: encoding.Hash(b.Header).String(),
+		Type:      "UNKNOWN",
+		Timestamp: tx.Timestamp(),
+	}
+	if name, err := encoding.Factory("transaction").TypeName(t); err == nil {
+		at.Type = lastPathElement(name)
+	}
+	if withData {
+		data, err := tx.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		at.Data = data
+	}
+	return at, nil
+}
+
+func (e *BlockExplorer) blockHeightByHash(str string) (uint32, error) {
+	var height uint32
+	if err := e.db.View(func(txn backend.StoreReader) error {
+		v, err := txn.Get([]byte(str))
+		if err != nil {
+			return err
+		}
+		if len(v) != 4 {
+			return ErrNotBlockHash
+		}
+		height = binutil.LittleEndian.Uint32(v)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+func (e *BlockExplorer) transactionIDByHash(h hash.Hash256) (uint32, uint16, error) {
+	var v []byte
+	if err := e.db.View(func(txn backend.StoreReader) error {
+		var err error
+		v, err = txn.Get(h[:])
+		return err
+	}); err != nil {
+		return 0, 0, err
+	}
+	return types.ParseTransactionID(string(v))
+}
+
+func shortTypeName(fc *factory.Factory, v interface{}) string {
+	t, err := fc.TypeOf(v)
+	if err != nil {
+		return "UNKNOWN"
+	}
+	name, err := fc.TypeName(t)
+	if err != nil {
+		return "UNKNOWN"
+	}
+	return lastPathElement(name)
+}
+
+func lastPathElement(name string) string {
+	strs := strings.Split(name, "/")
+	return strs[len(strs)-1]
+}
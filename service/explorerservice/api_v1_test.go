@@ -0,0 +1,118 @@
+package explorerservice
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/labstack/echo/v4"
+)
+
+type testTx struct {
+	Timestamp_ uint64
+}
+
+func (tx *testTx) Timestamp() uint64 { return tx.Timestamp_ }
+func (tx *testTx) Validate(p types.Process, loader types.LoaderWrapper, signers []common.PublicHash) error {
+	return nil
+}
+func (tx *testTx) Execute(p types.Process, ctw *types.ContextWrapper, index uint16) error {
+	return nil
+}
+func (tx *testTx) MarshalJSON() ([]byte, error) { return json.Marshal(tx.Timestamp_) }
+
+// testProvider returns blocks that have the number of transactions of the height and empty blocks above them
+type testProvider struct {
+	types.Provider
+	height  uint32
+	txCount map[uint32]int
+}
+
+func (p *testProvider) ChainID() uint8 { return 1 }
+func (p *testProvider) Height() uint32 { return p.height }
+func (p *testProvider) Block(height uint32) (*types.Block, error) {
+	b := &types.Block{
+		Header: types.Header{
+			ChainID: p.ChainID(),
+			Height:  height,
+		},
+		TransactionTypes: []uint16{},
+		Transactions:     []types.Transaction{},
+	}
+	for i := 0; i < p.txCount[height]; i++ {
+		b.TransactionTypes = append(b.TransactionTypes, 1)
+		b.Transactions = append(b.Transactions, &testTx{Timestamp_: uint64(i)})
+	}
+	return b, nil
+}
+
+func TestAPITransactionsCursor(t *testing.T) {
+	// transactions of lower blocks are behind more empty blocks than a page scans
+	be := &BlockExplorer{
+		provider: &testProvider{
+			height:  3 + apiMaxScanBlocks,
+			txCount: map[uint32]int{1: 2, 2: 2, 3: 2},
+		},
+	}
+	ec := echo.New()
+
+	tests := []struct {
+		name   string
+		ids    []string
+		cursor string
+	}{
+		{"empty blocks", []string{}, types.TransactionID(3, math.MaxUint16)},
+		{"full page", []string{
+			types.TransactionID(3, 1),
+			types.TransactionID(3, 0),
+			types.TransactionID(2, 1),
+			types.TransactionID(2, 0),
+		}, types.TransactionID(1, 1)},
+		{"last page", []string{
+			types.TransactionID(1, 1),
+			types.TransactionID(1, 0),
+		}, ""},
+	}
+	cursor := ""
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/transactions?limit=4&cursor="+cursor, nil)
+		rec := httptest.NewRecorder()
+		if err := be.apiTransactions(ec.NewContext(req, rec)); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: got status %d, want %d", tt.name, rec.Code, http.StatusOK)
+		}
+		page := &APITransactionPage{}
+		if err := json.Unmarshal(rec.Body.Bytes(), page); err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Items) != len(tt.ids) {
+			t.Fatalf("%s: got %d transactions, want %d", tt.name, len(page.Items), len(tt.ids))
+		}
+		for i, at := range page.Items {
+			if at.ID != tt.ids[i] {
+				t.Errorf("%s: transaction %d: got %s, want %s", tt.name, i, at.ID, tt.ids[i])
+			}
+		}
+		if page.NextCursor != tt.cursor {
+			t.Errorf("%s: got cursor %s, want %s", tt.name, page.NextCursor, tt.cursor)
+		}
+		cursor = page.NextCursor
+	}
+
+	for _, query := range []string{"cursor=invalid", "limit=0"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/transactions?"+query, nil)
+		rec := httptest.NewRecorder()
+		if err := be.apiTransactions(ec.NewContext(req, rec)); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: got status %d, want %d", query, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
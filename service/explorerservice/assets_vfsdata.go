@@ -82,10 +82,10 @@ var Assets = func() http.FileSystem {
 		},
 		"/layout/layout.html": &vfsgen۰CompressedFileInfo{
 			name:             "layout.html",
-			modTime:          time.Date(2019, 10, 15, 17, 20, 1, 890510300, time.UTC),
-			uncompressedSize: 5711,
+			modTime:          time.Date(2020, 2, 12, 9, 0, 0, 0, time.UTC),
+			uncompressedSize: 5844,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x57\x5f\x6f\xdb\x38\x12\x7f\x76\x81\x7e\x87\x39\xb6\x6e\xec\xba\xb2\xec\xa2\x4f\x89\x64\xa0\xed\x5d\xef\x0a\xa4\xd7\x05\x36\x6f\x8b\x85\x41\x4b\x94\xcc\x0d\x45\x0a\x12\xe5\x6d\xb1\xc8\x77\x5f\x90\x94\x28\x4a\xa2\xd3\x6c\x53\xec\x3e\x45\x1e\xce\x9f\xdf\xcc\xfc\x66\xc8\x44\xf3\x94\x64\x94\x13\x40\xff\x23\x38\x25\x15\x9a\xef\x9e\x3e\x89\x8e\xfa\x1b\x68\x1a\x23\xf3\x89\x20\x61\xb8\xae\xed\xcf\xdd\xd3\x27\x00\x00\x51\x4a\x4f\xdd\x49\x22\xb8\xc4\x94\xf7\x87\x63\x85\x5a\xe2\xe4\xd6\x3d\x1c\x2b\x1c\x2a\xcc\xd3\xb1\xc2\x58\x29\x63\x42\x52\x9e\xfb\xd4\xb4\x2a\x86\x63\x45\xb2\x18\x85\x16\x72\x11\x68\xc7\xfb\x3d\x13\xb9\x08\x7e\xaf\x70\x59\x0e\x41\x4e\x7c\xd0\x22\x07\xcc\x64\x8c\x10\xd4\x55\x12\xa3\xb0\x22\xb5\x68\xaa\x84\x84\xb4\xc0\x39\xa9\xc3\x0f\xd7\xff\xb9\x79\xbb\xbf\xfe\xfc\xdf\xcf\xeb\x92\xe7\x08\xc2\x73\x68\x42\xec\x4b\x27\x4c\xe9\x69\x5c\x87\x91\x6c\xf2\x7b\x5c\x48\x28\xc4\x81\x32\x12\x14\x84\x37\x93\xa2\x62\xdd\x3a\x5c\xd3\x94\xec\x4d\xc7\xf6\x8c\xd6\x72\x6f\x6c\xf6\x52\xe4\x39\x23\x68\x50\xf7\x56\x58\xa1\xb6\x7e\xbf\xe1\x13\xae\x93\x8a\x96\xf2\xf2\xf9\xe2\xe2\x19\x23\x99\xbc\x58\xae\x71\x9a\xbe\x57\x46\x8b\x0b\xe5\x2f\x10\xfc\x62\x79\xe5\xed\x58\x5d\x62\xbe\x8b\x42\xfd\x67\x9c\xe9\xb8\x26\x3e\xb8\x2a\xad\x47\xc0\x6d\xbd\x70\x7c\x1a\x80\x56\x5e\x5b\xd0\xf0\x18\xd4\xf7\x75\xc7\x84\x0e\xd4\x1f\xe4\x4c\x90\xc2\x32\xe9\xd3\xa1\x91\x52\x70\x4b\x54\xd3\xd1\x84\x89\x9a\x20\x10\x3c\x61\x34\xb9\x8d\xd1\x24\x9f\x8a\x14\xe2\x44\xc6\x29\x99\x60\x6d\xc9\xb4\x8f\xfd\x41\x72\xb4\x8b\x68\xe7\x9f\x61\x60\xb8\x75\xbf\x8b\x42\xba\x8b\x42\x03\xc0\x37\x93\x0e\x72\x4d\xb1\x51\x7a\x3e\xda\x69\xdb\x86\xd9\x74\x54\x0b\x3d\x59\x5b\x55\x46\x07\xaa\x54\x92\x02\xa2\xb9\x24\x45\xc9\xb0\x24\x80\x4a\x9c\x93\x1b\x2a\x55\xeb\xd7\xf3\x1d\xe0\x44\xd2\x13\xf9\x37\xae\x8f\x07\x81\xab\x14\xc1\xce\x37\xec\xca\x13\xa3\xfc\x16\x81\x54\xa6\x31\xea\x0d\x9c\x52\xa4\x8e\x50\x15\x42\x75\xbc\x3b\x93\xe4\x8b\x44\x3b\x6b\xd6\xd2\xc1\x3f\xca\x86\x0c\x8c\xfe\xd0\x14\xdf\x31\x91\xdc\xd6\xc8\x49\xef\x60\x24\xe7\x93\xb4\x26\x36\xde\xa1\x93\xf8\xd3\x33\x06\x36\x37\xfa\x77\xe6\x77\x53\x61\x5e\xab\x4f\xc1\x07\x59\x4a\x57\x7e\x3e\xd7\x91\xb9\x45\x20\x87\x72\x7f\xde\xae\xf1\x3f\x93\xfd\x07\x51\x15\x0d\xc3\x52\x54\x83\xe4\x33\x47\x7c\x3e\xf7\xa1\xb1\x0d\x9f\x0d\xc4\xfe\xcc\x1d\xd3\xc7\x24\x1e\x85\x0d\x3b\x73\x77\xf9\xf7\x63\xf7\x1d\x85\x66\x75\xa8\xcf\x39\xe1\xe9\x5c\x5b\xf4\xef\x8f\x6b\x92\xc9\xb7\xea\x0a\x30\x4f\x90\x6e\x07\xa9\x8b\xc7\x16\x44\x5f\x11\x81\x16\xed\x9e\x3e\x99\x8d\xf6\xa7\x92\x7b\xb7\x67\x7b\x79\x0d\xf6\xa6\x73\x7f\xd9\x38\x7f\x7d\x6d\x2a\x10\x1d\xd2\xd3\x78\x55\x1a\xb4\x46\x54\xcb\xaf\xaa\x7f\xa5\xa8\xa9\xe2\xde\x25\x54\x84\x61\x45\x07\x7d\x7d\xce\x66\x67\xf6\xe6\x6c\x36\xf3\xb2\xcc\x50\xa9\xd5\x98\x59\x16\x51\x9e\x92\x2f\xeb\xa3\x2c\xd8\x94\x43\xd0\x69\xcf\x22\x3a\x39\x0c\x68\x22\x38\x64\x8c\x48\x6c\x72\xec\x54\x5d\x1e\xf5\xda\x9a\x8d\x68\x07\x67\x8e\xd5\x1b\x6b\x7c\xda\x92\x50\x3d\x9c\xe0\x13\xa6\x1c\xde\x1f\x31\xe5\x96\x89\xc3\x9b\x57\x87\xd6\xcc\xd4\x1f\x8c\xfa\x4b\x51\x13\x3d\xc8\xb6\x0c\xc7\x37\xbe\xe3\xc0\x84\xfe\xb9\x39\x98\x98\x75\x14\x1e\xdf\x74\x26\x5e\x87\x5d\x35\xb0\x54\x1f\x41\x21\x2a\x12\x9c\x5e\x3b\x85\xb1\x90\xda\x69\x98\xf5\x23\x60\xe9\xee\xe3\xf8\x07\x21\x64\xf7\xc6\xce\xf4\x77\x3f\xc1\xfa\xa4\x9b\x19\xf5\x02\x3d\xf3\xf4\xd4\x8a\xfb\x6b\x91\x8b\xfe\xed\x19\x85\xc6\xfe\x5c\xdc\x12\xe7\x94\x63\x5d\xab\xc1\x70\x39\xf2\x9d\x05\x3e\x3d\xbc\x69\x37\x9a\xa5\x71\x4a\xeb\x92\xe1\xaf\x97\xc0\x05\x6f\x19\xec\x10\xd8\xf5\xaa\x8b\xd4\xb7\x2d\xab\x44\x71\x23\x4a\x67\xf1\x3d\x73\x66\x55\xed\xcb\xf7\xea\x73\x21\x8f\xb4\x5e\x22\xc7\x21\x09\xf4\x1e\xd4\xfb\xaa\xaf\x7e\xef\xb8\xac\xc8\x89\x8a\xa6\xfe\x81\x9e\x4b\x1c\x23\xde\x14\xdf\xef\x71\x7b\x0e\x2c\xd7\x84\xfc\xd1\x25\x90\xe2\x9d\x90\x52\x3c\x02\xb0\xeb\xb9\x65\xb6\x65\x85\x79\x5c\xb7\xfc\xcc\x1a\xae\x07\x05\xfa\x56\x2f\x6a\x89\x2b\xf9\x0a\xa4\x90\x98\xbd\x82\x9a\xe6\x7c\x09\x7f\x28\x88\x34\x83\x85\xfa\x09\xff\x8a\x21\xd8\xb6\xc2\x99\x56\x87\xd8\xe8\x43\x00\xfa\xb7\x3a\xb9\xeb\xaf\x91\x13\xae\xe0\x79\x1f\x02\x62\x78\xbe\x40\xcf\x3c\xbc\x5c\xae\x13\x26\x38\x59\x2c\xaf\x86\xc6\x84\xa7\xff\x6f\x0a\x88\xa1\xc4\x55\x4d\x3e\x72\xb9\xd0\xe1\xc2\xed\xc6\xd5\x54\x00\xb5\x7c\xbe\xdd\x28\x90\x1b\x8d\xd1\xbd\xe1\x8c\x9f\xd5\xca\x31\x72\x60\xf6\x80\xd6\x84\xa7\x3f\xe1\x9c\x40\xdc\x9a\x8c\xf0\x24\x4d\x55\x11\x2e\x47\x98\x4c\xf0\x40\x57\x60\xa9\xb0\xf5\x46\xbd\xc1\x6a\xe5\x0d\xd8\x2a\xb4\x41\x7b\xf5\x2b\xf7\x3a\x56\x09\xba\xa1\x63\xd8\x4e\x52\x74\xea\xbc\xce\x28\x4f\x17\x68\x6d\x67\xca\xf9\x17\x4a\x8d\x3e\x3e\x30\x92\xa2\xe5\x37\xed\xbb\x61\xff\x96\xf9\xdd\x7d\x48\x4d\x1d\x1f\x02\x57\x4f\xd5\x77\x42\xb5\xb3\xf3\x00\xac\xc3\x96\xea\xae\x7d\x54\xf7\xae\xdb\xd2\x3e\x07\xd5\xcf\x97\xdb\xcd\x94\x98\x9d\xcd\x27\x2c\x8f\xeb\x82\xb6\x03\xa4\xa5\xab\xed\xe6\x55\xc7\xb9\xad\xcb\xd4\x41\xb0\xce\x47\xb0\xdd\x0c\x9d\x73\x4d\xaf\x69\x96\xbf\x94\x38\xbe\xe0\x4d\x71\xf1\x2b\x72\x9d\x66\xa2\x82\x85\xb2\xa3\x10\xbb\x11\xae\x80\x42\xd4\x23\xbd\x02\xba\x5a\x4d\xfa\xa0\x1a\x46\x61\x07\x1b\x78\xf1\x42\xe9\x9f\x6d\x98\x05\x07\xb1\x02\xd8\x0d\xec\x54\xa9\x43\x8b\xd1\x52\xbf\x64\x16\xd4\x05\x7b\x9e\x28\xd4\x1b\xd2\x78\xec\x7b\xda\x3e\x9c\x7c\x2e\xef\x3c\x58\x9a\x62\x7d\x20\x99\xa8\xc8\x82\x8f\x4d\xee\xbc\x04\x56\x16\xe6\x9d\xe9\xe6\x96\x08\x5e\x0b\x46\xd6\x4c\xe4\x2e\xea\x15\x20\xb8\x04\x04\xab\x6e\x51\xf5\x82\x76\x27\x2c\x1c\xf5\x60\xab\x88\xb4\x5c\x3a\x7e\x87\xeb\xb0\x2b\x98\xdb\x79\x2d\xe8\x4c\x2c\x79\xdd\xfd\xdd\x5e\x0a\x37\xea\x52\x70\x2b\xa8\x97\xaf\xba\x2a\xf4\xda\xd5\xc7\xeb\x12\x2b\x2c\x93\x35\x3b\xa0\x65\xb0\x1d\xad\x56\xed\x64\x7d\xc4\x75\xdb\x02\xbb\x16\x26\x0d\x1b\xf8\x71\x47\xe6\x0e\x08\xab\x89\xd7\x5b\xbf\xa4\xee\x77\xe7\xdf\x99\xc1\xeb\x87\x45\x31\xbb\xe5\x7b\x22\x3c\xcc\x7f\xbf\x7f\x1e\x1c\xa3\xbd\x6a\x06\xe5\x6e\x23\xdc\xe7\xc1\x44\x1e\x8d\xd8\x32\xd8\x7a\xd9\x9c\x13\x9d\x83\xb3\x9a\x5e\xda\xeb\xe9\x4e\x3d\x0e\xec\xab\xa0\x7d\x76\xfe\x19\x00\x00\xff\xff\x2e\x0c\x32\xc5\x4f\x16\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xc5\x57\x5b\x6f\xdb\x36\x14\x7e\x76\x7e\x05\xa7\x36\x8d\x5d\x47\xbe\x14\x7d\x18\x12\xdb\x40\x2f\xcb\x5a\x20\x6d\x07\xcc\x0f\x03\x86\xc1\xa0\x25\xda\xe2\x42\x91\xaa\x48\xbb\x2d\x86\xfc\xf7\x1d\x5e\x24\x51\x32\x9d\x66\x59\xb0\x05\x70\x2c\x1f\x9e\xcb\x77\xee\xd4\xec\x34\x25\x1b\xca\x09\x8a\xde\x11\x9c\x92\x32\x3a\x5d\x9c\xcc\x32\xf3\x88\x68\x3a\x8f\xec\x63\x84\x12\x86\xa5\xac\x7f\x2e\x4e\x10\xfc\xcd\x52\xba\xaf\x0e\x12\xc1\x15\x06\x3d\xd5\x59\xf7\x5c\x2a\x9c\xdc\x78\x67\xdd\xf3\x75\x89\x79\xda\x39\xef\xf2\x6c\x98\x50\x94\x6f\x03\x5c\x86\x13\xa3\xac\x24\x9b\x79\x34\xae\xd1\xe6\xb1\x51\xbb\x5a\x31\xb1\x15\xf1\x97\x12\x17\x45\x0b\xe0\x81\x0a\x9a\x6f\x11\x66\x6a\x1e\x45\x48\x96\x09\xa8\x2a\x89\x14\xbb\x32\x21\x63\x9a\xe3\x2d\x91\xe3\xab\xeb\x9f\x96\xaf\x56\xd7\x9f\x7e\xfe\x34\x2a\x00\x09\x1a\x1f\xc1\x32\xc6\x01\x57\xc6\xe0\x4b\x27\x02\x6d\x52\xf7\x67\x37\x7e\x28\x17\x6b\xca\x48\x9c\x13\xbe\xeb\xc6\x12\x9b\x74\x61\x49\x53\xb2\xb2\x59\x5a\x31\x2a\xd5\xca\x8a\xac\x94\xd8\x6e\x19\x89\x5a\xe1\x76\x44\xc8\xae\x0d\xdc\x9f\x78\x8f\x65\x52\xd2\x42\x5d\x3c\xed\x9f\x3d\x61\x64\xa3\xce\x06\x23\x9c\xa6\x6f\xb4\x50\xff\x4c\xeb\x8b\x05\x3f\x1b\x5c\x86\x12\x25\x0b\xcc\x17\xb3\xb1\xf9\x3a\xb9\x33\x18\x21\xac\xda\xa5\x7f\x81\xd5\x69\xe1\x78\xdf\x42\xac\xb5\x3a\xc4\xe8\xc1\x90\xef\xc8\x8a\x35\x1b\xeb\xaf\xc8\x6b\x17\x8d\xa3\x9b\x9f\xf5\x4e\x29\xc1\xeb\xca\xb4\x89\x4c\x98\x90\xe0\xa8\xe0\x09\xa3\xc9\xcd\x3c\x3a\x70\xa5\x24\xb9\xd8\x93\xae\x37\xd6\x96\x8b\x96\xd1\xb1\x5a\x2b\x1e\x2d\x66\xb4\xd2\xcf\x30\x62\xd8\xa9\x07\x0f\x29\x7c\x2c\x80\x40\x07\x7a\xb8\x4d\x61\x75\x9c\x0b\x14\x9b\x11\xdd\xb1\xda\x19\x9d\xbb\x43\x97\x6b\x4e\x46\x5b\x9c\x54\x91\x1c\xcd\x4e\xe1\x7f\xc1\xb0\x82\xc9\x53\x40\x6b\x2d\xa9\xd2\x29\x1f\x9d\x2e\x10\x4e\x14\xdd\x93\xb7\x58\x66\x6b\x81\x4b\x08\xec\x22\xd4\xda\x5a\x13\xa3\xfc\x26\x42\x4a\x8b\xce\xa3\x46\xc0\x8b\x43\xea\x11\x75\x14\x74\xaa\xab\x33\x45\xbe\xaa\x68\x51\x8b\xb9\x3a\x08\xb6\xae\x2d\x03\x46\x1f\xd3\xc1\xd7\x4c\x24\x37\x32\xf2\x9c\x5b\x5b\xca\x71\x17\x6b\x91\xda\xde\xba\xa2\x84\x9d\xb3\x02\xb5\x67\xf4\xbf\xf3\x6e\x09\x4d\x2b\xf5\xa3\xe0\x2d\x1f\x95\x4f\x3f\xee\x69\x47\xbc\x46\xa0\xda\xf4\xb0\xd7\xbe\xf0\xff\xe1\xfb\x95\x28\xf3\x1d\x9c\x8b\xb2\xe5\xfa\xc6\x23\x1f\xf7\xbc\x2d\x5c\x9b\xdf\xb4\xc8\x61\xbf\x3d\xd1\x87\xbb\x3d\x1b\xef\x58\x80\xaa\xed\x23\x1b\x53\x70\x45\x12\x5c\x26\x59\x84\x72\xa2\x32\x01\xe3\x63\x4b\x54\x77\x6c\x54\x2c\x52\x7d\xd3\x6e\xa5\x54\x42\xc8\xbe\x5d\x20\xca\xc1\x5d\x12\x9b\xc2\xbd\x44\x39\x2e\xb7\x94\xc7\x7a\xd5\x5c\xa0\x17\x93\xe2\xeb\xe5\xb1\x21\x42\x79\xb1\x53\x48\x7d\x2b\x88\x73\x17\x71\x9c\xc3\xf3\xe7\x08\x81\xe2\x84\x64\x82\x81\xd9\x39\x5c\x63\xe8\x36\x53\xe7\xe8\x1d\xf4\xf5\x39\x5a\xfe\xf6\xfe\xed\x39\x7a\x95\xa6\xb0\xc8\x25\x12\x25\xfa\x08\x42\x35\xa8\x2f\x34\x55\x19\xd8\xfd\xd1\x18\x0e\x2d\xf3\x99\xc9\x5a\x70\x69\x87\xf6\x83\x7b\x9c\x8d\x6d\x10\xe0\xe9\x94\xf0\x14\xae\x54\xf0\x50\xdd\xb3\xae\xc1\xd7\x57\x7a\xf5\x99\xab\x56\x35\x80\x75\x04\xea\x10\x9a\xcd\x68\x82\x02\xd1\xe8\x75\x56\x87\x26\x07\x17\x87\xdb\xd7\xad\x95\xe1\xad\xec\xda\xcc\x3f\xde\x18\x00\xa1\x82\xb9\xef\x2e\x09\x0b\xd5\x92\x5c\x54\x0b\x21\xa9\xae\x93\x0b\x54\x12\xa8\x46\x68\x08\x9d\xd4\x5e\x2f\xbc\x31\x7a\x70\x10\xea\x32\xdb\x4a\x96\xa1\x57\x37\x11\xe5\x29\xf9\x3a\xca\x54\xce\x0e\x5b\x08\x39\xe6\xde\x8c\x1e\x9c\xc5\x14\x2e\xa8\x68\xc3\x88\xc2\xd6\x3b\xc7\xe9\x37\x51\xc3\x6c\x5a\x31\x5a\xa0\x23\xc7\xfa\x22\xd9\x3d\x75\x1d\xa8\xaf\x87\xe8\x03\x5c\x84\xd1\x9b\x0c\xfe\xd7\x6d\xd8\xba\x69\x18\xcb\xba\x2b\xcd\x37\xa3\xc1\x20\x48\x62\x9a\xad\x0a\x40\xf6\x32\x74\x1a\x5b\xb3\xbf\xee\xd6\xd6\x1e\xf4\x7d\xf6\xd2\x49\x04\xd5\x55\x71\x80\xb4\xc0\x43\x9c\x8b\x92\xc4\xfb\x17\x4d\x48\x2a\x38\x76\x0a\xf4\xaa\x52\xaf\xea\xfa\xb0\x9a\xaf\x84\x50\xee\xad\x61\x63\x1e\x9b\x81\x65\x0e\x5c\x5f\xe8\x8b\xf5\x91\x1b\xb5\xe1\x5b\x5d\xc3\x0d\xbd\xbe\x52\xeb\xae\xd3\xd4\xa0\x45\x18\xb9\x94\x63\x13\x1b\xbf\x81\x3c\xf2\xa2\x82\x7b\x78\xb6\x74\x93\xfb\x70\x2e\x71\xc1\x6d\x9d\x7a\x65\xea\xeb\x3c\x69\xa5\x68\x53\x8a\x7c\x29\x0a\x6f\xbc\x3f\xf1\xba\x51\x6f\x85\x37\xfa\xb1\xaf\x32\x2a\x07\x91\xa7\x0f\xda\x5a\x4f\x7b\x33\x95\xeb\x60\x37\x7a\x8b\x92\xec\xa9\xd8\xc9\xc7\x53\x5c\xe0\x79\xc4\x77\xf9\xc3\x15\x4e\x8f\x40\xe5\xa6\xf4\x1e\xd9\x7f\x25\x5e\x0b\x18\x3a\xf9\xa3\x28\xb6\x45\x5c\x15\x83\x7d\x5b\xb0\xf5\xb8\xd9\x71\xd3\x0e\xa8\xc9\x70\x1f\xde\xb0\x4a\xd8\x1b\x4a\x28\xcc\xce\x91\xa4\x5b\x3e\x40\x7f\x01\x3a\xba\x41\x7d\xfd\x0b\xfd\x30\x47\xf1\xd4\xd2\x7a\x86\x19\xcd\x2d\x37\x8a\x91\xf9\x0d\x07\xb7\xf5\x56\xd8\xe3\x12\x3d\x6d\xd4\x03\xef\xd3\x7e\xf4\x24\x50\x89\x83\x11\x0c\x5d\x4e\xfa\x83\xcb\x96\x2c\xd4\xfd\xc7\x5d\x0e\x62\x05\x2e\x25\x79\xcf\x55\xdf\xd8\x1a\x4f\x27\x1e\xa3\xc6\x66\xc8\xa7\xd3\x89\xc6\x37\xd1\xf0\xfc\x5d\x65\xb5\x0c\x87\x8d\x48\x83\xb0\xc1\x32\x02\xb6\x5f\x20\x84\x60\xcd\x0a\xb4\xa1\x24\xbb\xb2\x24\x5c\x75\xe0\x58\xc3\xb1\xf1\x7c\xa0\x61\xd5\x32\x0d\xff\x70\x18\xb2\xe6\xce\x9d\xc5\x86\xfb\xf2\xa4\xe5\x98\x6f\x76\x8e\xa6\x5d\xd7\xbc\xe0\x8e\x60\x32\xa4\xfd\x68\x54\x77\x8f\xf7\x0e\xa8\x3b\x1c\xaf\x19\x49\xa3\xc1\xf7\xc4\xab\xa6\xfe\x8e\xf4\xed\x1d\x28\x6d\xf8\xee\x01\xd5\x74\xcf\xc3\x60\xd6\x3d\xf2\x5d\x9c\xad\x2c\x9a\x44\xbd\xd7\xfb\xd3\xcf\x62\x03\x5f\xa7\xf0\xf9\x74\x72\x50\x85\x95\xc8\x07\xac\xb2\x51\x4e\x5d\xa3\x18\xea\x70\x3a\x39\xaf\x4a\x6c\xea\x95\x65\xcb\x54\xa5\x22\x9e\x4e\x5a\xaa\xb9\x29\xa7\x43\xff\x7e\x87\x89\x75\x06\x87\x67\x7f\x44\x9e\x4a\xb8\x86\xa1\xbe\x16\xa3\x20\xe4\xe9\xbf\x04\xc2\xac\x81\x09\x3f\x87\xc3\x6e\xf8\x75\x9a\x28\x5a\xa0\x09\x7a\xf6\x4c\xb3\x1f\x4b\x53\x8d\x0c\x4c\x00\x80\xaa\x2f\x0f\x78\x2a\xa4\x18\x32\xa0\xaf\x22\x7d\xea\x01\x3d\x5e\x1b\x34\x64\xcf\xea\x6b\xf2\xe8\xae\x3d\x01\x85\xb7\x87\x38\x00\xe3\x9a\x40\x64\x48\x9f\x77\x04\x6e\x03\xd5\xaa\xd9\xed\xe5\xd0\xf3\x09\x6e\x01\x52\x30\x32\x62\x62\xeb\xc3\x1d\xa2\x08\x5d\xc0\x67\x58\xcd\xa1\x86\xe0\xfa\xbe\xef\xb1\xc3\x58\x84\xca\x19\x0c\x1a\xb5\xed\x59\x57\xc5\xc9\x4f\xb6\x21\x38\x09\x57\xa9\xfe\x54\x76\x63\x7e\xa9\xc7\xbc\x17\x36\x33\x55\xf5\xec\x37\xf3\xd4\x9c\x8e\xa0\x96\x01\x46\x77\x7e\xb6\x6a\x30\x9e\xb6\x67\xa6\x51\x31\xca\xb0\x74\x51\xaf\x3b\xbf\x9b\xa2\x96\x16\xaf\x37\x6e\x11\x61\x92\x04\x75\x35\x43\xe8\x4e\x65\xe1\x79\x18\xbf\xb8\x97\x0d\x3b\x3d\x1e\xa0\xff\x5e\xda\x9b\x01\x73\x5f\x0b\x6e\x7f\xf8\x61\x76\xfa\xef\x90\xb7\x66\x3b\xad\x34\x88\xa7\x81\xd2\x85\xf7\x4b\xad\xdf\x1b\x3d\xcf\xab\x85\x73\x0b\x0b\xbe\x5a\xed\xee\xb6\xf8\x37\x92\x57\x1e\x56\xd4\x16\x00\x00"),
 		},
 		"/resource": &vfsgen۰DirInfo{
 			name:    "resource",
@@ -403,6 +403,13 @@ var Assets = func() http.FileSystem {
 			name:    "view",
 			modTime: time.Date(2019, 10, 15, 17, 20, 1, 927518600, time.UTC),
 		},
+		"/view/address.html": &vfsgen۰CompressedFileInfo{
+			name:             "address.html",
+			modTime:          time.Date(2020, 2, 12, 9, 0, 0, 0, time.UTC),
+			uncompressedSize: 3617,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xad\x57\x6d\x6f\xdb\x36\x10\xfe\x9e\x5f\xc1\xb1\x35\x24\x43\x95\x94\xe4\x63\x23\x29\x58\xd1\x15\x6b\xb1\x65\xc3\xe2\x0f\x03\x86\xc1\x60\x24\xda\x96\x4d\x8b\x1a\x49\x39\x0e\xd6\xfe\xf7\x1d\xa9\x17\x4b\xd6\x8b\x83\x62\xfa\x10\x53\xc7\xe7\xee\x78\x77\xcf\xf1\x94\x60\x96\xd0\x55\x9a\x51\x84\x37\x94\x24\x8f\xb1\x48\x73\x85\x67\xd1\x55\x20\xcd\x32\xba\x42\xf0\xbc\xb5\x57\x45\x16\xab\x94\x67\xc8\x9e\xa3\x7f\x8d\x4c\x3f\x07\x22\x90\x54\x02\x85\xc8\x0a\x66\x69\x96\xd0\x23\xf2\x10\x26\x71\xcc\x8b\x4c\x7d\x24\x8a\x80\x25\xeb\xae\x81\xa7\x2b\x64\x1b\x78\x88\x30\x6e\xdb\x29\x7d\xe0\x37\x69\xb6\xe2\x1f\x78\xf2\x82\xe7\x1e\xc9\x73\x9a\x25\xb6\x15\x00\x3e\x66\x44\xca\x10\x0b\xfe\xec\xd2\x03\xcd\x70\x14\xa8\x4d\xf4\x93\x10\x5c\x04\x3e\xac\x02\x95\x44\x0f\x5c\x21\x7a\x4c\xa5\x42\x95\x77\xd8\x49\x22\xf8\x23\x22\x6b\xde\xf1\x23\xa8\x2a\x44\xd6\x88\xbe\x75\x82\x39\x40\x28\x5f\x1e\x7f\x7b\xf0\x72\x22\x24\xd5\x87\x9d\x5f\x75\x00\x29\x00\xae\x3b\x92\xb7\xf5\xa1\x61\xa7\x1b\x43\x03\x6b\x92\x97\x17\xea\x0f\xfe\x8c\xec\xdd\x3b\x50\x65\xbd\x0c\xd4\xaa\x63\xc1\x5b\x8e\x6d\xa7\x8e\x33\xbb\x0d\xc3\xeb\xf9\xbd\xa5\x73\x61\xbd\xb7\x78\x92\xdc\x58\x73\xc7\x2a\xd3\x62\x39\x3b\xc7\x6a\xd2\x62\x39\xe0\xc7\xbc\xf7\x93\x71\x8a\xbc\x3c\x96\x8d\x7f\x4c\x12\x41\xa5\xc4\x70\x3a\x8f\x94\xeb\x79\x0f\xf4\x40\xf6\xd4\x20\x32\x58\xf4\xb7\x17\x2f\x79\xb9\xad\x60\x31\xef\x94\xfe\xe0\xad\xb8\xd8\x17\x8c\x28\x2e\x96\x66\xfb\x2c\x01\xb5\x8d\x4f\x0d\x0c\x35\xe6\xce\x55\x27\xc2\xf8\x40\x18\xc9\xe2\x52\xed\xa9\x5c\xf7\xcf\xf9\x0b\x8f\x77\x34\x41\x6d\x2c\x33\xa2\x65\x4f\x05\x5c\xc3\xe1\xa1\xd4\x5b\x5d\x7c\x74\x07\xbf\x01\xc0\xa5\x22\xbb\x34\x5b\x4b\x8f\xd1\x6c\xad\x36\x5a\xee\x38\xe7\x21\x99\x0e\x01\xb5\x13\xfc\xaf\xed\xdf\x83\x41\x3f\x96\xfb\x70\x12\x2b\x20\x68\x23\xe8\x2a\xc4\x7e\x55\x85\x7b\xfd\x1b\x5a\x8e\xf4\x36\x10\xbb\x58\x56\x62\x5d\xf3\x93\x50\xd7\x43\x97\x9a\x44\x48\x0b\xc9\x5e\xb7\x41\x3b\x4f\x5d\xda\xaa\x63\x8b\xb4\xe5\x4b\x8b\xb2\x1a\x12\x17\x42\x72\xdd\xdc\x18\xf7\xa9\xbc\xa6\xea\x77\xb2\xa6\xdd\xfb\xa0\xd6\x2c\x04\xd3\x6a\x3e\xc9\x53\xff\x70\xe3\x57\x2d\x29\x7d\xec\x34\xc4\x72\xb0\xaf\x8e\x12\x77\x54\x35\x49\x2a\xa7\x3f\x0c\x5d\x11\xfa\xd1\xa6\x1d\xd8\xbc\x2f\x81\x21\x76\xca\x45\x07\xf8\xad\xdb\x57\x1e\x1c\x56\x77\xb5\x0d\xca\xef\x4e\x21\xd8\x39\x04\x30\xe4\x63\xb0\xe0\x1a\xec\xa5\x8a\xee\xa7\x0b\xde\x4e\x83\xa0\x31\x18\x38\x29\x9e\x97\xbe\x39\x60\x99\xfe\xa9\xb6\xdf\x4e\x34\x3d\xb4\x76\x43\x18\x49\x89\x88\x37\xf7\xff\x00\x59\xc0\xbb\xa7\x8e\x69\x52\xb2\xe4\xf4\xa6\x19\x52\x5e\x08\xe6\x82\xd0\x1b\x1b\x9a\xae\x37\xaa\xbe\x27\x1a\xb1\x6e\xb4\x86\x56\x23\xd7\x69\x3f\xdd\xf5\x65\xae\x8e\x0b\xae\x08\x83\xbb\x5c\xd1\xa3\x32\xc9\xf6\x94\x96\x20\x07\x61\xb4\x10\x24\x93\xc4\x14\x42\xe2\xbe\xc9\x86\x7b\x46\x2d\x03\x03\xcb\x4a\xf4\xf5\x6b\x9b\x8f\x6d\x87\x7b\x2e\xe8\x02\x38\x05\x0e\xf9\x7a\xcd\x68\x97\x4b\x5d\x82\x0c\xdd\x1f\x5d\x1b\x31\x4b\xe3\xdd\xc8\xdc\xd3\x4f\xd5\x00\xf6\xd0\x74\x41\x2b\xc2\x24\xbd\x1a\xf0\xd6\xd5\x82\x8d\xc0\xaf\x07\x6d\x30\x83\xe2\xc3\xe4\x85\x45\x3d\x92\x75\xf0\x8b\x54\x31\x0a\x73\xb4\xba\x9c\x07\x50\x9f\x38\x57\x54\x7c\xce\x62\x56\x24\xb4\x37\xc1\x91\x14\x31\x10\x03\x74\x79\x21\x62\xea\x6f\xa5\x1f\xf3\xfd\x9e\x67\xde\x56\x02\x7b\xa6\xdc\xaf\x18\x55\xc4\x5c\x0c\xda\x5c\x92\x1e\x5a\xac\xc4\xe5\x97\x41\x5b\x1a\x73\xe6\x1e\x99\x7b\x73\x5b\xed\x9d\xef\xe7\x5c\x40\x28\xaa\xb5\x3b\x82\x58\x3e\x69\x9f\x51\xaf\xc8\x6d\xe8\xde\xad\xc0\xa8\x59\xb9\xee\x13\x17\x09\x15\x34\x71\x25\xdd\xa7\xed\x8d\x55\xc1\x98\x5b\xb2\x1c\x0d\x18\x1e\x35\xbe\x1c\x3b\x4a\xa3\xa5\xc8\x13\xa3\xb5\x5e\xf9\x62\xd2\xe6\xf6\xd6\xb7\x13\x66\x8c\x29\xc8\xdf\x5a\xf0\x22\x9f\x86\xd5\x50\xf4\x9c\x26\x6a\x13\xe2\xdb\xeb\xd9\x25\xc3\xfe\xeb\x2c\x07\x4a\x07\x8b\xd2\x24\xc4\xcd\x47\xcc\x25\xcb\x46\x65\x22\x3d\xbe\x09\x7d\x24\xe3\x3e\xa4\x7c\xa0\xca\x7d\xf1\x99\xe8\xfc\xf5\xbb\x39\x86\x32\xee\x2a\xdd\x5f\xee\x18\xe3\xa6\xcb\x3b\x46\x25\xa5\xbf\xa5\xa7\x48\x23\x2e\x55\x62\x13\x2d\xfe\xfc\xfc\xd1\x7c\xc7\x5d\x44\xfe\x6c\x78\xfd\x3a\xac\xfe\x9e\x9a\x46\x9a\x4b\x7e\xa4\x5e\x13\x71\xfd\x0f\x31\x27\x91\x19\x18\x66\xd4\x5c\x82\x1a\x96\xd6\x13\x26\x7a\x95\xca\x05\xd4\x77\xc7\xdd\x34\x4d\xf5\x11\x35\x6a\x64\xb8\x55\x46\x5b\xa4\x19\xea\x6f\xb0\x31\x5f\x8f\xa6\x86\xc8\x30\x1c\x5c\x96\x66\x3b\x0c\xff\x83\xbd\x30\x1a\xe2\x24\x95\x39\x23\x2f\xef\x81\xd6\x19\xbd\xc3\xd1\xaf\xa0\xa1\xa7\xfd\x2b\x7b\xa9\x5a\xd6\x3f\xd5\x3c\xf8\x0f\x3a\xaa\xcf\x50\x21\x0e\x00\x00"),
+		},
 		"/view/blockDetail.html": &vfsgen۰CompressedFileInfo{
 			name:             "blockDetail.html",
 			modTime:          time.Date(2019, 10, 15, 17, 20, 1, 923517800, time.UTC),
@@ -550,6 +557,7 @@ var Assets = func() http.FileSystem {
 		fs["/resource/js/txs/paginationTxs.js"].(os.FileInfo),
 	}
 	fs["/view"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
+		fs["/view/address.html"].(os.FileInfo),
 		fs["/view/blockDetail.html"].(os.FileInfo),
 		fs["/view/blocks.html"].(os.FileInfo),
 		fs["/view/email.html"].(os.FileInfo),
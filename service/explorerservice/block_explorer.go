@@ -4,12 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fletaio/fleta_testnet/core/backend"
@@ -17,6 +18,9 @@ import (
 	"github.com/fletaio/webserver"
 
 	"github.com/fletaio/fleta_testnet/pof"
+	"github.com/fletaio/fleta_testnet/process/formulator"
+	"github.com/fletaio/fleta_testnet/process/vault"
+	"github.com/fletaio/fleta_testnet/service/history"
 
 	"github.com/fletaio/fleta_testnet/common/binutil"
 	"github.com/fletaio/fleta_testnet/common/factory"
@@ -52,12 +56,22 @@ var (
 	ErrAlreadyRegistrationBlock = errors.New("Already registration block")
 	ErrNotBlockHash             = errors.New("This hash is not a block hash")
 	ErrInvalidHeightFormat      = errors.New("Invalid height format")
+	ErrInvalidCursor            = errors.New("Invalid cursor")
+	ErrInvalidLimit             = errors.New("Invalid limit")
+	ErrNotExistBlock            = errors.New("Not exist block")
+	ErrNotExistTransaction      = errors.New("Not exist transaction")
+	ErrNotFoundSearch           = errors.New("Not found search result")
+	ErrNotLoadedHistory         = errors.New("History service is not loaded")
 )
 
 // BlockExplorer struct
 type BlockExplorer struct {
+	sync.Mutex
 	types.ServiceBase
 	provider               types.Provider
+	vault                  *vault.Vault
+	formulator             *formulator.Formulator
+	history                *history.History
 	transactionCountList   []*countInfo
 	CurrentChainInfo       currentChainInfo
 	lastestTransactionList []txInfos
@@ -74,7 +88,10 @@ type BlockExplorer struct {
 
 	MaximumTps int
 
-	port int
+	port     int
+	isClosed bool
+	// backfilling is set atomically so that connected blocks are skipped without waiting for the lock
+	backfilling int32
 }
 
 type countInfo struct {
@@ -84,7 +101,7 @@ type countInfo struct {
 
 //NewBlockExplorer TODO
 func NewBlockExplorer(dbPath string, cs *pof.Consensus, port int) (*BlockExplorer, error) {
-	DB, err := backend.Create("badger", "./ndata/explorer")
+	DB, err := backend.Create("badger", dbPath)
 	if err != nil {
 		panic(err)
 	}
@@ -132,63 +149,49 @@ func (e *BlockExplorer) Name() string {
 }
 func (e *BlockExplorer) Init(pm types.ProcessManager, p types.Provider) error {
 	e.provider = p
+	if vp, err := pm.ProcessByName("fleta.vault"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vp.(*vault.Vault); is {
+		e.vault = v
+	}
+	if fp, err := pm.ProcessByName("fleta.formulator"); err != nil {
+		//ignore when not loaded
+	} else if v, is := fp.(*formulator.Formulator); is {
+		e.formulator = v
+	}
+	if hs, err := pm.ServiceByName("fleta.history"); err != nil {
+		//ignore when not loaded
+	} else if v, is := hs.(*history.History); is {
+		e.history = v
+	}
 	return nil
 }
 
-var reverseOrderedTx = []byte("orderedTx")
-
 func (e *BlockExplorer) OnLoadChain(loader types.Loader) error {
+	if err := e.checkSchema(); err != nil {
+		return err
+	}
+	e.Lock()
+	e.loadLastestTransactions()
+	e.startBackfill()
+	e.Unlock()
+
 	go e.StartExplorer(e.port)
+	return nil
+}
 
-	func(e *BlockExplorer) {
-		initHeightKey := []byte("initHeightKey")
-		var startHeight uint32
-		e.db.View(func(txn backend.StoreReader) error {
-			value, err := txn.Get(initHeightKey)
-			if err != nil {
-				if err != backend.ErrNotExistKey {
-					return err
-				}
-				startHeight = 0
-			} else {
-				startHeight = binutil.LittleEndian.Uint32(value)
-			}
+// Close stops the backfill and closes the store after the batch in progress
+func (e *BlockExplorer) Close() {
+	e.Lock()
+	defer e.Unlock()
 
-			return nil
-		})
-
-		fc := encoding.Factory("transaction")
-		currHeight := e.provider.Height()
-		for i := startHeight; i < currHeight; i++ {
-			b, err := e.provider.Block(i)
-			if err != nil {
-				continue
-			}
-			e.updateChain(b, fc, nil)
-
-			if err := e.db.Update(func(txn backend.StoreWriter) error {
-				txn.Set(initHeightKey, binutil.LittleEndian.Uint32ToBytes(i+1))
-				return nil
-			}); err != nil {
-				fmt.Errorf("err : %v", err)
-			}
+	if !e.isClosed {
+		e.isClosed = true
+		if e.e != nil {
+			e.e.Close()
 		}
-
-	}(e)
-
-	e.db.View(func(txn backend.StoreReader) error {
-		prefix := []byte(reverseOrderedTx)
-		//Iterate(prefix []byte, fn func(key []byte, value []byte) error) error
-		err := txn.Iterate(prefix, func(key []byte, value []byte) error {
-			buf := bytes.NewBuffer(value)
-			ti := &txInfos{}
-			ti.ReadFrom(buf)
-			e.txinfoInsertSort(*ti)
-			return nil
-		})
-		return err
-	})
-	return nil
+		e.db.Close()
+	}
 }
 
 func (e *BlockExplorer) txinfoInsertSort(el txInfos) {
@@ -196,8 +199,8 @@ func (e *BlockExplorer) txinfoInsertSort(el txInfos) {
 	e.lastestTransactionList = append(e.lastestTransactionList, txInfos{})
 	copy(e.lastestTransactionList[index+1:], e.lastestTransactionList[index:])
 	e.lastestTransactionList[index] = el
-	if len(e.lastestTransactionList) > 500 {
-		e.lastestTransactionList = e.lastestTransactionList[0:500]
+	if len(e.lastestTransactionList) > lastestTransactionCount {
+		e.lastestTransactionList = e.lastestTransactionList[0:lastestTransactionCount]
 	}
 }
 
@@ -254,79 +257,97 @@ func (e *BlockExplorer) updateMaximumTps(count int) {
 }
 
 func (e *BlockExplorer) OnBlockConnected(b *types.Block, events []types.Event, loader types.Loader) {
-	fc := encoding.Factory("transaction")
-	e.updateChain(b, fc, e.txinfoInsertSort)
-}
-
-func (e *BlockExplorer) updateChain(b *types.Block, fc *factory.Factory, insertTx func(el txInfos)) {
-	e.db.Update(func(txn backend.StoreWriter) error {
-		// txn.Set(MaximumTpsBytes, binutil.LittleEndian.Uint32ToBytes(uint32(e.MaximumTps)))
-		_, err := txn.Get([]byte(encoding.Hash(b.Header).String()))
-		if err != backend.ErrNotExistKey {
-			return ErrAlreadyRegistrationBlock
-		}
-
-		e.CurrentChainInfo.currentTransactions = len(b.Transactions)
-		if e.CurrentChainInfo.Blocks < b.Header.Height {
-			e.CurrentChainInfo.Blocks = b.Header.Height
-		}
-
-		e.countinfoInsertSort(&countInfo{
-			Time:  int64(b.Header.Timestamp),
-			Count: len(b.Transactions),
-		})
+	// blocks connected while backfilling are indexed by the backfill
+	if atomic.LoadInt32(&e.backfilling) != 0 {
+		return
+	}
 
-		value := binutil.LittleEndian.Uint32ToBytes(b.Header.Height | 0xFFFFFFFF)
+	e.Lock()
+	defer e.Unlock()
 
-		txs := b.Transactions
-		for i, tx := range txs {
-			t := b.TransactionTypes[i]
-			name, err := fc.TypeName(t)
-			if err != nil {
-				name = "UNKNOWN"
-			} else {
-				strs := strings.Split(name, "/")
-				name = strs[len(strs)-1]
-			}
-			ti := txInfos{
-				TxHash:    types.HashTransactionByType(e.provider.ChainID(), t, tx).String(),
-				BlockHash: encoding.Hash(b.Header).String(),
-				Time:      tx.Timestamp(),
-				TxType:    name,
-			}
-			if insertTx != nil {
-				insertTx(ti)
-			}
-			iBs := binutil.LittleEndian.Uint32ToBytes(uint32(i) | 0xFFFFFFFF)
-			v := append(value, iBs...)
+	if e.isClosed || atomic.LoadInt32(&e.backfilling) != 0 {
+		return
+	}
+	height, err := e.IndexedHeight()
+	if err != nil {
+		logger.Error("Index failed", "height", b.Header.Height, "err", err)
+		return
+	}
+	if height+1 != b.Header.Height {
+		e.startBackfill()
+		return
+	}
+	fc := encoding.Factory("transaction")
+	if err := e.db.Update(func(txn backend.StoreWriter) error {
+		return e.indexBlock(txn, b, fc, e.txinfoInsertSort)
+	}); err != nil {
+		logger.Error("Index failed", "height", b.Header.Height, "err", err)
+	}
+}
 
-			buf := &bytes.Buffer{}
-			ti.WriteTo(buf)
+func (e *BlockExplorer) indexBlock(txn backend.StoreWriter, b *types.Block, fc *factory.Factory, insertTx func(el txInfos)) error {
+	_, err := txn.Get([]byte(encoding.Hash(b.Header).String()))
+	if err != backend.ErrNotExistKey {
+		return ErrAlreadyRegistrationBlock
+	}
 
-			if err := txn.Set(append(reverseOrderedTx, v...), buf.Bytes()); err != nil {
-				return err
-			}
+	e.CurrentChainInfo.currentTransactions = len(b.Transactions)
+	if e.CurrentChainInfo.Blocks < b.Header.Height {
+		e.CurrentChainInfo.Blocks = b.Header.Height
+	}
 
-		}
+	e.countinfoInsertSort(&countInfo{
+		Time:  int64(b.Header.Timestamp),
+		Count: len(b.Transactions),
+	})
 
-		err = e.updateHashs(txn, b, fc)
+	txs := b.Transactions
+	for i, tx := range txs {
+		t := b.TransactionTypes[i]
+		name, err := fc.TypeName(t)
 		if err != nil {
-			return err
+			name = "UNKNOWN"
+		} else {
+			name = lastPathElement(name)
+		}
+		ti := txInfos{
+			TxHash:    types.HashTransactionByType(e.provider.ChainID(), t, tx).String(),
+			BlockHash: encoding.Hash(b.Header).String(),
+			Time:      tx.Timestamp(),
+			TxType:    name,
+		}
+		if insertTx != nil {
+			insertTx(ti)
 		}
 
 		buf := &bytes.Buffer{}
-		_, err = e.CurrentChainInfo.WriteTo(buf)
-		if err != nil {
+		ti.WriteTo(buf)
+
+		if err := txn.Set(toOrderedTxKey(b.Header.Height, uint16(i)), buf.Bytes()); err != nil {
 			return err
 		}
+	}
+
+	if err := e.updateHashs(txn, b, fc); err != nil {
+		return err
+	}
 
-		e.CurrentChainInfo.Transactions += e.CurrentChainInfo.currentTransactions
+	e.CurrentChainInfo.Transactions += e.CurrentChainInfo.currentTransactions
 
-		cs := e.cs.Candidates()
-		e.CurrentChainInfo.Foumulators = len(cs)
-		txn.Set(blockChainInfoBytes, buf.Bytes())
-		return nil
-	})
+	buf := &bytes.Buffer{}
+	if _, err := e.CurrentChainInfo.WriteTo(buf); err != nil {
+		return err
+	}
+
+	cs := e.cs.Candidates()
+	e.CurrentChainInfo.Foumulators = len(cs)
+	if err := txn.Set(blockChainInfoBytes, buf.Bytes()); err != nil {
+		return err
+	}
+	if err := txn.Set(indexedHeightBytes, binutil.LittleEndian.Uint32ToBytes(b.Header.Height)); err != nil {
+		return err
+	}
+	return nil
 }
 
 var blockChainInfoBytes = []byte("blockChainInfo")
@@ -412,71 +433,90 @@ func (e *BlockExplorer) InitURL() {
 	}
 
 	e.e.Any("/data/:order", e.dataHandler)
+	e.initAPI()
 	e.e.GET("/", func(c echo.Context) error {
 		args := map[string]string{
 			"MaximumTps": fmt.Sprintln(e.MaximumTps),
 		}
 		err := c.Render(http.StatusOK, "index.html", args)
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		return err
 	}, e.webChecker)
 	e.e.GET("/blocks", func(c echo.Context) error {
 		args, err := ec.Blocks(c.Request())
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		err = c.Render(http.StatusOK, "blocks.html", args)
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		return err
 	}, e.webChecker)
 	e.e.GET("/blockDetail", func(c echo.Context) error {
 		args, err := ec.BlockDetail(c.Request())
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		err = c.Render(http.StatusOK, "blockDetail.html", args)
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		return err
 	}, e.webChecker)
 	e.e.GET("/transactions", func(c echo.Context) error {
 		args, err := ec.Transactions(c.Request())
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		err = c.Render(http.StatusOK, "transactions.html", args)
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		return err
 	}, e.webChecker)
 	e.e.GET("/transactionDetail", func(c echo.Context) error {
 		args, err := ec.TransactionDetail(c.Request())
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		err = c.Render(http.StatusOK, "transactionDetail.html", args)
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		return err
 	}, e.webChecker)
 	e.e.GET("/formulators", func(c echo.Context) error {
 		args, err := ec.Formulators(c.Request())
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		err = c.Render(http.StatusOK, "formulators.html", args)
 		if err != nil {
-			log.Println(err)
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
+		}
+		return err
+	}, e.webChecker)
+	e.e.GET("/address", func(c echo.Context) error {
+		args, err := ec.Address(c.Request())
+		if err != nil {
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
+		}
+		err = c.Render(http.StatusOK, "address.html", args)
+		if err != nil {
+			logger.Warn("Request failed", "path", c.Request().URL.Path, "err", err)
 		}
 		return err
 	}, e.webChecker)
+	e.e.GET("/search", func(c echo.Context) error {
+		sr, err := e.Search(c.QueryParam("q"))
+		if err != nil {
+			return c.String(http.StatusNotFound, err.Error())
+		}
+		return c.Redirect(http.StatusFound, sr.Path())
+	}, e.webChecker)
 
 }
 
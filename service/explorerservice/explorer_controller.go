@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/fletaio/fleta_testnet/common"
 	"github.com/fletaio/fleta_testnet/common/binutil"
 	"github.com/fletaio/fleta_testnet/common/hash"
 	"github.com/fletaio/fleta_testnet/core/backend"
@@ -194,3 +195,18 @@ func (e *ExplorerController) Formulators(r *http.Request) (map[string]string, er
 		"formulatorData": string(j),
 	}, nil
 }
+
+func (e *ExplorerController) Address(r *http.Request) (map[string]string, error) {
+	addr, err := common.ParseAddress(r.URL.Query().Get("addr"))
+	if err != nil {
+		return nil, err
+	}
+	aa, err := e.block.accountInfo(addr)
+	if err != nil {
+		return nil, err
+	}
+	j, _ := json.Marshal(aa)
+	return map[string]string{
+		"accountData": string(j),
+	}, nil
+}
@@ -0,0 +1,153 @@
+package explorerservice
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/formulator"
+)
+
+// search result kinds
+const (
+	SearchBlock       = "block"
+	SearchTransaction = "transaction"
+	SearchAddress     = "address"
+)
+
+// SearchResult is the resolved target of the search query
+type SearchResult struct {
+	Kind    string `json:"kind"`
+	Height  uint32 `json:"height,omitempty"`
+	TXID    string `json:"txid,omitempty"`
+	Hash    string `json:"hash,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+// Path returns the explorer page of the search result
+func (sr *SearchResult) Path() string {
+	switch sr.Kind {
+	case SearchBlock:
+		return "/blockDetail?height=" + strconv.FormatUint(uint64(sr.Height), 10)
+	case SearchTransaction:
+		return "/transactionDetail?hash=" + sr.Hash
+	case SearchAddress:
+		return "/address?addr=" + sr.Address
+	default:
+		return "/"
+	}
+}
+
+// Search resolves the query as a height, a block hash, a transaction id, a transaction hash, an address or an account name
+func (e *BlockExplorer) Search(q string) (*SearchResult, error) {
+	q = strings.TrimSpace(q)
+	if len(q) == 0 {
+		return nil, ErrNotEnoughParameter
+	}
+
+	if h, err := strconv.ParseUint(q, 10, 32); err == nil {
+		if h > 0 && uint32(h) <= e.provider.Height() {
+			return &SearchResult{
+				Kind:   SearchBlock,
+				Height: uint32(h),
+			}, nil
+		}
+	}
+	if height, index, err := types.ParseTransactionID(q); err == nil {
+		if sr, err := e.searchTransaction(height, index); err == nil {
+			return sr, nil
+		}
+	}
+	if h, err := hash.ParseHash(q); err == nil {
+		if height, err := e.blockHeightByHash(h.String()); err == nil {
+			return &SearchResult{
+				Kind:   SearchBlock,
+				Height: height,
+			}, nil
+		}
+		if height, index, err := e.transactionIDByHash(h); err == nil {
+			if sr, err := e.searchTransaction(height, index); err == nil {
+				return sr, nil
+			}
+		}
+	}
+
+	loader := e.provider.NewLoaderWrapper(0)
+	if addr, err := common.ParseAddress(q); err == nil {
+		if has, err := loader.HasAccount(addr); err == nil && has {
+			return &SearchResult{
+				Kind:    SearchAddress,
+				Address: addr.String(),
+			}, nil
+		}
+	}
+	if addr, err := loader.AddressByName(q); err == nil {
+		return &SearchResult{
+			Kind:    SearchAddress,
+			Address: addr.String(),
+		}, nil
+	}
+	return nil, ErrNotFoundSearch
+}
+
+func (e *BlockExplorer) searchTransaction(height uint32, index uint16) (*SearchResult, error) {
+	if height == 0 || height > e.provider.Height() {
+		return nil, ErrNotExistTransaction
+	}
+	b, err := e.provider.Block(height)
+	if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(b.Transactions) {
+		return nil, ErrNotExistTransaction
+	}
+	return &SearchResult{
+		Kind:   SearchTransaction,
+		Height: height,
+		TXID:   types.TransactionID(height, index),
+		Hash:   types.HashTransactionByType(e.provider.ChainID(), b.TransactionTypes[index], b.Transactions[index]).String(),
+	}, nil
+}
+
+func formulatorTypeName(ft formulator.FormulatorType) string {
+	switch ft {
+	case formulator.AlphaFormulatorType:
+		return "alpha"
+	case formulator.SigmaFormulatorType:
+		return "sigma"
+	case formulator.OmegaFormulatorType:
+		return "omega"
+	case formulator.HyperFormulatorType:
+		return "hyper"
+	default:
+		return ""
+	}
+}
+
+// stakings returns staking amounts of the address to the hyper formulators of the candidates
+func (e *BlockExplorer) stakings(loader types.Loader, addr common.Address) []*APIStaking {
+	list := []*APIStaking{}
+	if e.formulator == nil {
+		return list
+	}
+	for _, c := range e.cs.Candidates() {
+		acc, err := loader.Account(c.Address)
+		if err != nil {
+			continue
+		}
+		frAcc, is := acc.(*formulator.FormulatorAccount)
+		if !is || frAcc.FormulatorType != formulator.HyperFormulatorType {
+			continue
+		}
+		if am := e.formulator.GetStakingAmount(loader, c.Address, addr); !am.IsZero() {
+			list = append(list, &APIStaking{
+				HyperAddress: c.Address.String(),
+				HyperName:    frAcc.Name(),
+				Amount:       am.String(),
+			})
+		}
+	}
+	return list
+}
@@ -0,0 +1,203 @@
+package explorerservice
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+
+	"github.com/fletaio/fleta_testnet/common/binutil"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	"github.com/fletaio/fleta_testnet/encoding"
+)
+
+// ExplorerSchemaVersion is the version of the explorer store
+// the store is rebuilt from blocks of the chain when the stored version is different
+const ExplorerSchemaVersion = 2
+
+const (
+	// backfillLogBlocks is the number of blocks between progress logs of the backfill
+	backfillLogBlocks = 500
+	// lastestTransactionCount is the number of transactions that are kept in the lastest transaction list
+	lastestTransactionCount = 500
+)
+
+// errStopIterate stops the iteration of the store without an error
+var errStopIterate = errors.New("stop iterate")
+
+var (
+	schemaVersionBytes = []byte("schemaVersion")
+	indexedHeightBytes = []byte("indexedHeight")
+	orderedTxBytes     = []byte("orderedTx")
+)
+
+// toOrderedTxKey returns the key of the transaction info that is ordered from the newest one
+func toOrderedTxKey(height uint32, index uint16) []byte {
+	bs := make([]byte, len(orderedTxBytes)+6)
+	copy(bs, orderedTxBytes)
+	binutil.BigEndian.PutUint32(bs[len(orderedTxBytes):], ^height)
+	binutil.BigEndian.PutUint16(bs[len(orderedTxBytes)+4:], ^index)
+	return bs
+}
+
+// StoreStatus is the sync status of the explorer store
+type StoreStatus struct {
+	SchemaVersion uint32 `json:"schema_version"`
+	IndexedHeight uint32 `json:"indexed_height"`
+	ChainHeight   uint32 `json:"chain_height"`
+	IsBackfilling bool   `json:"is_backfilling"`
+}
+
+// Status returns the sync status of the explorer store
+func (e *BlockExplorer) Status() (*StoreStatus, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	height, err := e.IndexedHeight()
+	if err != nil {
+		return nil, err
+	}
+	return &StoreStatus{
+		SchemaVersion: ExplorerSchemaVersion,
+		IndexedHeight: height,
+		ChainHeight:   e.provider.Height(),
+		IsBackfilling: atomic.LoadInt32(&e.backfilling) != 0,
+	}, nil
+}
+
+// IndexedHeight returns the last indexed height
+func (e *BlockExplorer) IndexedHeight() (uint32, error) {
+	var height uint32
+	if err := e.db.View(func(txn backend.StoreReader) error {
+		value, err := txn.Get(indexedHeightBytes)
+		if err != nil {
+			if err == backend.ErrNotExistKey {
+				return nil
+			}
+			return err
+		}
+		height = binutil.LittleEndian.Uint32(value)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// checkSchema clears the store when it is written by the other schema version or it is ahead of the chain
+func (e *BlockExplorer) checkSchema() error {
+	var version uint32
+	if err := e.db.View(func(txn backend.StoreReader) error {
+		value, err := txn.Get(schemaVersionBytes)
+		if err != nil {
+			if err == backend.ErrNotExistKey {
+				return nil
+			}
+			return err
+		}
+		version = binutil.LittleEndian.Uint32(value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	height, err := e.IndexedHeight()
+	if err != nil {
+		return err
+	}
+	if version == ExplorerSchemaVersion && height <= e.provider.Height() {
+		return nil
+	}
+
+	logger.Warn("Store is rebuilt", "version", version, "indexed", height, "height", e.provider.Height())
+	if err := backend.DeleteAll(e.db); err != nil {
+		return err
+	}
+	e.CurrentChainInfo = currentChainInfo{}
+	e.MaximumTps = 0
+	e.transactionCountList = []*countInfo{}
+	e.lastestTransactionList = []txInfos{}
+	return e.db.Update(func(txn backend.StoreWriter) error {
+		return txn.Set(schemaVersionBytes, binutil.LittleEndian.Uint32ToBytes(ExplorerSchemaVersion))
+	})
+}
+
+// startBackfill starts indexing blocks from the last indexed height in the background
+func (e *BlockExplorer) startBackfill() {
+	if !atomic.CompareAndSwapInt32(&e.backfilling, 0, 1) {
+		return
+	}
+	go e.backfill()
+}
+
+// backfill indexes blocks one by one and releases the lock between blocks
+// it catches up blocks that are connected while it runs before the flag is cleared
+func (e *BlockExplorer) backfill() {
+	fc := encoding.Factory("transaction")
+	for {
+		e.Lock()
+		if e.isClosed {
+			atomic.StoreInt32(&e.backfilling, 0)
+			e.Unlock()
+			return
+		}
+		height, err := e.IndexedHeight()
+		if err != nil {
+			atomic.StoreInt32(&e.backfilling, 0)
+			e.Unlock()
+			logger.Error("Backfill failed", "err", err)
+			return
+		}
+		target := e.provider.Height()
+		if height >= target {
+			// the flag is cleared under the lock so the next connected block follows the indexed height
+			atomic.StoreInt32(&e.backfilling, 0)
+			e.loadLastestTransactions()
+			e.Unlock()
+			return
+		}
+		if err := e.db.Update(func(txn backend.StoreWriter) error {
+			b, err := e.provider.Block(height + 1)
+			if err != nil {
+				return err
+			}
+			return e.indexBlock(txn, b, fc, nil)
+		}); err != nil {
+			atomic.StoreInt32(&e.backfilling, 0)
+			e.Unlock()
+			logger.Error("Backfill failed", "height", height+1, "err", err)
+			return
+		}
+		e.Unlock()
+		if (height+1)%backfillLogBlocks == 0 || height+1 == target {
+			logger.Info("Backfill", "height", height+1, "target", target)
+		}
+	}
+}
+
+// loadLastestTransactions loads the lastest transactions from the store
+// the caller should hold the lock
+func (e *BlockExplorer) loadLastestTransactions() {
+	list := []txInfos{}
+	if err := e.db.View(func(txn backend.StoreReader) error {
+		if err := txn.Iterate(orderedTxBytes, func(key []byte, value []byte) error {
+			ti := txInfos{}
+			if _, err := ti.ReadFrom(bytes.NewReader(value)); err != nil {
+				return err
+			}
+			list = append(list, ti)
+			if len(list) >= lastestTransactionCount {
+				return errStopIterate
+			}
+			return nil
+		}); err != nil && err != errStopIterate {
+			return err
+		}
+		return nil
+	}); err != nil {
+		logger.Error("Load latest transactions failed", "err", err)
+		return
+	}
+	e.lastestTransactionList = []txInfos{}
+	for _, ti := range list {
+		e.txinfoInsertSort(ti)
+	}
+}
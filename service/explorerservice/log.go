@@ -0,0 +1,6 @@
+package explorerservice
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the block explorer
+var logger = logging.New("explorer")
@@ -0,0 +1,147 @@
+package explorerservice
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type apiRoute struct {
+	Path     string
+	Summary  string
+	Params   []*apiParam
+	Response interface{}
+	Handler  echo.HandlerFunc
+}
+
+type apiParam struct {
+	Name        string
+	In          string
+	Description string
+}
+
+func cursorParam(Description string) *apiParam {
+	return &apiParam{Name: "cursor", In: "query", Description: Description}
+}
+
+func limitParam() *apiParam {
+	return &apiParam{Name: "limit", In: "query", Description: "number of items of the page (default 20, max 100)"}
+}
+
+// openAPIDocument generates the OpenAPI document of the routes
+// response schemas are generated from the json tags of the response types
+func openAPIDocument(routes []*apiRoute) map[string]interface{} {
+	schemas := map[string]interface{}{}
+	paths := map[string]interface{}{}
+	for _, r := range routes {
+		params := []interface{}{}
+		for _, p := range r.Params {
+			params = append(params, map[string]interface{}{
+				"name":        p.Name,
+				"in":          p.In,
+				"description": p.Description,
+				"required":    p.In == "path",
+				"schema":      map[string]interface{}{"type": "string"},
+			})
+		}
+		paths[openAPIPath(r.Path)] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary":    r.Summary,
+				"parameters": params,
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "OK",
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": openAPISchema(reflect.TypeOf(r.Response), schemas),
+							},
+						},
+					},
+					"default": map[string]interface{}{
+						"description": "Error",
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": openAPISchema(reflect.TypeOf(APIError{}), schemas),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   "FLETA Block Explorer API",
+			"version": "1.0.0",
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"url": "/api/v1"},
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+}
+
+// openAPIPath converts the echo path parameters to the OpenAPI path parameters
+func openAPIPath(path string) string {
+	strs := strings.Split(path, "/")
+	for i, v := range strs {
+		if strings.HasPrefix(v, ":") {
+			strs[i] = "{" + v[1:] + "}"
+		}
+	}
+	return strings.Join(strs, "/")
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+func openAPISchema(rt reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	if rt == rawMessageType {
+		return map[string]interface{}{"type": "object"}
+	}
+	switch rt.Kind() {
+	case reflect.Ptr:
+		return openAPISchema(rt.Elem(), schemas)
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": openAPISchema(rt.Elem(), schemas),
+		}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Struct:
+		if _, has := schemas[rt.Name()]; !has {
+			props := map[string]interface{}{}
+			schemas[rt.Name()] = map[string]interface{}{
+				"type":       "object",
+				"properties": props,
+			}
+			for i := 0; i < rt.NumField(); i++ {
+				f := rt.Field(i)
+				name := strings.Split(f.Tag.Get("json"), ",")[0]
+				if name == "-" || len(f.PkgPath) > 0 {
+					continue
+				}
+				if len(name) == 0 {
+					name = f.Name
+				}
+				props[name] = openAPISchema(f.Type, schemas)
+			}
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + rt.Name()}
+	default:
+		return map[string]interface{}{"type": "object"}
+	}
+}
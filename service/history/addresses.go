@@ -0,0 +1,135 @@
+package history
+
+import (
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/formulator"
+	"github.com/fletaio/fleta_testnet/process/gateway"
+	"github.com/fletaio/fleta_testnet/process/payment"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+type addressSet struct {
+	list []common.Address
+	has  map[common.Address]bool
+}
+
+func newAddressSet() *addressSet {
+	return &addressSet{
+		list: []common.Address{},
+		has:  map[common.Address]bool{},
+	}
+}
+
+func (set *addressSet) Add(addrs ...common.Address) {
+	for _, addr := range addrs {
+		if !set.has[addr] {
+			set.has[addr] = true
+			set.list = append(set.list, addr)
+		}
+	}
+}
+
+type fromTransaction interface {
+	From() common.Address
+}
+
+// transactionAddresses returns addresses that are touched by the transaction
+func transactionAddresses(cn types.Provider, height uint32, index uint16, t types.Transaction) []common.Address {
+	set := newAddressSet()
+	if ft, is := t.(fromTransaction); is {
+		set.Add(ft.From())
+	}
+	switch tx := t.(type) {
+	case *vault.Transfer:
+		set.Add(tx.To)
+	case *vault.TransferWithTag:
+		set.Add(tx.To)
+	case *vault.LockedTransfer:
+		set.Add(tx.To)
+	case *vault.VestingTransfer:
+		set.Add(tx.To)
+	case *vault.MultiTransfer:
+		set.Add(tx.To...)
+	case *vault.CreateAccount, *vault.CreateMultiAccount, *vault.IssueAccount:
+		set.Add(cn.NewAddress(height, index))
+	case *formulator.CreateAlpha, *formulator.CreateHyper:
+		set.Add(cn.NewAddress(height, index))
+	case *formulator.Transmute:
+		set.Add(cn.NewAddress(height, index))
+		set.Add(tx.HyperFormulators...)
+	case *formulator.CreateSigma:
+		set.Add(tx.AlphaFormulators...)
+	case *formulator.CreateOmega:
+		set.Add(tx.SigmaFormulators...)
+	case *formulator.Staking:
+		set.Add(tx.HyperFormulator)
+	case *formulator.Unstaking:
+		set.Add(tx.HyperFormulator)
+	case *formulator.RevertUnstaking:
+		set.Add(tx.HyperFormulator)
+	case *formulator.UpdateUserAutoStaking:
+		set.Add(tx.HyperFormulator)
+	case *formulator.ChangeStaking:
+		set.Add(tx.HyperUnstaking, tx.HyperStaking)
+	case *formulator.Revoke:
+		set.Add(tx.Heritor)
+	case *formulator.RevokeAdmin:
+		set.Add(tx.Formulator, tx.Heritor)
+	case *gateway.TokenIn:
+		set.Add(tx.ToAddresses...)
+	case *payment.RequestPayment:
+		set.Add(tx.To)
+	case *payment.Billing:
+		set.Add(tx.To)
+	case *payment.IndexSubscribe:
+		set.Add(tx.Subscribers...)
+	case *payment.AddTopic:
+		set.Add(tx.Owner, tx.Payee)
+	case *payment.TransferTopic:
+		set.Add(tx.Owner)
+	case *payment.UpdateTopic:
+		set.Add(tx.Payee)
+	}
+	return set.list
+}
+
+// eventAddresses returns addresses that are touched by the event
+func eventAddresses(e types.Event) []common.Address {
+	set := newAddressSet()
+	switch ev := e.(type) {
+	case *formulator.RewardEvent:
+		ev.GenBlockMap.EachAll(func(addr common.Address, _ uint32) bool {
+			set.Add(addr)
+			return true
+		})
+		for _, mp := range []*types.AddressAmountMap{ev.RewardMap, ev.StackedMap, ev.CommissionMap} {
+			mp.EachAll(func(addr common.Address, _ *amount.Amount) bool {
+				set.Add(addr)
+				return true
+			})
+		}
+		for _, mp := range []*types.AddressAddressAmountMap{ev.StakedMap, ev.StakeRewardMap} {
+			mp.EachAll(func(HyperAddr common.Address, sub *types.AddressAmountMap) bool {
+				set.Add(HyperAddr)
+				sub.EachAll(func(addr common.Address, _ *amount.Amount) bool {
+					set.Add(addr)
+					return true
+				})
+				return true
+			})
+		}
+	case *formulator.RevokedEvent:
+		set.Add(ev.Formulator)
+	case *formulator.UnstakedEvent:
+		set.Add(ev.HyperFormulator, ev.Address)
+	case *payment.ChargedEvent:
+		set.Add(ev.Subscriber)
+	case *payment.ChargeFailedEvent:
+		set.Add(ev.Subscriber)
+	case *payment.PaymentStateEvent:
+		set.Add(ev.Payer)
+	}
+	return set.list
+}
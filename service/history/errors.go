@@ -0,0 +1,12 @@
+package history
+
+import "errors"
+
+// errors
+var (
+	ErrInvalidKind        = errors.New("invalid kind")
+	ErrInvalidHeightRange = errors.New("invalid height range")
+	ErrNotExistRecord     = errors.New("not exist record")
+	ErrRebuildInProgress  = errors.New("rebuild in progress")
+	ErrHistoryClosed      = errors.New("history closed")
+)
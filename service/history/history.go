@@ -0,0 +1,183 @@
+package history
+
+import (
+	"sync"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/service/apiserver"
+)
+
+// History indexes transactions and events of every address of the chain
+type History struct {
+	types.ServiceBase
+	sync.Mutex
+	db           backend.StoreBackend
+	cn           types.Provider
+	isRebuilding bool
+	isClosed     bool
+}
+
+// NewHistory returns a History
+func NewHistory(db backend.StoreBackend) *History {
+	s := &History{
+		db: db,
+	}
+	return s
+}
+
+// Name returns the name of the service
+func (s *History) Name() string {
+	return "fleta.history"
+}
+
+// Init called when initialize service
+func (s *History) Init(pm types.ProcessManager, cn types.Provider) error {
+	s.cn = cn
+
+	if vs, err := pm.ServiceByName("fleta.apiserver"); err != nil {
+		//ignore when not loaded
+	} else if v, is := vs.(*apiserver.APIServer); !is {
+		//ignore when not loaded
+	} else {
+		as, err := v.JRPC("history")
+		if err != nil {
+			return err
+		}
+		as.Set("height", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			return s.Height()
+		})
+		as.Set("list", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			if arg.Len() != 7 {
+				return nil, apiserver.ErrInvalidArgument
+			}
+			arg0, err := arg.String(0)
+			if err != nil {
+				return nil, err
+			}
+			addr, err := common.ParseAddress(arg0)
+			if err != nil {
+				return nil, err
+			}
+			kind, err := arg.Uint8(1)
+			if err != nil {
+				return nil, err
+			}
+			t, err := arg.Uint16(2)
+			if err != nil {
+				return nil, err
+			}
+			From, err := arg.Uint32(3)
+			if err != nil {
+				return nil, err
+			}
+			To, err := arg.Uint32(4)
+			if err != nil {
+				return nil, err
+			}
+			offset, err := arg.Uint32(5)
+			if err != nil {
+				return nil, err
+			}
+			count, err := arg.Uint32(6)
+			if err != nil {
+				return nil, err
+			}
+			return s.Records(addr, kind, t, From, To, offset, count)
+		})
+		as.Set("rebuild", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+			s.Lock()
+			isRebuilding := s.isRebuilding
+			s.Unlock()
+			if isRebuilding {
+				return nil, ErrRebuildInProgress
+			}
+			go func() {
+				if err := s.Rebuild(); err != nil {
+					logger.Error("Rebuild failed", "err", err)
+				}
+			}()
+			return nil, nil
+		})
+	}
+	return nil
+}
+
+// Close closes the store after the index in progress
+func (s *History) Close() {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.isClosed {
+		s.isClosed = true
+		s.db.Close()
+	}
+}
+
+// OnLoadChain called when the chain loaded
+// the index is rebuilt when it is ahead of the chain that is recovered from the crash or its layout is older
+func (s *History) OnLoadChain(loader types.Loader) error {
+	height, err := s.Height()
+	if err != nil {
+		return err
+	}
+	version, err := s.Version()
+	if err != nil {
+		return err
+	}
+	if height > s.cn.Height() {
+		logger.Warn("Index is ahead of the chain and is rebuilt", "indexed", height, "height", s.cn.Height())
+		if err := s.clear(); err != nil {
+			return err
+		}
+	} else if version < indexVersion {
+		logger.Warn("Index layout is older and is rebuilt", "version", version, "required", indexVersion)
+		if err := s.clear(); err != nil {
+			return err
+		}
+	}
+	return s.sync()
+}
+
+// OnBlockConnected called when a block is connected to the chain
+func (s *History) OnBlockConnected(b *types.Block, events []types.Event, loader types.Loader) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.isRebuilding || s.isClosed {
+		return
+	}
+	if err := s.indexBlock(b, events); err != nil {
+		logger.Error("Index failed", "height", b.Header.Height, "err", err)
+	}
+}
+
+// Rebuild removes all indexes and rebuilds them from blocks of the chain
+func (s *History) Rebuild() error {
+	s.Lock()
+	if s.isClosed {
+		s.Unlock()
+		return ErrHistoryClosed
+	}
+	if s.isRebuilding {
+		s.Unlock()
+		return ErrRebuildInProgress
+	}
+	s.isRebuilding = true
+	err := s.clear()
+	s.Unlock()
+
+	if err == nil {
+		err = s.sync()
+	}
+
+	s.Lock()
+	s.isRebuilding = false
+	s.Unlock()
+	if err != nil {
+		return err
+	}
+	// blocks connected while rebuilding are skipped by OnBlockConnected
+	return s.sync()
+}
@@ -0,0 +1,365 @@
+package history
+
+import (
+	"strings"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/binutil"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/encoding"
+)
+
+// Height returns the last indexed height
+func (s *History) Height() (uint32, error) {
+	var height uint32
+	if err := s.db.View(func(txn backend.StoreReader) error {
+		h, err := getHeight(txn)
+		if err != nil {
+			return err
+		}
+		height = h
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+func getHeight(txn backend.StoreReader) (uint32, error) {
+	value, err := txn.Get(tagHeight)
+	if err != nil {
+		if err == backend.ErrNotExistKey {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return binutil.LittleEndian.Uint32(value), nil
+}
+
+// indexVersion is the version of the index layout and the index is rebuilt when the stored one is older
+// version 1 adds the list of records by the kind
+const indexVersion = 1
+
+// Version returns the version of the index layout
+func (s *History) Version() (uint32, error) {
+	var version uint32
+	if err := s.db.View(func(txn backend.StoreReader) error {
+		value, err := txn.Get(tagVersion)
+		if err != nil {
+			if err == backend.ErrNotExistKey {
+				return nil
+			}
+			return err
+		}
+		version = binutil.LittleEndian.Uint32(value)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+func getCount(txn backend.StoreReader, key []byte) (uint32, error) {
+	value, err := txn.Get(key)
+	if err != nil {
+		if err == backend.ErrNotExistKey {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return binutil.LittleEndian.Uint32(value), nil
+}
+
+func (s *History) sync() error {
+	for {
+		s.Lock()
+		if s.isClosed {
+			s.Unlock()
+			return ErrHistoryClosed
+		}
+		height, err := s.Height()
+		if err != nil {
+			s.Unlock()
+			return err
+		}
+		if height >= s.cn.Height() {
+			s.Unlock()
+			return nil
+		}
+		b, err := s.cn.Block(height + 1)
+		if err != nil {
+			s.Unlock()
+			return err
+		}
+		events, err := s.cn.Events(height+1, height+1)
+		if err != nil {
+			s.Unlock()
+			return err
+		}
+		err = s.indexBlock(b, events)
+		s.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *History) clear() error {
+	return s.db.Update(func(txn backend.StoreWriter) error {
+		keys := [][]byte{}
+		for _, tag := range [][]byte{tagCount, tagList, tagTypeCount, tagTypeList, tagKindCount, tagKindList} {
+			if err := txn.Iterate(tag, func(key []byte, value []byte) error {
+				k := make([]byte, len(key))
+				copy(k, key)
+				keys = append(keys, k)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		for _, key := range keys {
+			if err := txn.Delete(key); err != nil {
+				return err
+			}
+		}
+		if err := txn.Delete(tagHeight); err != nil {
+			if err != backend.ErrNotExistKey {
+				return err
+			}
+		}
+		if err := txn.Set(tagVersion, binutil.LittleEndian.Uint32ToBytes(indexVersion)); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *History) indexBlock(b *types.Block, events []types.Event) error {
+	efc := encoding.Factory("event")
+	return s.db.Update(func(txn backend.StoreWriter) error {
+		height, err := getHeight(txn)
+		if err != nil {
+			return err
+		}
+		if b.Header.Height != height+1 {
+			return nil
+		}
+		for i, tx := range b.Transactions {
+			rec := &Record{
+				Height: b.Header.Height,
+				Index:  uint16(i),
+				Kind:   TransactionKind,
+				Type:   b.TransactionTypes[i],
+			}
+			for _, addr := range transactionAddresses(s.cn, b.Header.Height, uint16(i), tx) {
+				if err := appendRecord(txn, addr, rec); err != nil {
+					return err
+				}
+			}
+		}
+		for _, ev := range events {
+			t, err := efc.TypeOf(ev)
+			if err != nil {
+				return err
+			}
+			rec := &Record{
+				Height: b.Header.Height,
+				Index:  ev.Index(),
+				N:      ev.N(),
+				Kind:   EventKind,
+				Type:   t,
+			}
+			for _, addr := range eventAddresses(ev) {
+				if err := appendRecord(txn, addr, rec); err != nil {
+					return err
+				}
+			}
+		}
+		if err := txn.Set(tagHeight, binutil.LittleEndian.Uint32ToBytes(b.Header.Height)); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func appendRecord(txn backend.StoreWriter, addr common.Address, rec *Record) error {
+	bs := rec.Bytes()
+
+	Count, err := getCount(txn, toCountKey(addr))
+	if err != nil {
+		return err
+	}
+	if err := txn.Set(toListKey(addr, Count), bs); err != nil {
+		return err
+	}
+	if err := txn.Set(toCountKey(addr), binutil.LittleEndian.Uint32ToBytes(Count+1)); err != nil {
+		return err
+	}
+
+	KindCount, err := getCount(txn, toKindCountKey(addr, rec.Kind))
+	if err != nil {
+		return err
+	}
+	if err := txn.Set(toKindListKey(addr, rec.Kind, KindCount), bs); err != nil {
+		return err
+	}
+	if err := txn.Set(toKindCountKey(addr, rec.Kind), binutil.LittleEndian.Uint32ToBytes(KindCount+1)); err != nil {
+		return err
+	}
+
+	TypeCount, err := getCount(txn, toTypeCountKey(addr, rec.Kind, rec.Type))
+	if err != nil {
+		return err
+	}
+	if err := txn.Set(toTypeListKey(addr, rec.Kind, rec.Type, TypeCount), bs); err != nil {
+		return err
+	}
+	if err := txn.Set(toTypeCountKey(addr, rec.Kind, rec.Type), binutil.LittleEndian.Uint32ToBytes(TypeCount+1)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Records returns records of the address from the newest one in the height range
+// kind and t are used as filters when they are not zero, and t requires kind
+func (s *History) Records(addr common.Address, kind uint8, t uint16, From uint32, To uint32, offset uint32, count uint32) (*QueryResult, error) {
+	if kind != 0 && kind != TransactionKind && kind != EventKind {
+		return nil, ErrInvalidKind
+	}
+	if t != 0 && kind == 0 {
+		return nil, ErrInvalidKind
+	}
+	if To < From {
+		return nil, ErrInvalidHeightRange
+	}
+
+	result := &QueryResult{
+		Records: []*Record{},
+	}
+	if err := s.db.View(func(txn backend.StoreReader) error {
+		var CountKey []byte
+		var listKey func(seq uint32) []byte
+		if t != 0 {
+			CountKey = toTypeCountKey(addr, kind, t)
+			listKey = func(seq uint32) []byte {
+				return toTypeListKey(addr, kind, t, seq)
+			}
+		} else if kind != 0 {
+			CountKey = toKindCountKey(addr, kind)
+			listKey = func(seq uint32) []byte {
+				return toKindListKey(addr, kind, seq)
+			}
+		} else {
+			CountKey = toCountKey(addr)
+			listKey = func(seq uint32) []byte {
+				return toListKey(addr, seq)
+			}
+		}
+		Count, err := getCount(txn, CountKey)
+		if err != nil {
+			return err
+		}
+		get := func(seq uint32) (*Record, error) {
+			value, err := txn.Get(listKey(seq))
+			if err != nil {
+				return nil, err
+			}
+			return NewRecordFromBytes(value)
+		}
+
+		// records are appended by the height order so the range is found by the binary search
+		search := func(fn func(rec *Record) bool) (uint32, error) {
+			lo, hi := uint32(0), Count
+			for lo < hi {
+				mid := lo + (hi-lo)/2
+				rec, err := get(mid)
+				if err != nil {
+					return 0, err
+				}
+				if fn(rec) {
+					hi = mid
+				} else {
+					lo = mid + 1
+				}
+			}
+			return lo, nil
+		}
+		Begin, err := search(func(rec *Record) bool { return rec.Height >= From })
+		if err != nil {
+			return err
+		}
+		End, err := search(func(rec *Record) bool { return rec.Height > To })
+		if err != nil {
+			return err
+		}
+
+		list := []*Record{}
+		result.Total = End - Begin
+		if offset < result.Total {
+			for seq := End - offset; seq > Begin && uint32(len(list)) < count; seq-- {
+				rec, err := get(seq - 1)
+				if err != nil {
+					return err
+				}
+				list = append(list, rec)
+			}
+		}
+		result.Records = list
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for _, rec := range result.Records {
+		if err := s.fillRecord(rec); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *History) fillRecord(rec *Record) error {
+	switch rec.Kind {
+	case TransactionKind:
+		b, err := s.cn.Block(rec.Height)
+		if err != nil {
+			return err
+		}
+		if int(rec.Index) >= len(b.Transactions) {
+			return ErrNotExistRecord
+		}
+		data, err := b.Transactions[rec.Index].MarshalJSON()
+		if err != nil {
+			return err
+		}
+		rec.TXID = types.TransactionID(rec.Height, rec.Index)
+		rec.Data = data
+		if name, err := encoding.Factory("transaction").TypeName(rec.Type); err == nil {
+			rec.TypeName = shortTypeName(name)
+		}
+	case EventKind:
+		events, err := s.cn.Events(rec.Height, rec.Height)
+		if err != nil {
+			return err
+		}
+		for _, ev := range events {
+			if ev.Index() == rec.Index && ev.N() == rec.N {
+				data, err := ev.MarshalJSON()
+				if err != nil {
+					return err
+				}
+				rec.Data = data
+				break
+			}
+		}
+		if name, err := encoding.Factory("event").TypeName(rec.Type); err == nil {
+			rec.TypeName = shortTypeName(name)
+		}
+	}
+	return nil
+}
+
+func shortTypeName(name string) string {
+	strs := strings.Split(name, "/")
+	return strs[len(strs)-1]
+}
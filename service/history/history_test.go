@@ -0,0 +1,100 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	_ "github.com/fletaio/fleta_testnet/core/backend/buntdb_driver"
+)
+
+func TestAppendRecordKindList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := backend.Create("buntdb", filepath.Join(dir, "history"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewHistory(db)
+	defer s.Close()
+
+	addr := common.NewAddress(0, 1, 0)
+	recs := []*Record{
+		{Height: 1, Index: 0, Kind: TransactionKind, Type: 1},
+		{Height: 1, Index: 0, Kind: EventKind, Type: 1},
+		{Height: 2, Index: 0, Kind: EventKind, Type: 2},
+		{Height: 3, Index: 1, Kind: TransactionKind, Type: 2},
+	}
+	if err := s.db.Update(func(txn backend.StoreWriter) error {
+		for _, rec := range recs {
+			if err := appendRecord(txn, addr, rec); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		kind    uint8
+		heights []uint32
+	}{
+		{"transactions", TransactionKind, []uint32{1, 3}},
+		{"events", EventKind, []uint32{1, 2}},
+	}
+	for _, tt := range tests {
+		if err := s.db.View(func(txn backend.StoreReader) error {
+			Count, err := getCount(txn, toKindCountKey(addr, tt.kind))
+			if err != nil {
+				return err
+			}
+			if Count != uint32(len(tt.heights)) {
+				t.Errorf("%s: got %d records, want %d", tt.name, Count, len(tt.heights))
+				return nil
+			}
+			for i, height := range tt.heights {
+				value, err := txn.Get(toKindListKey(addr, tt.kind, uint32(i)))
+				if err != nil {
+					return err
+				}
+				rec, err := NewRecordFromBytes(value)
+				if err != nil {
+					return err
+				}
+				if rec.Kind != tt.kind || rec.Height != height {
+					t.Errorf("%s: record %d: got kind %d height %d, want kind %d height %d", tt.name, i, rec.Kind, rec.Height, tt.kind, height)
+				}
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.clear(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.db.View(func(txn backend.StoreReader) error {
+		if Count, err := getCount(txn, toKindCountKey(addr, TransactionKind)); err != nil {
+			return err
+		} else if Count != 0 {
+			t.Errorf("kind index remains after the clear")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := s.Version(); err != nil {
+		t.Fatal(err)
+	} else if version != indexVersion {
+		t.Errorf("version: got %d, want %d", version, indexVersion)
+	}
+}
@@ -0,0 +1,6 @@
+package history
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the history index
+var logger = logging.New("history")
@@ -0,0 +1,59 @@
+package history
+
+import (
+	"encoding/json"
+
+	"github.com/fletaio/fleta_testnet/common/binutil"
+)
+
+// kinds of the record
+const (
+	TransactionKind = uint8(1)
+	EventKind       = uint8(2)
+)
+
+const recordSize = 11
+
+// Record is a reference to the transaction or the event that touched an address
+type Record struct {
+	Height   uint32          `json:"height"`
+	Index    uint16          `json:"index"`
+	N        uint16          `json:"n"`
+	Kind     uint8           `json:"kind"`
+	Type     uint16          `json:"type"`
+	TypeName string          `json:"type_name,omitempty"`
+	TXID     string          `json:"txid,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// Bytes returns the byte representation of the record
+func (rec *Record) Bytes() []byte {
+	bs := make([]byte, recordSize)
+	binutil.BigEndian.PutUint32(bs, rec.Height)
+	binutil.BigEndian.PutUint16(bs[4:], rec.Index)
+	binutil.BigEndian.PutUint16(bs[6:], rec.N)
+	bs[8] = rec.Kind
+	binutil.BigEndian.PutUint16(bs[9:], rec.Type)
+	return bs
+}
+
+// NewRecordFromBytes parses the record from the byte array
+func NewRecordFromBytes(bs []byte) (*Record, error) {
+	if len(bs) != recordSize {
+		return nil, ErrNotExistRecord
+	}
+	rec := &Record{
+		Height: binutil.BigEndian.Uint32(bs),
+		Index:  binutil.BigEndian.Uint16(bs[4:]),
+		N:      binutil.BigEndian.Uint16(bs[6:]),
+		Kind:   bs[8],
+		Type:   binutil.BigEndian.Uint16(bs[9:]),
+	}
+	return rec, nil
+}
+
+// QueryResult is a page of records of an address
+type QueryResult struct {
+	Total   uint32    `json:"total"`
+	Records []*Record `json:"records"`
+}
@@ -0,0 +1,69 @@
+package history
+
+import (
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/binutil"
+)
+
+// tags
+var (
+	tagHeight    = []byte{1, 0}
+	tagVersion   = []byte{1, 1}
+	tagCount     = []byte{2, 0}
+	tagList      = []byte{2, 1}
+	tagTypeCount = []byte{3, 0}
+	tagTypeList  = []byte{3, 1}
+	tagKindCount = []byte{4, 0}
+	tagKindList  = []byte{4, 1}
+)
+
+func toCountKey(addr common.Address) []byte {
+	bs := make([]byte, 2+common.AddressSize)
+	copy(bs, tagCount)
+	copy(bs[2:], addr[:])
+	return bs
+}
+
+func toListKey(addr common.Address, seq uint32) []byte {
+	bs := make([]byte, 6+common.AddressSize)
+	copy(bs, tagList)
+	copy(bs[2:], addr[:])
+	binutil.BigEndian.PutUint32(bs[2+common.AddressSize:], seq)
+	return bs
+}
+
+func toTypeCountKey(addr common.Address, kind uint8, t uint16) []byte {
+	bs := make([]byte, 5+common.AddressSize)
+	copy(bs, tagTypeCount)
+	copy(bs[2:], addr[:])
+	bs[2+common.AddressSize] = kind
+	binutil.BigEndian.PutUint16(bs[3+common.AddressSize:], t)
+	return bs
+}
+
+func toTypeListKey(addr common.Address, kind uint8, t uint16, seq uint32) []byte {
+	bs := make([]byte, 9+common.AddressSize)
+	copy(bs, tagTypeList)
+	copy(bs[2:], addr[:])
+	bs[2+common.AddressSize] = kind
+	binutil.BigEndian.PutUint16(bs[3+common.AddressSize:], t)
+	binutil.BigEndian.PutUint32(bs[5+common.AddressSize:], seq)
+	return bs
+}
+
+func toKindCountKey(addr common.Address, kind uint8) []byte {
+	bs := make([]byte, 3+common.AddressSize)
+	copy(bs, tagKindCount)
+	copy(bs[2:], addr[:])
+	bs[2+common.AddressSize] = kind
+	return bs
+}
+
+func toKindListKey(addr common.Address, kind uint8, seq uint32) []byte {
+	bs := make([]byte, 7+common.AddressSize)
+	copy(bs, tagKindList)
+	copy(bs[2:], addr[:])
+	bs[2+common.AddressSize] = kind
+	binutil.BigEndian.PutUint32(bs[3+common.AddressSize:], seq)
+	return bs
+}
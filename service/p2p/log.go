@@ -0,0 +1,6 @@
+package p2p
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the p2p network
+var logger = logging.New("p2p")
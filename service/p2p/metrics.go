@@ -0,0 +1,13 @@
+package p2p
+
+import "github.com/fletaio/fleta_testnet/common/metrics"
+
+// metrics of the node mesh
+var (
+	peersGauge   = metrics.NewGauge("fleta_p2p_peers", "number of connected peers of the node mesh")
+	recvMessages = metrics.NewCounterVec("fleta_p2p_received_messages_total", "number of messages received from peers by the message type", "type")
+	recvBytes    = metrics.NewCounter("fleta_p2p_received_bytes_total", "bytes of packets received from peers")
+	sentPackets  = metrics.NewCounter("fleta_p2p_sent_packets_total", "number of packets sent to peers by the node mesh")
+	sentBytes    = metrics.NewCounter("fleta_p2p_sent_bytes_total", "bytes of packets sent to peers by the node mesh")
+	invalidPeers = metrics.NewCounter("fleta_p2p_invalid_peers_total", "number of peers removed by invalid packets or messages")
+)
@@ -2,8 +2,6 @@ package p2p
 
 import (
 	"bytes"
-	"fmt"
-	"log"
 	"runtime"
 	"sync"
 	"time"
@@ -187,7 +185,7 @@ func (nd *Node) Run(BindAddress string) {
 					}
 					if err := nd.addTx(ctw, item.TxHash, item.Type, item.Tx, item.Sigs); err != nil {
 						if err != ErrInvalidUTXO && err != txpool.ErrExistTransaction && err != txpool.ErrTransactionPoolOverflowed && err != types.ErrUsedTimeSlot && err != types.ErrInvalidTransactionTimeSlot {
-							rlog.Println("TransactionError", item.TxHash.String(), err.Error())
+							logger.Debug("Transaction rejected", "tx_hash", item.TxHash, "peer", common.PublicHashFromPeerID(item.PeerID), "err", err)
 							if len(item.PeerID) > 0 {
 								nd.ms.AddBadPoint(item.PeerID, 1)
 							}
@@ -289,12 +287,14 @@ func (nd *Node) Run(BindAddress string) {
 				}
 				m, err := PacketToMessage(item.Packet)
 				if err != nil {
-					log.Println("PacketToMessage", err)
+					logger.Warn("Invalid peer packet", "peer", common.PublicHashFromPeerID(item.PeerID), "err", err)
+					invalidPeers.Inc()
 					nd.ms.RemovePeer(item.PeerID)
 					break
 				}
 				if err := nd.handlePeerMessage(item.PeerID, m); err != nil {
-					log.Println("handlePeerMessage", err)
+					logger.Warn("Peer message failed", "peer", common.PublicHashFromPeerID(item.PeerID), "err", err)
+					invalidPeers.Inc()
 					nd.ms.RemovePeer(item.PeerID)
 					break
 				}
@@ -338,13 +338,13 @@ func (nd *Node) Run(BindAddress string) {
 		for item != nil {
 			b := item.(*types.Block)
 			if err := nd.cn.ConnectBlock(b, nd.txpool); err != nil {
-				rlog.Println(err)
+				logger.Error("Block connect failed", "height", b.Header.Height, "err", err)
 				panic(err)
 				break
 			}
 			nd.cleanPool(b)
 			if nd.cn.Provider().Height()%100 == 0 {
-				rlog.Println("Node", nd.myPublicHash.String(), nd.cn.Provider().Height(), "BlockConnected", b.Header.Generator.String(), b.Header.Height, len(b.Transactions))
+				logger.Info("Block connected", "height", b.Header.Height, "generator", b.Header.Generator, "txs", len(b.Transactions))
 			}
 
 			txs := nd.txpool.Clean(types.ToTimeSlot(b.Header.Timestamp))
@@ -352,7 +352,9 @@ func (nd *Node) Run(BindAddress string) {
 			for _, s := range svcs {
 				s.OnTransactionInPoolExpired(txs)
 			}
-			fmt.Println("EXPIRED", len(txs))
+			if len(txs) > 0 {
+				logger.Debug("Transactions expired", "height", b.Header.Height, "txs", len(txs))
+			}
 
 			TargetHeight++
 			Count++
@@ -484,7 +486,7 @@ func (nd *Node) handlePeerMessage(ID string, m interface{}) error {
 			}
 			if h != msg.LastHash {
 				//TODO : critical error signal
-				rlog.Println(chain.ErrFoundForkedBlock, ID, h.String(), msg.LastHash.String(), msg.Height)
+				logger.Error("Forked block found", "peer", common.PublicHashFromPeerID(ID), "height", msg.Height, "hash", h, "peer_hash", msg.LastHash)
 				nd.ms.RemovePeer(ID)
 			}
 		}
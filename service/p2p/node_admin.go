@@ -0,0 +1,222 @@
+package p2p
+
+import (
+	"sort"
+	"time"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/service/apiserver"
+)
+
+// NodeInfo is the information of the node
+type NodeInfo struct {
+	PublicHash      string            `json:"public_hash"`
+	ChainID         uint8             `json:"chain_id"`
+	Symbol          string            `json:"symbol"`
+	Usage           string            `json:"usage"`
+	Version         uint16            `json:"version"`
+	ProcessVersions map[string]string `json:"process_versions"`
+	GenesisHash     string            `json:"genesis_hash"`
+	Height          uint32            `json:"height"`
+	LastHash        string            `json:"last_hash"`
+	LastTimestamp   uint64            `json:"last_timestamp"`
+	PeerCount       int               `json:"peer_count"`
+	TxPoolSize      int               `json:"tx_pool_size"`
+}
+
+// PeerInfo is the information of the connected peer
+// Latency is the round trip time of the handshake in milliseconds
+type PeerInfo struct {
+	PublicHash    string  `json:"public_hash"`
+	ConnectedTime int64   `json:"connected_time"`
+	Latency       float64 `json:"latency"`
+	Height        uint32  `json:"height"`
+}
+
+// SyncStatus is the sync progress of the node versus the best peer
+type SyncStatus struct {
+	Height         uint32 `json:"height"`
+	BestPeer       string `json:"best_peer"`
+	BestPeerHeight uint32 `json:"best_peer_height"`
+	RemainBlocks   uint32 `json:"remain_blocks"`
+	QueuedBlocks   int    `json:"queued_blocks"`
+	IsSyncing      bool   `json:"is_syncing"`
+}
+
+// PoolTransaction is the transaction in the transaction pool
+type PoolTransaction struct {
+	TxHash    string      `json:"tx_hash"`
+	Type      uint16      `json:"type"`
+	Timestamp uint64      `json:"timestamp"`
+	Signers   []string    `json:"signers"`
+	Tx        interface{} `json:"tx"`
+}
+
+// NodeInfo returns the information of the node
+func (nd *Node) NodeInfo() (*NodeInfo, error) {
+	cp := nd.cn.Provider()
+	GenesisHash, err := cp.Hash(0)
+	if err != nil {
+		return nil, err
+	}
+	height, lastHash := cp.LastStatus()
+	info := &NodeInfo{
+		PublicHash:      nd.myPublicHash.String(),
+		ChainID:         cp.ChainID(),
+		Symbol:          cp.Symbol(),
+		Usage:           cp.Usage(),
+		Version:         cp.Version(),
+		ProcessVersions: map[string]string{},
+		GenesisHash:     GenesisHash.String(),
+		Height:          height,
+		LastHash:        lastHash.String(),
+		LastTimestamp:   cp.LastTimestamp(),
+		PeerCount:       len(nd.ms.Peers()),
+		TxPoolSize:      nd.txpool.Size(),
+	}
+	for _, p := range nd.cn.Processes() {
+		info.ProcessVersions[p.Name()] = p.Version()
+	}
+	return info, nil
+}
+
+// PeerInfos returns the information of connected peers that is sorted by the public hash
+func (nd *Node) PeerInfos() []*PeerInfo {
+	peers := nd.ms.Peers()
+	infos := make([]*PeerInfo, 0, len(peers))
+	nd.statusLock.Lock()
+	for _, p := range peers {
+		info := &PeerInfo{
+			PublicHash:    p.Name(),
+			ConnectedTime: p.ConnectedTime(),
+			Latency:       float64(nd.ms.PeerLatency(p.ID())) / float64(time.Millisecond),
+		}
+		if status, has := nd.statusMap[p.ID()]; has {
+			info.Height = status.Height
+		}
+		infos = append(infos, info)
+	}
+	nd.statusLock.Unlock()
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].PublicHash < infos[j].PublicHash
+	})
+	return infos
+}
+
+// SyncStatus returns the sync progress of the node versus the best peer
+func (nd *Node) SyncStatus() *SyncStatus {
+	ss := &SyncStatus{
+		Height:       nd.cn.Provider().Height(),
+		QueuedBlocks: nd.blockQ.Size(),
+	}
+	nd.statusLock.Lock()
+	for ID, status := range nd.statusMap {
+		if ss.BestPeerHeight < status.Height {
+			ss.BestPeer = common.PublicHashFromPeerID(ID).String()
+			ss.BestPeerHeight = status.Height
+		}
+	}
+	nd.statusLock.Unlock()
+	if ss.Height < ss.BestPeerHeight {
+		ss.RemainBlocks = ss.BestPeerHeight - ss.Height
+		ss.IsSyncing = true
+	}
+	return ss
+}
+
+// PoolTransactions returns transactions in the transaction pool up to the limit in the timestamp order
+func (nd *Node) PoolTransactions(limit int) []*PoolTransaction {
+	items := nd.txpool.List()
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Transaction.Timestamp() < items[j].Transaction.Timestamp()
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	txs := make([]*PoolTransaction, 0, len(items))
+	for _, item := range items {
+		signers := make([]string, 0, len(item.Signers))
+		for _, pubhash := range item.Signers {
+			signers = append(signers, pubhash.String())
+		}
+		txs = append(txs, &PoolTransaction{
+			TxHash:    item.TxHash.String(),
+			Type:      item.TxType,
+			Timestamp: item.Transaction.Timestamp(),
+			Signers:   signers,
+			Tx:        item.Transaction,
+		})
+	}
+	return txs
+}
+
+// DisconnectPeer closes the connection of the peer of the public hash
+func (nd *Node) DisconnectPeer(pubhash common.PublicHash) error {
+	ID := string(pubhash[:])
+	if nd.ms.GetPeer(ID) == nil {
+		return ErrNotExistPeer
+	}
+	nd.ms.RemovePeer(ID)
+	return nil
+}
+
+// RequestBlocks requests blocks after the current height to peers that have higher blocks
+func (nd *Node) RequestBlocks() {
+	nd.tryRequestBlocks()
+}
+
+// NewAdminAPI returns the api server that has methods to inspect and control the node
+// it is not registered to the public api server and should be run on the loopback address because it exposes peers and the transaction pool
+// methods are in the admin namespace of this api server, it is separated from the shared admin namespace of the public api server that has council queries
+func (nd *Node) NewAdminAPI() (*apiserver.APIServer, error) {
+	as := apiserver.NewAPIServer()
+	s, err := as.JRPC("admin")
+	if err != nil {
+		return nil, err
+	}
+	s.Set("nodeInfo", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+		return nd.NodeInfo()
+	})
+	s.Set("peers", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+		return nd.PeerInfos(), nil
+	})
+	s.Set("syncStatus", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+		return nd.SyncStatus(), nil
+	})
+	s.Set("txPool", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+		limit := 100
+		if arg.Len() > 0 {
+			v, err := arg.Int(0)
+			if err != nil {
+				return nil, err
+			}
+			limit = v
+		}
+		return nd.PoolTransactions(limit), nil
+	})
+	s.Set("txPoolSize", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+		return nd.TxPoolSize(), nil
+	})
+	s.Set("disconnectPeer", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+		if arg.Len() != 1 {
+			return nil, apiserver.ErrInvalidArgument
+		}
+		arg0, err := arg.String(0)
+		if err != nil {
+			return nil, err
+		}
+		pubhash, err := common.ParsePublicHash(arg0)
+		if err != nil {
+			return nil, err
+		}
+		if err := nd.DisconnectPeer(pubhash); err != nil {
+			return nil, err
+		}
+		return true, nil
+	})
+	s.Set("requestBlocks", func(ID interface{}, arg *apiserver.Argument) (interface{}, error) {
+		go nd.RequestBlocks()
+		return nd.SyncStatus(), nil
+	})
+	return as, nil
+}
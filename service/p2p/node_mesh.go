@@ -2,7 +2,6 @@ package p2p
 
 import (
 	crand "crypto/rand"
-	"log"
 	"net"
 	"sort"
 	"sync"
@@ -12,7 +11,6 @@ import (
 	"github.com/fletaio/fleta_testnet/common/binutil"
 	"github.com/fletaio/fleta_testnet/common/hash"
 	"github.com/fletaio/fleta_testnet/common/key"
-	"github.com/fletaio/fleta_testnet/common/rlog"
 	"github.com/fletaio/fleta_testnet/core/chain"
 	"github.com/fletaio/fleta_testnet/service/p2p/nodepoolmanage"
 	"github.com/fletaio/fleta_testnet/service/p2p/peer"
@@ -38,6 +36,7 @@ type NodeMesh struct {
 	badPointMap     map[string]int
 	clientPeerMap   map[string]peer.Peer
 	serverPeerMap   map[string]peer.Peer
+	latencyMap      map[string]time.Duration
 	nodePoolManager nodepoolmanage.Manager
 }
 
@@ -53,6 +52,7 @@ func NewNodeMesh(ChainID uint8, key key.Key, SeedNodeMap map[common.PublicHash]s
 		badPointMap:   map[string]int{},
 		clientPeerMap: map[string]peer.Peer{},
 		serverPeerMap: map[string]peer.Peer{},
+		latencyMap:    map[string]time.Duration{},
 	}
 	manager, err := nodepoolmanage.NewNodePoolManage(peerStorePath, ms, ms.myPublicHash)
 	if err != nil {
@@ -86,7 +86,7 @@ func (ms *NodeMesh) Run(BindAddress string) {
 					}
 					if !hasC && !hasS {
 						if err := ms.client(NetAddr, pubhash); err != nil {
-							rlog.Println("[client]", err, NetAddr)
+							logger.Debug("Peer connection failed", "addr", NetAddr, "err", err)
 						}
 					}
 					time.Sleep(30 * time.Second)
@@ -139,6 +139,14 @@ func (ms *NodeMesh) Peers() []peer.Peer {
 	return peers
 }
 
+// PeerLatency returns the handshake round trip time of the peer
+func (ms *NodeMesh) PeerLatency(ID string) time.Duration {
+	ms.Lock()
+	defer ms.Unlock()
+
+	return ms.latencyMap[ID]
+}
+
 // AddBadPoint adds bad points to to the peer
 func (ms *NodeMesh) AddBadPoint(ID string, Point int) {
 	ms.Lock()
@@ -164,6 +172,7 @@ func (ms *NodeMesh) RemovePeer(ID string) {
 	if hasClient || hasServer {
 		ms.updatePeerIDs()
 		delete(ms.badPointMap, ID)
+		delete(ms.latencyMap, ID)
 	}
 	ms.Unlock()
 
@@ -233,6 +242,8 @@ func (ms *NodeMesh) SendTo(pubhash common.PublicHash, bs []byte) {
 
 	if p != nil {
 		p.SendPacket(bs)
+		sentPackets.Inc()
+		sentBytes.Add(uint64(len(bs)))
 	}
 }
 
@@ -256,6 +267,8 @@ func (ms *NodeMesh) ExceptCast(ID string, bs []byte) {
 	for _, p := range peerMap {
 		p.SendPacket(bs)
 	}
+	sentPackets.Add(uint64(len(peerMap)))
+	sentBytes.Add(uint64(len(peerMap) * len(bs)))
 }
 
 // BroadcastPacket sends a packet to all peers
@@ -273,6 +286,8 @@ func (ms *NodeMesh) BroadcastPacket(bs []byte) {
 	for _, p := range peerMap {
 		p.SendPacket(bs)
 	}
+	sentPackets.Add(uint64(len(peerMap)))
+	sentBytes.Add(uint64(len(peerMap) * len(bs)))
 }
 
 func (ms *NodeMesh) RequestConnect(Address string, TargetPubHash common.PublicHash) {
@@ -304,7 +319,7 @@ func (ms *NodeMesh) AddPeerList(ips []string, hashs []string) {
 }
 
 func (ms *NodeMesh) client(Address string, TargetPubHash common.PublicHash) error {
-	log.Println("ConnectingTo", Address, TargetPubHash.String())
+	logger.Debug("Connecting to the peer", "addr", Address, "peer", TargetPubHash)
 
 	if TargetPubHash == ms.myPublicHash {
 		ms.nodePoolManager.Ban(string(TargetPubHash[:]))
@@ -319,12 +334,12 @@ func (ms *NodeMesh) client(Address string, TargetPubHash common.PublicHash) erro
 
 	start := time.Now()
 	if err := ms.recvHandshake(conn); err != nil {
-		rlog.Println("[recvHandshake]", err)
+		logger.Debug("Peer handshake failed", "step", "recv", "err", err)
 		return err
 	}
 	pubhash, bindAddress, err := ms.sendHandshake(conn)
 	if err != nil {
-		rlog.Println("[sendHandshake]", err)
+		logger.Debug("Peer handshake failed", "step", "send", "err", err)
 		return err
 	}
 	if pubhash == ms.myPublicHash {
@@ -335,7 +350,7 @@ func (ms *NodeMesh) client(Address string, TargetPubHash common.PublicHash) erro
 	if pubhash != TargetPubHash {
 		return common.ErrInvalidPublicHash
 	}
-	//duration := time.Since(start)
+	duration := time.Since(start)
 	var ipAddress string
 	if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
 		ipAddress = addr.IP.String()
@@ -349,6 +364,7 @@ func (ms *NodeMesh) client(Address string, TargetPubHash common.PublicHash) erro
 	ms.Lock()
 	old, has := ms.clientPeerMap[ID]
 	ms.clientPeerMap[ID] = p
+	ms.latencyMap[ID] = duration
 	if !has {
 		ms.updatePeerIDs()
 	}
@@ -359,7 +375,7 @@ func (ms *NodeMesh) client(Address string, TargetPubHash common.PublicHash) erro
 	defer ms.removePeerInMap(p.ID(), ms.clientPeerMap)
 
 	if err := ms.handleConnection(p); err != nil {
-		rlog.Println("[handleConnection]", err)
+		logger.Debug("Peer disconnected", "peer", p.Name(), "err", err)
 	}
 	return nil
 }
@@ -369,7 +385,7 @@ func (ms *NodeMesh) server(BindAddress string) error {
 	if err != nil {
 		return err
 	}
-	rlog.Println(common.NewPublicHash(ms.key.PublicKey()), "Start to Listen", BindAddress)
+	logger.Info("Node mesh listens", "public_hash", common.NewPublicHash(ms.key.PublicKey()), "addr", BindAddress)
 	for {
 		conn, err := lstn.Accept()
 		if err != nil {
@@ -381,7 +397,7 @@ func (ms *NodeMesh) server(BindAddress string) error {
 			start := time.Now()
 			pubhash, bindAddress, err := ms.sendHandshake(conn)
 			if err != nil {
-				rlog.Println("[sendHandshake]", err)
+				logger.Debug("Peer handshake failed", "step", "send", "err", err)
 				return
 			}
 			if pubhash == ms.myPublicHash {
@@ -390,10 +406,10 @@ func (ms *NodeMesh) server(BindAddress string) error {
 				return
 			}
 			if err := ms.recvHandshake(conn); err != nil {
-				rlog.Println("[recvHandshakeAck]", err)
+				logger.Debug("Peer handshake failed", "step", "recv ack", "err", err)
 				return
 			}
-			//duration := time.Since(start)
+			duration := time.Since(start)
 			var ipAddress string
 			if addr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
 				ipAddress = addr.IP.String()
@@ -404,11 +420,12 @@ func (ms *NodeMesh) server(BindAddress string) error {
 			//ms.nodePoolManager.NewNode(ipAddress, ID, duration)
 			p := NewTCPAsyncPeer(conn, ID, pubhash.String(), start.UnixNano())
 
-			log.Println("ConnectedFrom", pubhash.String())
+			logger.Debug("Peer connected", "peer", pubhash)
 
 			ms.Lock()
 			old, has := ms.serverPeerMap[ID]
 			ms.serverPeerMap[ID] = p
+			ms.latencyMap[ID] = duration
 			if !has {
 				ms.updatePeerIDs()
 			}
@@ -419,7 +436,7 @@ func (ms *NodeMesh) server(BindAddress string) error {
 			defer ms.removePeerInMap(p.ID(), ms.serverPeerMap)
 
 			if err := ms.handleConnection(p); err != nil {
-				rlog.Println("[handleConnection]", err)
+				logger.Debug("Peer disconnected", "peer", p.Name(), "err", err)
 			}
 		}()
 	}
@@ -428,6 +445,9 @@ func (ms *NodeMesh) server(BindAddress string) error {
 func (ms *NodeMesh) handleConnection(p peer.Peer) error {
 	// rlog.Println("Node", common.NewPublicHash(ms.key.PublicKey()).String(), "Node Connected", p.Name())
 
+	peersGauge.Inc()
+	defer peersGauge.Dec()
+
 	ms.handler.OnConnected(p)
 	defer ms.handler.OnDisconnected(p)
 
@@ -436,6 +456,8 @@ func (ms *NodeMesh) handleConnection(p peer.Peer) error {
 		if err != nil {
 			return err
 		}
+		recvMessages.With(PacketMessageName(bs)).Inc()
+		recvBytes.Add(uint64(len(bs)))
 		if err := ms.handler.OnRecv(p, bs); err != nil {
 			return err
 		}
@@ -0,0 +1,6 @@
+package nodepoolmanage
+
+import "github.com/fletaio/fleta_testnet/common/logging"
+
+// logger writes logs of the peer pool
+var logger = logging.New("p2p")
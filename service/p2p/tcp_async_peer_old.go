@@ -1,7 +1,6 @@
 package p2p
 
 import (
-	"log"
 	"net"
 	"sync/atomic"
 	"time"
@@ -66,12 +65,12 @@ func NewTCPAsyncPeer(conn net.Conn, ID string, Name string, connectedTime int64)
 				}
 				bs := v.([]byte)
 				if err := p.conn.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
-					log.Println(p.name, "SendPacket.SetWriteDeadline", err)
+					logger.Debug("Send failed", "peer", p.name, "err", err)
 					p.Close()
 					return
 				}
 				if _, err := p.conn.Write(bs); err != nil {
-					log.Println(p.name, "SendPacket.Write", err)
+					logger.Debug("Send failed", "peer", p.name, "err", err)
 					p.Close()
 					return
 				}
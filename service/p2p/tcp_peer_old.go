@@ -1,7 +1,6 @@
 package p2p
 
 import (
-	"log"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -118,12 +117,12 @@ func (p *TCPPeer) SendPacket(bs []byte) {
 	defer p.Unlock()
 
 	if err := p.conn.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
-		log.Println(p.name, "SendPacket.SetWriteDeadline", err)
+		logger.Debug("Send failed", "peer", p.name, "err", err)
 		p.Close()
 		return
 	}
 	if _, err := p.conn.Write(bs); err != nil {
-		log.Println(p.name, "SendPacket.Write", err)
+		logger.Debug("Send failed", "peer", p.name, "err", err)
 		p.Close()
 		return
 	}
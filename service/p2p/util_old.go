@@ -5,6 +5,7 @@ import (
 	"compress/gzip"
 	"io"
 	"io/ioutil"
+	"strings"
 
 	"github.com/bluele/gcache"
 	"github.com/fletaio/fleta_testnet/common/binutil"
@@ -184,6 +185,21 @@ func PacketMessageType(bs []byte) uint16 {
 	return binutil.LittleEndian.Uint16(bs)
 }
 
+// PacketMessageName returns the short type name of the message of the packet
+func PacketMessageName(bs []byte) string {
+	if len(bs) < 2 {
+		return "unknown"
+	}
+	name, err := encoding.Factory("message").TypeName(PacketMessageType(bs))
+	if err != nil {
+		return "unknown"
+	}
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return name
+}
+
 func PacketToMessage(bs []byte) (interface{}, error) {
 	t := PacketMessageType(bs)
 	//compressed := true
@@ -1,7 +1,6 @@
 package p2p
 
 import (
-	"log"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -97,12 +96,12 @@ func (p *WebsocketPeer) SendPacket(bs []byte) {
 	defer p.Unlock()
 
 	if err := p.conn.SetWriteDeadline(time.Now().Add(5 * time.Second)); err != nil {
-		log.Println(p.name, "SendPacket", err)
+		logger.Debug("Send failed", "peer", p.name, "err", err)
 		p.Close()
 		return
 	}
 	if err := p.conn.WriteMessage(websocket.BinaryMessage, bs); err != nil {
-		log.Println(p.name, "SendPacket", err)
+		logger.Debug("Send failed", "peer", p.name, "err", err)
 		p.Close()
 		return
 	}
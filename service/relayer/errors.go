@@ -0,0 +1,10 @@
+package relayer
+
+import "errors"
+
+// errors
+var (
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrPaidBatch           = errors.New("paid batch")
+	ErrInvalidAmount       = errors.New("invalid amount")
+)
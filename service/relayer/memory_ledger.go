@@ -0,0 +1,145 @@
+package relayer
+
+import (
+	"sync"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/binutil"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/process/gateway"
+)
+
+// MemoryLedger is an in-memory erc20 ledger that stands in for the ethereum chain
+// The gateway holds deposited tokens and pays out withdrawal batches from them
+type MemoryLedger struct {
+	sync.Mutex
+	Gateway    gateway.ERC20Address
+	balanceMap map[gateway.ERC20Address]*amount.Amount
+	deposits   []*Deposit
+	paidMap    map[uint64]hash.Hash256
+	txCount    uint64
+}
+
+// NewMemoryLedger returns a MemoryLedger
+func NewMemoryLedger(Gateway gateway.ERC20Address) *MemoryLedger {
+	lg := &MemoryLedger{
+		Gateway:    Gateway,
+		balanceMap: map[gateway.ERC20Address]*amount.Amount{},
+		deposits:   []*Deposit{},
+		paidMap:    map[uint64]hash.Hash256{},
+	}
+	return lg
+}
+
+// BalanceOf returns the token balance of the erc20 address
+func (lg *MemoryLedger) BalanceOf(addr gateway.ERC20Address) *amount.Amount {
+	lg.Lock()
+	defer lg.Unlock()
+
+	return lg.balanceOf(addr)
+}
+
+func (lg *MemoryLedger) balanceOf(addr gateway.ERC20Address) *amount.Amount {
+	if am, has := lg.balanceMap[addr]; has {
+		return am.Clone()
+	} else {
+		return amount.NewCoinAmount(0, 0)
+	}
+}
+
+// Mint issues tokens to the erc20 address
+func (lg *MemoryLedger) Mint(addr gateway.ERC20Address, am *amount.Amount) {
+	lg.Lock()
+	defer lg.Unlock()
+
+	lg.balanceMap[addr] = lg.balanceOf(addr).Add(am)
+}
+
+// Deposit transfers tokens to the gateway for the recipient of the chain and returns the erc20 txid
+func (lg *MemoryLedger) Deposit(From gateway.ERC20Address, To common.Address, am *amount.Amount) (hash.Hash256, error) {
+	lg.Lock()
+	defer lg.Unlock()
+
+	if err := lg.transfer(From, lg.Gateway, am); err != nil {
+		return hash.Hash256{}, err
+	}
+	TXID := lg.nextTXID()
+	lg.deposits = append(lg.deposits, &Deposit{
+		ERC20TXID: TXID,
+		ERC20From: From,
+		To:        To,
+		Amount:    am.Clone(),
+	})
+	return TXID, nil
+}
+
+// ReplayDeposit appends the deposit again as a reorganized ethereum chain would report it
+func (lg *MemoryLedger) ReplayDeposit(ERC20TXID hash.Hash256) bool {
+	lg.Lock()
+	defer lg.Unlock()
+
+	for _, d := range lg.deposits {
+		if d.ERC20TXID == ERC20TXID {
+			lg.deposits = append(lg.deposits, d)
+			return true
+		}
+	}
+	return false
+}
+
+// Deposits returns deposits to the gateway after the cursor and the next cursor
+func (lg *MemoryLedger) Deposits(cursor uint64) ([]*Deposit, uint64, error) {
+	lg.Lock()
+	defer lg.Unlock()
+
+	if cursor >= uint64(len(lg.deposits)) {
+		return []*Deposit{}, cursor, nil
+	}
+	list := make([]*Deposit, len(lg.deposits)-int(cursor))
+	copy(list, lg.deposits[cursor:])
+	return list, uint64(len(lg.deposits)), nil
+}
+
+// TransferBatch pays out items of the withdrawal batch that are not refunded from the gateway and returns the erc20 txid of the payout
+func (lg *MemoryLedger) TransferBatch(wb *gateway.WithdrawalBatch) (hash.Hash256, error) {
+	lg.Lock()
+	defer lg.Unlock()
+
+	if _, has := lg.paidMap[wb.Number]; has {
+		return hash.Hash256{}, ErrPaidBatch
+	}
+	total := amount.NewCoinAmount(0, 0)
+	for _, item := range wb.PayoutItems() {
+		total = total.Add(item.Amount)
+	}
+	if lg.balanceOf(lg.Gateway).Less(total) {
+		return hash.Hash256{}, ErrInsufficientBalance
+	}
+	for _, item := range wb.PayoutItems() {
+		if err := lg.transfer(lg.Gateway, item.ERC20To, item.Amount); err != nil {
+			return hash.Hash256{}, err
+		}
+	}
+	TXID := lg.nextTXID()
+	lg.paidMap[wb.Number] = TXID
+	return TXID, nil
+}
+
+func (lg *MemoryLedger) transfer(From gateway.ERC20Address, To gateway.ERC20Address, am *amount.Amount) error {
+	if am.IsZero() || am.Less(amount.NewCoinAmount(0, 0)) {
+		return ErrInvalidAmount
+	}
+	fromBalance := lg.balanceOf(From)
+	if fromBalance.Less(am) {
+		return ErrInsufficientBalance
+	}
+	lg.balanceMap[From] = fromBalance.Sub(am)
+	lg.balanceMap[To] = lg.balanceOf(To).Add(am)
+	return nil
+}
+
+func (lg *MemoryLedger) nextTXID() hash.Hash256 {
+	lg.txCount++
+	return hash.Hash(binutil.LittleEndian.Uint64ToBytes(lg.txCount))
+}
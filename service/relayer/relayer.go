@@ -0,0 +1,151 @@
+package relayer
+
+import (
+	"sync"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/common/key"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/gateway"
+)
+
+// Relayer relays deposits of the source chain to the gateway process and pays out sealed withdrawal batches to the source chain
+// It signs transactions by the gateway admin key and attests them by relayer keys
+type Relayer struct {
+	sync.Mutex
+	src        SourceChain
+	gw         *gateway.Gateway
+	cn         types.Provider
+	adminAddr  common.Address
+	adminKey   key.Key
+	attestKeys []key.Key
+	cursor     uint64
+	depositMap map[hash.Hash256]*Deposit
+	nextBatch  uint64
+	payoutMap  map[uint64]hash.Hash256
+}
+
+// RelayTransaction is a signed transaction that should be submitted to the chain
+type RelayTransaction struct {
+	Tx         types.Transaction
+	Signatures []common.Signature
+}
+
+// NewRelayer returns a Relayer
+func NewRelayer(src SourceChain, gw *gateway.Gateway, cn types.Provider, adminAddr common.Address, adminKey key.Key, attestKeys []key.Key) *Relayer {
+	r := &Relayer{
+		src:        src,
+		gw:         gw,
+		cn:         cn,
+		adminAddr:  adminAddr,
+		adminKey:   adminKey,
+		attestKeys: attestKeys,
+		depositMap: map[hash.Hash256]*Deposit{},
+		nextBatch:  1,
+		payoutMap:  map[uint64]hash.Hash256{},
+	}
+	return r
+}
+
+// Relay returns transactions that apply new deposits and payouts to the chain
+// Transactions of deposits and payouts that are not applied yet are returned again with the given timestamp
+func (r *Relayer) Relay(Timestamp uint64) ([]*RelayTransaction, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	deposits, next, err := r.src.Deposits(r.cursor)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deposits {
+		r.depositMap[d.ERC20TXID] = d
+	}
+	r.cursor = next
+
+	loader := r.cn.NewLoaderWrapper(r.gw.ID())
+	list := []*RelayTransaction{}
+	for TXID, d := range r.depositMap {
+		if r.gw.HasERC20TXID(loader, TXID) {
+			delete(r.depositMap, TXID)
+			continue
+		}
+		tx := &gateway.TokenIn{
+			Timestamp_:  Timestamp,
+			From_:       r.adminAddr,
+			ERC20TXID:   d.ERC20TXID,
+			ERC20From:   d.ERC20From,
+			ToAddresses: []common.Address{d.To},
+			Amounts:     []*amount.Amount{d.Amount},
+		}
+		if tx.Attestations, err = r.attest(tx.AttestationHash(r.cn.ChainID())); err != nil {
+			return nil, err
+		}
+		rt, err := r.sign(tx)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, rt)
+	}
+
+	current := r.gw.CurrentBatchNumber(loader)
+	for num := r.nextBatch; num < current; num++ {
+		wb, err := r.gw.Batch(loader, num)
+		if err != nil {
+			return nil, err
+		}
+		if wb.Status == gateway.BatchCompleted {
+			delete(r.payoutMap, num)
+			if num == r.nextBatch {
+				r.nextBatch++
+			}
+			continue
+		}
+		ERC20TXID, has := r.payoutMap[num]
+		if !has {
+			if ERC20TXID, err = r.src.TransferBatch(wb); err != nil {
+				return nil, err
+			}
+			r.payoutMap[num] = ERC20TXID
+		}
+		tx := &gateway.CompleteBatch{
+			Timestamp_:  Timestamp,
+			From_:       r.adminAddr,
+			BatchNumber: num,
+			ERC20TXID:   ERC20TXID,
+		}
+		if tx.Attestations, err = r.attest(wb.CompletionHash(r.cn.ChainID(), ERC20TXID)); err != nil {
+			return nil, err
+		}
+		rt, err := r.sign(tx)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, rt)
+	}
+	return list, nil
+}
+
+func (r *Relayer) attest(h hash.Hash256) ([]common.Signature, error) {
+	sigs := make([]common.Signature, 0, len(r.attestKeys))
+	for _, k := range r.attestKeys {
+		sig, err := k.Sign(h)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs, nil
+}
+
+func (r *Relayer) sign(tx types.Transaction) (*RelayTransaction, error) {
+	sig, err := r.adminKey.Sign(types.HashTransaction(r.cn.ChainID(), tx))
+	if err != nil {
+		return nil, err
+	}
+	return &RelayTransaction{
+		Tx:         tx,
+		Signatures: []common.Signature{sig},
+	}, nil
+}
@@ -0,0 +1,368 @@
+package relayer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/key"
+	"github.com/fletaio/fleta_testnet/core/backend"
+	_ "github.com/fletaio/fleta_testnet/core/backend/buntdb_driver"
+	"github.com/fletaio/fleta_testnet/core/chain"
+	"github.com/fletaio/fleta_testnet/core/pile"
+	"github.com/fletaio/fleta_testnet/core/types"
+	"github.com/fletaio/fleta_testnet/process/admin"
+	"github.com/fletaio/fleta_testnet/process/gateway"
+	"github.com/fletaio/fleta_testnet/process/vault"
+)
+
+type testConsensus struct {
+	chain.ConsensusBase
+	ct chain.Committer
+}
+
+func (cs *testConsensus) Init(cn *chain.Chain, ct chain.Committer) error {
+	cs.ct = ct
+	return nil
+}
+
+type testApp struct {
+	*types.ApplicationBase
+	pm        types.ProcessManager
+	adminAddr common.Address
+	adminKey  key.Key
+	userAddr  common.Address
+	userKey   key.Key
+	relayers  []common.PublicHash
+}
+
+func (app *testApp) Name() string {
+	return "TestApp"
+}
+
+func (app *testApp) Version() string {
+	return "v1.0.0"
+}
+
+func (app *testApp) Init(reg *types.Register, pm types.ProcessManager, cn types.Provider) error {
+	app.pm = pm
+	return nil
+}
+
+func (app *testApp) InitGenesis(ctw *types.ContextWrapper) error {
+	ap, _ := app.pm.ProcessByName("fleta.admin")
+	if err := ap.(*admin.Admin).InitAdmin(ctw, map[string]common.Address{
+		"fleta.gateway": app.adminAddr,
+		"fleta.vault":   app.adminAddr,
+	}); err != nil {
+		return err
+	}
+	vp, _ := app.pm.ProcessByName("fleta.vault")
+	sp := vp.(*vault.Vault)
+	if err := sp.InitPolicy(ctw, &vault.Policy{
+		AccountCreationAmount: amount.NewCoinAmount(10, 0),
+	}); err != nil {
+		return err
+	}
+	gp, _ := app.pm.ProcessByName("fleta.gateway")
+	if err := gp.(*gateway.Gateway).InitPolicy(ctw, &gateway.Policy{
+		WithdrawFee:          amount.NewCoinAmount(1, 0),
+		BatchInterval:        2,
+		MaxBatchSize:         10,
+		WithdrawFeeRatio1000: 0,
+		MinWithdrawFee:       amount.NewCoinAmount(0, 0),
+		MaxWithdrawFee:       amount.NewCoinAmount(0, 0),
+		AddressDailyLimit:    amount.NewCoinAmount(0, 0),
+		GlobalDailyLimit:     amount.NewCoinAmount(0, 0),
+		BlocksPerDay:         172800,
+	}); err != nil {
+		return err
+	}
+	if err := gp.(*gateway.Gateway).InitRelayerSet(ctw, &gateway.RelayerSet{
+		Relayers:  app.relayers,
+		Threshold: 2,
+	}); err != nil {
+		return err
+	}
+	for _, acc := range []*vault.SingleAccount{
+		{Address_: app.adminAddr, Name_: "fleta.gateway", KeyHash: common.NewPublicHash(app.adminKey.PublicKey())},
+		{Address_: app.userAddr, Name_: "user", KeyHash: common.NewPublicHash(app.userKey.PublicKey())},
+	} {
+		if err := ctw.CreateAccount(acc); err != nil {
+			return err
+		}
+	}
+	if err := sp.AddBalance(ctw, app.adminAddr, amount.NewCoinAmount(1000000, 0)); err != nil {
+		return err
+	}
+	return sp.AddBalance(ctw, app.userAddr, amount.NewCoinAmount(100, 0))
+}
+
+type sandbox struct {
+	t          *testing.T
+	dir        string
+	st         *chain.Store
+	cn         *chain.Chain
+	cs         *testConsensus
+	app        *testApp
+	gw         *gateway.Gateway
+	vt         *vault.Vault
+	attestKeys []key.Key
+	timestamp  uint64
+}
+
+func newSandbox(t *testing.T) *sandbox {
+	dir, err := ioutil.TempDir("", "relayer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := backend.Create("buntdb", filepath.Join(dir, "context"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdb, err := pile.Open(filepath.Join(dir, "chain"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := chain.NewStore(back, cdb, 0x01, "FLETA", "Sandbox", 0x0001)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := &testApp{
+		adminAddr: common.NewAddress(0, 1, 0),
+		adminKey:  mustKey(t),
+		userAddr:  common.NewAddress(0, 2, 0),
+		userKey:   mustKey(t),
+	}
+	attestKeys := []key.Key{mustKey(t), mustKey(t), mustKey(t)}
+	for _, k := range attestKeys {
+		app.relayers = append(app.relayers, common.NewPublicHash(k.PublicKey()))
+	}
+
+	cs := &testConsensus{}
+	cn := chain.NewChain(cs, app, st)
+	cn.MustAddProcess(admin.NewAdmin(1))
+	vt := vault.NewVault(2)
+	cn.MustAddProcess(vt)
+	gw := gateway.NewGateway(4)
+	cn.MustAddProcess(gw)
+	if err := cn.Init(); err != nil {
+		t.Fatal(err)
+	}
+	return &sandbox{
+		t:          t,
+		dir:        dir,
+		st:         st,
+		cn:         cn,
+		cs:         cs,
+		app:        app,
+		gw:         gw,
+		vt:         vt,
+		attestKeys: attestKeys,
+		timestamp:  uint64(time.Now().UnixNano()),
+	}
+}
+
+func (sb *sandbox) Close() {
+	sb.cn.Close()
+	os.RemoveAll(sb.dir)
+}
+
+// nextTimestamp returns the timestamp of the next block
+func (sb *sandbox) nextTimestamp() uint64 {
+	return sb.timestamp + uint64(time.Second)
+}
+
+// commit connects a block that includes transactions and returns errors of rejected transactions
+func (sb *sandbox) commit(rts ...*RelayTransaction) []error {
+	sb.timestamp = sb.nextTimestamp()
+	ctx := sb.cs.ct.NewContext()
+	bc := chain.NewBlockCreator(sb.cn, ctx, sb.app.adminAddr, nil, sb.timestamp)
+	if err := bc.Init(); err != nil {
+		sb.t.Fatal(err)
+	}
+	errs := []error{}
+	for _, rt := range rts {
+		if err := bc.AddTx(sb.app.adminAddr, rt.Tx, rt.Signatures); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	b, err := bc.Finalize()
+	if err != nil {
+		sb.t.Fatal(err)
+	}
+	if err := sb.cs.ct.ConnectBlockWithContext(b, ctx); err != nil {
+		sb.t.Fatal(err)
+	}
+	return errs
+}
+
+func (sb *sandbox) relay(r *Relayer) []*RelayTransaction {
+	rts, err := r.Relay(sb.nextTimestamp())
+	if err != nil {
+		sb.t.Fatal(err)
+	}
+	return rts
+}
+
+func (sb *sandbox) loader() types.LoaderWrapper {
+	return sb.cn.Provider().NewLoaderWrapper(sb.gw.ID())
+}
+
+func mustKey(t *testing.T) key.Key {
+	k, err := key.NewMemoryKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestDepositWithdrawRoundTrip(t *testing.T) {
+	sb := newSandbox(t)
+	defer sb.Close()
+
+	lg := NewMemoryLedger(gateway.ERC20Address{0xff})
+	r := NewRelayer(lg, sb.gw, sb.cn.Provider(), sb.app.adminAddr, sb.app.adminKey, sb.attestKeys[:2])
+
+	userERC20 := gateway.ERC20Address{0x01}
+	lg.Mint(userERC20, amount.NewCoinAmount(100, 0))
+	if _, err := lg.Deposit(userERC20, sb.app.userAddr, amount.NewCoinAmount(50, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	rts := sb.relay(r)
+	if len(rts) != 1 {
+		t.Fatalf("expected 1 relay transaction, got %d", len(rts))
+	}
+	if errs := sb.commit(rts...); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if b := sb.vt.Balance(sb.loader(), sb.app.userAddr); !b.Equal(amount.NewCoinAmount(150, 0)) {
+		t.Fatalf("unexpected balance after deposit %v", b.String())
+	}
+	if rts := sb.relay(r); len(rts) != 0 {
+		t.Fatalf("expected no relay transaction, got %d", len(rts))
+	}
+
+	out := &gateway.TokenOut{
+		Timestamp_: sb.nextTimestamp(),
+		From_:      sb.app.userAddr,
+		ERC20To:    userERC20,
+		Amount:     amount.NewCoinAmount(20, 0),
+	}
+	sig, err := sb.app.userKey.Sign(types.HashTransaction(sb.cn.Provider().ChainID(), out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := sb.commit(&RelayTransaction{Tx: out, Signatures: []common.Signature{sig}}); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	TXID := types.TransactionID(sb.cn.Provider().Height(), 0)
+	if num, err := sb.gw.OutBatchNumber(sb.loader(), TXID); err != nil || num != 1 {
+		t.Fatalf("unexpected out batch %v %v", num, err)
+	}
+	sb.commit()
+	sb.commit()
+
+	wb, err := sb.gw.Batch(sb.loader(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wb.Status != gateway.BatchSealed {
+		t.Fatalf("expected sealed batch, got %v", wb.Status)
+	}
+
+	rts = sb.relay(r)
+	if len(rts) != 1 {
+		t.Fatalf("expected 1 relay transaction, got %d", len(rts))
+	}
+	if errs := sb.commit(rts...); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if wb, err := sb.gw.Batch(sb.loader(), 1); err != nil {
+		t.Fatal(err)
+	} else if wb.Status != gateway.BatchCompleted {
+		t.Fatalf("expected completed batch, got %v", wb.Status)
+	}
+	if !sb.gw.HasOutTXID(sb.loader(), TXID) {
+		t.Fatal("out txid is not processed")
+	}
+	if b := lg.BalanceOf(userERC20); !b.Equal(amount.NewCoinAmount(70, 0)) {
+		t.Fatalf("unexpected erc20 balance after withdrawal %v", b.String())
+	}
+	if b := lg.BalanceOf(lg.Gateway); !b.Equal(amount.NewCoinAmount(30, 0)) {
+		t.Fatalf("unexpected gateway balance after withdrawal %v", b.String())
+	}
+	if rts := sb.relay(r); len(rts) != 0 {
+		t.Fatalf("expected no relay transaction, got %d", len(rts))
+	}
+}
+
+func TestDoubleDeposit(t *testing.T) {
+	sb := newSandbox(t)
+	defer sb.Close()
+
+	lg := NewMemoryLedger(gateway.ERC20Address{0xff})
+	r := NewRelayer(lg, sb.gw, sb.cn.Provider(), sb.app.adminAddr, sb.app.adminKey, sb.attestKeys[1:])
+
+	userERC20 := gateway.ERC20Address{0x01}
+	lg.Mint(userERC20, amount.NewCoinAmount(100, 0))
+	ERC20TXID, err := lg.Deposit(userERC20, sb.app.userAddr, amount.NewCoinAmount(50, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rts := sb.relay(r)
+	if errs := sb.commit(rts...); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	if !lg.ReplayDeposit(ERC20TXID) {
+		t.Fatal("deposit is not replayed")
+	}
+	if rts := sb.relay(r); len(rts) != 0 {
+		t.Fatalf("expected no relay transaction for the replayed deposit, got %d", len(rts))
+	}
+
+	// a forged TokenIn of the processed deposit is rejected even with valid attestations
+	forged := NewRelayer(NewMemoryLedger(lg.Gateway), sb.gw, sb.cn.Provider(), sb.app.adminAddr, sb.app.adminKey, sb.attestKeys)
+	tx := &gateway.TokenIn{
+		Timestamp_:  sb.nextTimestamp(),
+		From_:       sb.app.adminAddr,
+		ERC20TXID:   ERC20TXID,
+		ERC20From:   userERC20,
+		ToAddresses: []common.Address{sb.app.userAddr},
+		Amounts:     []*amount.Amount{amount.NewCoinAmount(50, 0)},
+	}
+	if tx.Attestations, err = forged.attest(tx.AttestationHash(sb.cn.Provider().ChainID())); err != nil {
+		t.Fatal(err)
+	}
+	rt, err := forged.sign(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := sb.commit(rt); len(errs) != 1 || errs[0] != gateway.ErrProcessedERC20TXID {
+		t.Fatalf("expected processed erc20 txid error, got %v", errs)
+	}
+
+	// a new deposit attested by a single relayer is rejected
+	single := NewRelayer(lg, sb.gw, sb.cn.Provider(), sb.app.adminAddr, sb.app.adminKey, sb.attestKeys[:1])
+	if _, err := lg.Deposit(userERC20, sb.app.userAddr, amount.NewCoinAmount(10, 0)); err != nil {
+		t.Fatal(err)
+	}
+	rts = sb.relay(single)
+	if len(rts) != 1 {
+		t.Fatalf("expected 1 relay transaction, got %d", len(rts))
+	}
+	if errs := sb.commit(rts...); len(errs) != 1 || errs[0] != gateway.ErrInsufficientAttestation {
+		t.Fatalf("expected insufficient attestation error, got %v", errs)
+	}
+
+	if b := sb.vt.Balance(sb.loader(), sb.app.userAddr); !b.Equal(amount.NewCoinAmount(150, 0)) {
+		t.Fatalf("unexpected balance after double deposit %v", b.String())
+	}
+}
@@ -0,0 +1,24 @@
+package relayer
+
+import (
+	"github.com/fletaio/fleta_testnet/common"
+	"github.com/fletaio/fleta_testnet/common/amount"
+	"github.com/fletaio/fleta_testnet/common/hash"
+	"github.com/fletaio/fleta_testnet/process/gateway"
+)
+
+// SourceChain defines functions of the erc20 side that the relayer watches and pays out to
+type SourceChain interface {
+	// Deposits returns deposits to the gateway after the cursor and the next cursor
+	Deposits(cursor uint64) ([]*Deposit, uint64, error)
+	// TransferBatch pays out items of the withdrawal batch that are not refunded and returns the erc20 txid of the payout
+	TransferBatch(wb *gateway.WithdrawalBatch) (hash.Hash256, error)
+}
+
+// Deposit is a token transfer to the gateway on the erc20 side
+type Deposit struct {
+	ERC20TXID hash.Hash256
+	ERC20From gateway.ERC20Address
+	To        common.Address
+	Amount    *amount.Amount
+}